@@ -0,0 +1,92 @@
+// fakeupbit는 로컬 개발과 통합 테스트용 가짜 업비트 API 서버입니다.
+// 봇의 UPBIT_API_URL을 http://localhost:<포트>/v1로, ACCESS_KEY/SECRET_KEY를 -access-key/-secret-key와 같게 설정하세요.
+//
+//	go run ./cmd/fakeupbit -addr :9000 -markets BTC=90000000,ETH=4000000 -volatility 0.002
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-trading-bot/internal/client/upbittest"
+)
+
+func main() {
+	addr := flag.String("addr", ":9000", "listen address")
+	accessKey := flag.String("access-key", "test-access-key", "accepted ACCESS_KEY")
+	secretKey := flag.String("secret-key", "test-secret-key", "accepted SECRET_KEY")
+	markets := flag.String("markets", "BTC=90000000,ETH=4000000,XRP=3000", "comma separated SYMBOL=PRICE list")
+	krw := flag.Float64("krw", upbittest.DEFAULT_BALANCE, "starting KRW balance")
+	seed := flag.Uint64("seed", uint64(time.Now().UnixNano()), "random walk seed")
+	volatility := flag.Float64("volatility", 0.002, "standard deviation of the log return per tick")
+	tick := flag.Duration("tick", upbittest.DEFAULT_TICK, "price update interval")
+	history := flag.Duration("history", upbittest.DEFAULT_HISTORY, "price history generated before start")
+	flag.Parse()
+
+	prices, err := parseMarkets(*markets)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	server := upbittest.New(upbittest.Options{
+		AccessKey:  *accessKey,
+		SecretKey:  *secretKey,
+		Markets:    prices,
+		Balance:    *krw,
+		Tick:       *tick,
+		History:    *history,
+		Volatility: *volatility,
+		Seed:       *seed,
+	})
+
+	fmt.Printf("가짜 업비트 서버 시작: http://localhost%s/v1 (seed %d) 🟢\n", *addr, *seed)
+	if err := http.ListenAndServe(*addr, logRequests(server)); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// parseMarkets는 "BTC=90000000,ETH=4000000"를 마켓별 가격으로 변환합니다. 마켓 코드(KRW-BTC)도 허용합니다
+func parseMarkets(value string) (map[string]float64, error) {
+	markets := make(map[string]float64)
+	for _, entry := range strings.Split(value, ",") {
+		symbol, price, found := strings.Cut(strings.TrimSpace(entry), "=")
+		if !found || symbol == "" {
+			return nil, fmt.Errorf("invalid market %q: expected SYMBOL=PRICE", entry)
+		}
+		number, err := strconv.ParseFloat(price, 64)
+		if err != nil || number <= 0 {
+			return nil, fmt.Errorf("invalid price for %s: %q", symbol, price)
+		}
+		if !strings.Contains(symbol, "-") {
+			symbol = "KRW-" + symbol
+		}
+		markets[symbol] = number
+	}
+	return markets, nil
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+		fmt.Printf("%s %s %s -> %d (%v)\n", start.Format("15:04:05"), r.Method, r.URL.RequestURI(), recorder.status, time.Since(start).Round(time.Microsecond))
+	})
+}
@@ -0,0 +1,296 @@
+// optimizer는 저장해 둔 과거 캔들로 전략 설정을 그리드/랜덤 탐색해 목표 지표 순으로 정렬합니다.
+// 캔들 파일은 업비트 캔들 API 응답(JSON 배열)을 마켓마다 저장한 것이며, 봇 설정의 candle 단위와 같아야 합니다.
+//
+//	go run ./cmd/optimizer -data data/candles \
+//	  -param moving-average-cycle.short-period=3:10 \
+//	  -param moving-average-cycle.medium-period=15,20,25,30 \
+//	  -param moving-average-cycle.long-period=40:80:10 \
+//	  -objective sharpe -csv results.csv
+//
+// -in-sample을 지정하면 워크포워드 분석을 합니다. 최적화 구간에서 고른 설정을 바로 다음 검증 구간에서 평가하고,
+// 검증 구간만 이어 붙인 성과를 단순 보유와 비교하며 구간별로 고른 값이 얼마나 흔들리는지 보여줍니다
+//
+//	go run ./cmd/optimizer -data data/candles -in-sample 500 -out-of-sample 100 \
+//	  -param moving-average-cross.short-period=3:10 \
+//	  -param moving-average-cross.long-period=20:60:5 \
+//	  -csv windows.csv -equity equity.csv
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"go-trading-bot/config"
+	"go-trading-bot/internal/analytics"
+	"go-trading-bot/internal/backtest"
+	"go-trading-bot/internal/logger"
+	"go-trading-bot/internal/model"
+	"go-trading-bot/internal/scheduler"
+	"go-trading-bot/internal/validator"
+
+	"github.com/sirupsen/logrus"
+)
+
+// paramFlags는 여러 번 지정할 수 있는 -param 플래그입니다
+type paramFlags []backtest.Param
+
+func (p *paramFlags) String() string {
+	paths := make([]string, len(*p))
+	for i, param := range *p {
+		paths[i] = param.Path
+	}
+	return strings.Join(paths, ",")
+}
+
+func (p *paramFlags) Set(value string) error {
+	param, err := backtest.ParseParam(value)
+	if err != nil {
+		return err
+	}
+	*p = append(*p, param)
+	return nil
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout))
+}
+
+func run(args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("optimizer", flag.ContinueOnError)
+	configPath := flags.String("config", config.TradingConfigPath, "base trading config")
+	data := flags.String("data", "data/candles", "candle files or directories, comma separated")
+	var params paramFlags
+	flags.Var(&params, "param", "parameter to search: path=v1,v2,... or path=start:end[:step] (repeatable)")
+	search := flags.String("search", "grid", "grid | random")
+	samples := flags.Int("samples", 100, "number of random combinations")
+	seed := flags.Uint64("seed", 1, "random search seed")
+	objective := flags.String("objective", backtest.OBJECTIVE_SHARPE, "ranking objective: "+strings.Join(backtest.Objectives(), " | "))
+	workers := flags.Int("workers", 0, "parallel backtests (0 = number of CPUs)")
+	minTrades := flags.Int("min-trades", 0, "rank combinations with fewer closed trades last")
+	capital := flags.Float64("capital", 0, "starting cash (0 = order-amount × max(max-positions, markets))")
+	fee := flags.Float64("fee", backtest.DEFAULT_FEE_RATE, "fee rate per order (negative = no fee)")
+	csvPath := flags.String("csv", "", "write the full results table to this CSV file (- for stdout)")
+	top := flags.Int("top", 10, "number of results to print")
+	inSample := flags.Int("in-sample", 0, "walk-forward: candles per optimization window (0 = optimize the whole history once)")
+	outOfSample := flags.Int("out-of-sample", 0, "walk-forward: candles per validation window (0 = in-sample / 4)")
+	anchored := flags.Bool("anchored", false, "walk-forward: keep every optimization window starting at the first candle")
+	reportPath := flags.String("report", "", "write a performance report of the best combination (walk-forward: the stitched out-of-sample result); format from the extension: .html, .md, .json")
+	equityPath := flags.String("equity", "", "walk-forward: write the stitched out-of-sample equity curve to this CSV file (- for stdout)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if len(params) == 0 {
+		fmt.Fprintln(os.Stderr, "at least one -param is required")
+		return 2
+	}
+	if *reportPath != "" && analytics.FormatFromPath(*reportPath) == "" {
+		fmt.Fprintf(os.Stderr, "unknown report format %q: use .html, .md or .json\n", *reportPath)
+		return 2
+	}
+
+	base, issues := validator.ValidateFile(*configPath, nil)
+	if issues.HasFatal() {
+		for _, issue := range issues {
+			fmt.Fprintln(os.Stderr, issue.String())
+		}
+		return 1
+	}
+
+	candles, err := backtest.LoadData(strings.Split(*data, ",")...)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	var combinations [][]float64
+	switch *search {
+	case "grid":
+		combinations = backtest.Grid(params)
+	case "random":
+		combinations = backtest.Random(params, *samples, *seed)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown search %q: grid | random\n", *search)
+		return 2
+	}
+
+	// 전략 분석 로그는 조합마다 반복되므로 버립니다
+	quiet := logrus.New()
+	quiet.SetOutput(io.Discard)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	ctx = logger.WithLogger(ctx, quiet)
+
+	fmt.Fprintf(out, "%s: %d개 조합, 마켓 %d개, 목표 %s\n", base.Strategy, len(combinations), len(candles), *objective)
+	optimizeOptions := backtest.OptimizeOptions{
+		Objective: *objective,
+		Workers:   *workers,
+		MinTrades: *minTrades,
+		Backtest:  backtest.Options{Capital: *capital, FeeRate: *fee},
+	}
+	if *inSample > 0 {
+		if *outOfSample <= 0 {
+			*outOfSample = max(*inSample/4, 1)
+		}
+		result, err := backtest.WalkForward(ctx, base, candles, params, combinations, backtest.WalkForwardOptions{
+			InSample:    *inSample,
+			OutOfSample: *outOfSample,
+			Anchored:    *anchored,
+			Optimize:    optimizeOptions,
+		})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		printWalkForward(out, params, result)
+		for _, export := range []struct {
+			path  string
+			write func(io.Writer) error
+		}{
+			{*csvPath, func(w io.Writer) error { return backtest.WriteWindowsCSV(w, params, result) }},
+			{*equityPath, func(w io.Writer) error { return backtest.WriteEquityCSV(w, result) }},
+			{*reportPath, func(w io.Writer) error { return result.Report().Write(w, analytics.FormatFromPath(*reportPath)) }},
+		} {
+			if export.path == "" {
+				continue
+			}
+			if err := writeFile(export.path, out, export.write); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				return 1
+			}
+		}
+		return 0
+	}
+
+	trials, err := backtest.Optimize(ctx, base, candles, params, combinations, optimizeOptions)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	printTrials(out, params, trials[:min(*top, len(trials))])
+
+	if *csvPath != "" {
+		if err := writeFile(*csvPath, out, func(w io.Writer) error { return backtest.WriteCSV(w, params, trials) }); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	}
+	if *reportPath != "" && len(trials) > 0 && trials[0].Err == nil {
+		if err := writeReport(ctx, *reportPath, out, base, candles, params, trials[0], optimizeOptions.Backtest); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	}
+	return 0
+}
+
+// writeReport는 가장 좋은 조합으로 다시 백테스트해 성과 보고서를 씁니다
+func writeReport(ctx context.Context, path string, out io.Writer, base *config.TradingConfig, candles map[string][]model.Candle, params []backtest.Param, best backtest.Trial, opts backtest.Options) error {
+	tc, err := backtest.Apply(base, params, best.Values)
+	if err != nil {
+		return err
+	}
+	result, err := backtest.Run(ctx, tc, candles, opts)
+	if err != nil {
+		return err
+	}
+	return writeFile(path, out, func(w io.Writer) error { return result.Report().Write(w, analytics.FormatFromPath(path)) })
+}
+
+func printTrials(out io.Writer, params []backtest.Param, trials []backtest.Trial) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	header := "#\t"
+	for _, param := range params {
+		header += param.Path + "\t"
+	}
+	fmt.Fprintln(w, header+"score\treturn\tsharpe\tmdd\tPF\ttrades\twin\t")
+	for i, trial := range trials {
+		row := fmt.Sprintf("%d\t", i+1)
+		for _, value := range trial.Values {
+			row += fmt.Sprintf("%v\t", value)
+		}
+		if trial.Err != nil {
+			fmt.Fprintln(w, row+trial.Err.Error()+"\t")
+			continue
+		}
+		m := trial.Metrics
+		fmt.Fprintf(w, "%s%.4f\t%.2f%%\t%.2f\t%.2f%%\t%.2f\t%d\t%.0f%%\t\n", row, trial.Score, m.TotalReturn*100, m.Sharpe, m.MaxDrawdown*100, m.ProfitFactor, m.Trades, m.WinRate*100)
+	}
+	w.Flush()
+}
+
+// printWalkForward는 구간별 결과, 설정 안정성, 단순 보유와의 비교를 출력합니다
+func printWalkForward(out io.Writer, params []backtest.Param, result *backtest.WalkForwardResult) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	header := "#\tin-sample\tout-of-sample\t"
+	for _, param := range params {
+		header += param.Path + "\t"
+	}
+	fmt.Fprintln(w, header+"score\tIS return\tOOS return\tOOS mdd\tOOS trades\t")
+	for i, window := range result.Windows {
+		row := fmt.Sprintf("%d\t%s ~ %s\t%s ~ %s\t", i+1, formatDate(window.InSampleFrom), formatDate(window.InSampleTo), formatDate(window.OutOfSampleFrom), formatDate(window.OutOfSampleTo))
+		if window.Err != nil {
+			fmt.Fprintln(w, row+strings.Repeat("-\t", len(params))+window.Err.Error()+"\t")
+			continue
+		}
+		for _, value := range window.Values {
+			row += fmt.Sprintf("%v\t", value)
+		}
+		fmt.Fprintf(w, "%s%.4f\t%.2f%%\t%.2f%%\t%.2f%%\t%d\t\n", row, window.Score, window.InSample.TotalReturn*100, window.OutOfSample.TotalReturn*100, window.OutOfSample.MaxDrawdown*100, window.OutOfSample.Trades)
+	}
+	w.Flush()
+
+	fmt.Fprintln(out, "\n설정 안정성")
+	w = tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "parameter\tmean\tstddev\tmin\tmax\tchanges\t")
+	for _, s := range result.Stability {
+		fmt.Fprintf(w, "%s\t%.2f\t%.2f\t%v\t%v\t%d/%d\t\n", s.Path, s.Mean, s.StdDev, s.Min, s.Max, s.Changes, max(len(s.Values)-1, 0))
+	}
+	w.Flush()
+
+	fmt.Fprintln(out, "\n검증 구간 성과")
+	w = tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "\treturn\tsharpe\tmdd\tPF\ttrades\twin\t")
+	for _, row := range []struct {
+		name    string
+		metrics backtest.Metrics
+	}{
+		{"walk-forward", result.Metrics},
+		{"buy & hold", result.BuyAndHoldMetrics},
+	} {
+		m := row.metrics
+		fmt.Fprintf(w, "%s\t%.2f%%\t%.2f\t%.2f%%\t%.2f\t%d\t%.0f%%\t\n", row.name, m.TotalReturn*100, m.Sharpe, m.MaxDrawdown*100, m.ProfitFactor, m.Trades, m.WinRate*100)
+	}
+	w.Flush()
+}
+
+func formatDate(t time.Time) string {
+	return t.In(scheduler.KST).Format("2006-01-02 15:04")
+}
+
+// writeFile은 write의 결과를 path에 저장합니다. path가 -이면 out에 씁니다
+func writeFile(path string, out io.Writer, write func(io.Writer) error) error {
+	if path == "-" {
+		return write(out)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(file); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "%s에 저장했습니다.\n", path)
+	return nil
+}
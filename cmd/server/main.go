@@ -1,56 +1,174 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"runtime"
 	"syscall"
+	"time"
 
 	"go-trading-bot/config"
 	"go-trading-bot/internal/api"
+	"go-trading-bot/internal/clock"
+	"go-trading-bot/internal/i18n"
 	"go-trading-bot/internal/logger"
+	"go-trading-bot/internal/notify"
 	"go-trading-bot/internal/service"
-	"go-trading-bot/internal/utils"
+	"go-trading-bot/internal/telegram"
+	"go-trading-bot/internal/validator"
 )
 
+// DEFAULT_SHUTDOWN_TIMEOUT은 SHUTDOWN_TIMEOUT이 올바르지 않을 때 사용하는 종료 기한입니다
+const DEFAULT_SHUTDOWN_TIMEOUT = 30 * time.Second
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "validate-config" {
+		os.Exit(runValidateConfig(os.Args[2:], config.GetConfig(), os.Stdout))
+	}
+
+	c := config.GetConfig()
+	if err := logger.Configure(logOptions(c)); err != nil {
+		logger.Log.Errorf("로그 설정이 올바르지 않아 기본값을 사용합니다. %v 🔴", err)
+	}
+
 	logger.Log.Infof("Go Trading Bot 🟢")
 	logger.Log.Infof("Go Version: %s\n", runtime.Version())
 	logger.Log.Infof("OS/Arch: %s/%s\n", runtime.GOOS, runtime.GOARCH)
 	logger.Log.Infof("Environment configured successfully!")
 
-	c := config.GetConfig()
 	logger.Log.Infof("config -> %+v\n", c)
 
-	config.ReadTradingConfig()
-	t := config.GetTradingConfig()
+	t, issues := validator.ValidateFile(config.TradingConfigPath, c)
+	for _, issue := range issues {
+		if issue.Fatal {
+			logger.Log.Error(issue.String())
+		} else {
+			logger.Log.Warn(issue.String())
+		}
+	}
+	if issues.HasFatal() {
+		logger.Log.Errorf("설정 오류로 봇을 시작할 수 없습니다. %v 파일을 확인하세요. 🔴", config.TradingConfigPath)
+		os.Exit(1)
+	}
+	config.SetTradingConfig(t)
 	logger.Log.Infof("tradingConfig -> %+v\n", t)
 
-	utils.SendTelegramMessage("프로그램 시작 🟢")
+	// 알림 언어와 템플릿은 위에서 검증했습니다
+	lang, _ := i18n.ParseLanguage(c.AlertLanguage)
+	if renderer, err := i18n.NewRenderer(lang, c.AlertTemplateDir); err != nil {
+		logger.Log.Errorf("알림 템플릿을 읽을 수 없어 기본 템플릿을 사용합니다. %v 🔴", err)
+	} else {
+		i18n.SetDefault(renderer)
+	}
+
+	notifier := notify.NewPolicyFromConfig(notify.NewRouterFromConfig(config.Global), config.Global, clock.Real)
+	_ = notifier.Notify(notify.Notification{Type: notify.TYPE_SYSTEM, Message: "프로그램 시작 🟢"})
 
 	stopChan := make(chan struct{})
-	tradingBot := &service.TradingBot{}
+	tradingBot := service.NewTradingBot(notifier)
 	tradingBot.Initialize()
 	go tradingBot.RunTradingBot(stopChan)
 
 	// TradingBot 인스턴스를 라우터에 주입
-	router := api.NewRouter(tradingBot)
+	// 요청 컨텍스트는 종료 시작과 함께 취소되어 이벤트 스트림(SSE) 같은 긴 요청도 Shutdown을 막지 않습니다
+	serverCtx, stopServer := context.WithCancel(context.Background())
+	server := &http.Server{
+		Addr:        fmt.Sprintf(":%d", c.Port),
+		Handler:     api.NewRouter(tradingBot, config.Global),
+		BaseContext: func(net.Listener) context.Context { return serverCtx },
+	}
 	go func() {
-		addr := fmt.Sprintf(":%d", c.Port)
-		logger.Log.Infof("Starting Gin API server on %s 🌐", addr)
-		if err := router.Run(addr); err != nil {
+		logger.Log.Infof("Starting Gin API server on %s 🌐", server.Addr)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			logger.Log.Errorf("Failed to start API server: %v", err)
 		}
 	}()
 
-	// Wait for interrupt
+	// 텔레그램 명령 처리 (getUpdates long-polling)
+	if c.TelegramCommand == "OK" && c.TelegramBotToken != "" {
+		allowedChatIDs := c.TelegramAllowedChatIDs
+		if allowedChatIDs == "" {
+			allowedChatIDs = c.TelegramChatID
+		}
+		commandBot := telegram.NewCommandBot(telegram.NewClient(c.TelegramAPIUrl, c.TelegramBotToken), tradingBot, telegram.ParseChatIDs(allowedChatIDs), clock.Real)
+		go commandBot.Run(stopChan)
+	}
+
+	// application.json 변경 감지
+	if c.ConfigWatchInterval > 0 {
+		go config.WatchTradingConfig(config.TradingConfigPath, time.Duration(c.ConfigWatchInterval)*time.Second, stopChan, func() {
+			_, _ = tradingBot.ReloadConfig()
+		})
+	}
+
+	// SIGHUP은 설정 리로드, SIGINT/SIGTERM은 종료
 	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
-	<-sigChan
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	for sig := range sigChan {
+		if sig != syscall.SIGHUP {
+			break
+		}
+		logger.Log.Info("SIGHUP 수신 -> 설정을 다시 읽습니다.")
+		_, _ = tradingBot.ReloadConfig()
+	}
 
-	// Graceful shutdown
-	logger.Log.Info("Shutting down Trading Bot 🛑")
-	utils.SendTelegramMessage("프로그램 종료 🔴")
+	// Graceful shutdown: 종료 신호를 한 번 더 받으면 기다리지 않고 종료합니다
+	timeout := shutdownTimeout(c)
+	logger.Log.Infof("Shutting down Trading Bot 🛑 (최대 %v 대기)", timeout)
+	go func() {
+		<-sigChan
+		logger.Log.Error("종료 신호를 다시 받아 즉시 종료합니다. 🔴")
+		os.Exit(1)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// 1. 스케줄러, 텔레그램 명령, 설정 감시를 멈춰 새 작업을 받지 않습니다
 	close(stopChan)
+
+	// 2. 새 API 요청을 막고 처리 중인 요청(수동 주문 등)이 끝나길 기다립니다
+	stopServer()
+	if err := server.Shutdown(ctx); err != nil {
+		logger.Log.Errorf("API 서버가 종료 기한 안에 멈추지 않았습니다. %v 🔴", err)
+	}
+
+	// 3. 실행 중인 사이클과 주문을 마무리하고 상태를 저장합니다
+	if err := tradingBot.Shutdown(ctx); err != nil {
+		logger.Log.Errorf("봇을 정상적으로 종료하지 못했습니다. %v 🔴", err)
+	}
+
+	// 4. 큐에 남은 알림을 전송한 뒤 종료합니다
+	_ = notifier.Notify(notify.Notification{Type: notify.TYPE_SYSTEM, Severity: notify.SEVERITY_WARNING, Message: "프로그램 종료 🔴"})
+	if err := notifier.Flush(ctx); err != nil {
+		logger.Log.Errorf("전송하지 못한 알림이 있습니다. %v 🔴", err)
+	}
+	logger.Log.Info("Trading Bot stopped 🟢")
+}
+
+// shutdownTimeout은 SHUTDOWN_TIMEOUT(초)을 time.Duration으로 변환합니다
+func shutdownTimeout(c *config.Config) time.Duration {
+	if c.ShutdownTimeout <= 0 {
+		return DEFAULT_SHUTDOWN_TIMEOUT
+	}
+	return time.Duration(c.ShutdownTimeout) * time.Second
+}
+
+// logOptions는 환경 변수의 로그 설정을 logger.Options로 변환합니다
+func logOptions(c *config.Config) logger.Options {
+	return logger.Options{
+		Env:        c.Env,
+		Format:     c.LogFormat,
+		Level:      c.LogLevel,
+		File:       c.LogFile,
+		MaxSize:    c.LogMaxSize,
+		MaxBackups: c.LogMaxBackups,
+		MaxAge:     c.LogMaxAge,
+		Compress:   c.LogCompress == "OK",
+	}
 }
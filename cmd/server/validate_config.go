@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"go-trading-bot/config"
+	"go-trading-bot/internal/validator"
+)
+
+// runValidateConfig는 봇 시작 시와 동일한 설정 검증을 실행하고 결과를 out에 출력합니다.
+// 설정 오류가 있으면 1, 없으면 0을 반환합니다.
+// 사용법: trading-bot validate-config [application.json 경로]
+func runValidateConfig(args []string, c *config.Config, out io.Writer) int {
+	path := config.TradingConfigPath
+	if len(args) > 0 {
+		path = args[0]
+	}
+
+	_, issues := validator.ValidateFile(path, c)
+	for _, issue := range issues {
+		fmt.Fprintln(out, issue.String())
+	}
+
+	if issues.HasFatal() {
+		fmt.Fprintf(out, "✗ %s: 설정 오류가 있습니다.\n", path)
+		return 1
+	}
+
+	fmt.Fprintf(out, "✓ %s: 설정이 올바릅니다. (경고 %d건)\n", path, len(issues))
+	return 0
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"go-trading-bot/config"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateConfigExitCode(t *testing.T) {
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte(`{"markets": [], "order-amount": 0}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	malformed := filepath.Join(dir, "malformed.json")
+	if err := os.WriteFile(malformed, []byte(`{"markets": [`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		code int
+		want string
+	}{
+		{"bundled application.json", []string{filepath.Join("..", "..", "application.json")}, 0, "✓"},
+		{"invalid values", []string{invalid}, 1, "[ERROR] $.markets"},
+		{"malformed json", []string{malformed}, 1, "[ERROR] $: JSON 문법 오류"},
+		{"missing file", []string{filepath.Join(dir, "missing.json")}, 1, "✗"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &config.Config{LogFormat: "text", LogLevel: "info", ShutdownTimeout: 30}
+			var out strings.Builder
+			if code := runValidateConfig(tt.args, c, &out); code != tt.code {
+				t.Errorf("exit code = %d, want %d\n%s", code, tt.code, out.String())
+			}
+			if !strings.Contains(out.String(), tt.want) {
+				t.Errorf("output = %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
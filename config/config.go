@@ -1,19 +1,22 @@
 package config
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
 	"sync"
+	"sync/atomic"
 
 	"github.com/joho/godotenv"
 )
 
+// TradingConfigPath는 트레이딩 설정 파일 경로입니다
+const TradingConfigPath = "application.json"
+
 var (
 	instance      *Config
 	once          sync.Once
-	tradingConfig *TradingConfig
+	tradingConfig atomic.Pointer[TradingConfig]
 )
 
 type Config struct {
@@ -35,6 +38,44 @@ type Config struct {
 	TelegramSend     string
 	TelegramBotToken string
 	TelegramChatID   string
+	TelegramAPIUrl   string
+
+	SlackWebhookURL   string
+	DiscordWebhookURL string
+
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+	SMTPTo       string // 수신자 목록(쉼표 구분)
+
+	NotifyWebhookURL    string // 일반 JSON 웹훅 주소
+	NotifyWebhookSecret string // 설정 시 X-Signature 헤더에 HMAC-SHA256 서명 추가
+
+	AlertLanguage    string // 알림 메시지 언어 (ko | en)
+	AlertTemplateDir string // 알림 템플릿(*.tmpl) 덮어쓰기 디렉터리, 비어 있으면 내장 템플릿만 사용
+
+	TelegramCommand        string // OK이면 텔레그램 명령(/status, /pause 등)을 처리
+	TelegramAllowedChatIDs string // 명령을 처리할 채팅 ID 목록(쉼표 구분), 비어 있으면 TELEGRAM_CHAT_ID만 허용
+
+	LogFormat     string // 로그 형식 (text | json)
+	LogLevel      string // 로그 레벨 (trace, debug, info, warn, error)
+	LogFile       string // 로그 파일 경로, 비어 있으면 stdout에만 출력
+	LogMaxSize    int    // 로그 파일 최대 크기(MB)
+	LogMaxBackups int    // 보관할 이전 로그 파일 수
+	LogMaxAge     int    // 이전 로그 파일 보관 기간(일)
+	LogCompress   string // OK이면 이전 로그 파일을 gzip으로 압축
+
+	ConfigWatchInterval int // application.json 변경 감지 주기(초), 0이면 감시하지 않음
+
+	ShutdownTimeout int    // 종료 시 사이클, 주문, API 요청, 알림 전송을 기다리는 최대 시간(초)
+	StateFile       string // 종료 시 포지션, 주문 기록, 일시정지 상태를 저장할 파일, 비어 있으면 저장하지 않음
+
+	APIKeys           string // API 인증 키 목록 "id:secret:role,..." (role: read | operator)
+	APIIPAllowlist    string // API 허용 IP/CIDR 목록, 비어 있으면 모두 허용
+	APIRateLimit      int    // 클라이언트별 분당 최대 요청 수, 0이면 제한 없음
+	APITrustedProxies string // X-Forwarded-For를 신뢰할 프록시 IP/CIDR 목록
 }
 
 type TradingConfig struct {
@@ -44,12 +85,38 @@ type TradingConfig struct {
 	MovingAverageCross MovingAverageCross `json:"moving-average-cross"`
 	MovingAverageCycle MovingAverageCycle `json:"moving-average-cycle"`
 	AnalysisInterval   int                `json:"analysis-interval"`
+	Scheduler          Scheduler          `json:"scheduler"`
+	TradingWindow      TradingWindow      `json:"trading-window"`
+	CycleOverlapPolicy string             `json:"cycle-overlap-policy"` // skip | queue
 	OrderAmount        float64            `json:"order-amount"`
+	LiveTrading        bool               `json:"live-trading"` // 실거래 모드: 업비트 주문 API(ACCESS_KEY/SECRET_KEY)로 실제 주문. 생략하면 모의 주문
+	Risk               Risk               `json:"risk"`
+	Notification       Notification       `json:"notification"`
+	AlertPolicy        AlertPolicy        `json:"alert-policy"`
 }
 
 type Candle struct {
-	Category string `json:"category"`
-	Unit     int    `json:"unit"`
+	Category   string `json:"category"`
+	Unit       int    `json:"unit"`
+	ClosedOnly bool   `json:"closed-only"` // 진행 중인 캔들을 제외하고 마감된 캔들만 분석
+}
+
+type Scheduler struct {
+	Type             string `json:"type"`               // interval | candle-close | cron
+	CandleCloseDelay int    `json:"candle-close-delay"` // 캔들 마감 후 실행까지 대기 시간(초)
+	Cron             string `json:"cron"`               // type이 cron일 때 분석 실행 스케줄
+	ReportCron       string `json:"report-cron"`        // 요약 리포트 전송 스케줄 (비어 있으면 사용 안 함)
+	MaintenanceCron  string `json:"maintenance-cron"`   // 마켓 재검증 등 유지보수 스케줄 (비어 있으면 사용 안 함)
+	Timezone         string `json:"timezone"`           // cron 해석 기준 타임존 (기본값 KST)
+}
+
+// TradingWindow는 신규 진입이 허용되는 시간대 규칙입니다. 청산은 항상 허용됩니다
+type TradingWindow struct {
+	Enabled       bool     `json:"enabled"`
+	Timezone      string   `json:"timezone"`
+	Hours         []string `json:"hours"`          // "09:00-23:00" 형식, 자정을 넘는 구간 허용
+	Weekdays      []string `json:"weekdays"`       // MON, TUE, ... SUN
+	BlackoutDates []string `json:"blackout-dates"` // 2006-01-02 형식
 }
 
 func (c *Candle) BuildAPIPath() string {
@@ -63,10 +130,15 @@ func (c *Candle) BuildAPIPath() string {
 	}
 }
 
+// CandleCategories는 업비트가 지원하는 캔들 종류입니다
+var CandleCategories = []string{"minutes", "days", "weeks", "months"}
+
+// MinuteUnits는 업비트가 지원하는 분봉 단위입니다
+var MinuteUnits = []int{1, 3, 5, 10, 15, 30, 60, 240}
+
 func (c *Candle) validateMinuteUnit() bool {
-	unitRange := []int{1, 3, 5, 10, 15, 30, 60, 240}
 	find := false
-	for _, u := range unitRange {
+	for _, u := range MinuteUnits {
 		if c.Unit == u {
 			find = true
 			break
@@ -76,6 +148,48 @@ func (c *Candle) validateMinuteUnit() bool {
 	return find
 }
 
+// Risk는 주문 리스크 제한 설정입니다. 0이면 해당 제한을 사용하지 않습니다
+type Risk struct {
+	MaxPositions      int     `json:"max-positions"`       // 동시 보유 포지션 수
+	MaxOrderAmount    float64 `json:"max-order-amount"`    // 1회 최대 주문 금액
+	StopLossPercent   float64 `json:"stop-loss-percent"`   // 진입가 대비 손절 비율(%)
+	TakeProfitPercent float64 `json:"take-profit-percent"` // 진입가 대비 익절 비율(%)
+}
+
+// Notification은 알림 라우팅 설정입니다. routes가 비어 있으면 설정된 모든 채널로 전송합니다
+type Notification struct {
+	Routes []NotificationRoute `json:"routes"`
+}
+
+// NotificationRoute는 이벤트 타입과 심각도에 따라 알림을 보낼 채널입니다
+type NotificationRoute struct {
+	Sink        string   `json:"sink"`         // telegram | slack | discord | email | webhook
+	Types       []string `json:"types"`        // 비어 있으면 모든 타입
+	MinSeverity string   `json:"min-severity"` // info | warning | critical, 비어 있으면 info
+}
+
+// AlertPolicy는 알림 정책입니다. 타입별 규칙(rules)에 따라 즉시 전송, 요약(digest), 무시를 결정합니다
+type AlertPolicy struct {
+	QuietHours QuietHours           `json:"quiet-hours"`
+	DigestCron string               `json:"digest-cron"` // 요약 알림 전송 주기 (예: "0 * * * *" 매시, "0 9 * * *" 매일)
+	Rules      map[string]AlertRule `json:"rules"`       // 키: 알림 타입 (signal, stage, hold, risk ...)
+}
+
+// QuietHours는 즉시 알림을 보내지 않는 시간대입니다
+type QuietHours struct {
+	Enabled  bool     `json:"enabled"`
+	Timezone string   `json:"timezone"`
+	Hours    []string `json:"hours"`    // "23:00-07:00" 형식, 자정을 넘는 구간 허용
+	Weekdays []string `json:"weekdays"` // 비어 있으면 매일
+}
+
+// AlertRule은 알림 타입별 규칙입니다
+type AlertRule struct {
+	Mode           string `json:"mode"`            // immediate | digest | off
+	SuppressRepeat bool   `json:"suppress-repeat"` // 마켓별 상태가 마지막으로 전송한 알림과 같으면 보내지 않음
+	QuietHours     string `json:"quiet-hours"`     // 조용한 시간 처리: digest | drop | send
+}
+
 type MovingAverageCross struct {
 	ShortPeriod int `json:"short-period"`
 	LongPeriod  int `json:"long-period"`
@@ -87,6 +201,33 @@ type MovingAverageCycle struct {
 	LongPeriod   int `json:"long-period"`
 }
 
+// REDACTED는 로그에 출력할 때 비밀 값 대신 쓰는 문자열입니다
+const REDACTED = "[REDACTED]"
+
+// String은 비밀 값(API 키, 비밀번호, 토큰, 웹훅 주소)을 가린 설정 문자열을 반환합니다
+func (c *Config) String() string {
+	redacted := *c
+	for _, secret := range []*string{
+		&redacted.DBPass,
+		&redacted.AccessKey,
+		&redacted.SecretKey,
+		&redacted.TelegramBotToken,
+		&redacted.SlackWebhookURL,
+		&redacted.DiscordWebhookURL,
+		&redacted.SMTPPassword,
+		&redacted.NotifyWebhookURL,
+		&redacted.NotifyWebhookSecret,
+		&redacted.APIKeys,
+	} {
+		if *secret != "" {
+			*secret = REDACTED
+		}
+	}
+	// String 메서드가 없는 타입으로 바꿔 재귀 호출을 피합니다
+	type plain Config
+	return fmt.Sprintf("%+v", plain(redacted))
+}
+
 func GetConfig() *Config {
 	// singleton
 	once.Do(func() {
@@ -117,6 +258,44 @@ func newConfig() *Config {
 		TelegramSend:     getEnvStr("TELEGRAM_SEND", ""),
 		TelegramBotToken: getEnvStr("TELEGRAM_BOT_TOKEN", ""),
 		TelegramChatID:   getEnvStr("TELEGRAM_CHAT_ID", ""),
+		TelegramAPIUrl:   getEnvStr("TELEGRAM_API_URL", "https://api.telegram.org"),
+
+		SlackWebhookURL:   getEnvStr("SLACK_WEBHOOK_URL", ""),
+		DiscordWebhookURL: getEnvStr("DISCORD_WEBHOOK_URL", ""),
+
+		SMTPHost:     getEnvStr("SMTP_HOST", ""),
+		SMTPPort:     getEnvInt("SMTP_PORT", 587),
+		SMTPUsername: getEnvStr("SMTP_USERNAME", ""),
+		SMTPPassword: getEnvStr("SMTP_PASSWORD", ""),
+		SMTPFrom:     getEnvStr("SMTP_FROM", ""),
+		SMTPTo:       getEnvStr("SMTP_TO", ""),
+
+		NotifyWebhookURL:    getEnvStr("NOTIFY_WEBHOOK_URL", ""),
+		NotifyWebhookSecret: getEnvStr("NOTIFY_WEBHOOK_SECRET", ""),
+
+		AlertLanguage:    getEnvStr("ALERT_LANGUAGE", "ko"),
+		AlertTemplateDir: getEnvStr("ALERT_TEMPLATE_DIR", ""),
+
+		TelegramCommand:        getEnvStr("TELEGRAM_COMMAND", ""),
+		TelegramAllowedChatIDs: getEnvStr("TELEGRAM_ALLOWED_CHAT_IDS", ""),
+
+		LogFormat:     getEnvStr("LOG_FORMAT", "text"),
+		LogLevel:      getEnvStr("LOG_LEVEL", "info"),
+		LogFile:       getEnvStr("LOG_FILE", "logs/go-trading-bot.log"),
+		LogMaxSize:    getEnvInt("LOG_MAX_SIZE", 10),
+		LogMaxBackups: getEnvInt("LOG_MAX_BACKUPS", 5),
+		LogMaxAge:     getEnvInt("LOG_MAX_AGE", 7),
+		LogCompress:   getEnvStr("LOG_COMPRESS", "OK"),
+
+		ConfigWatchInterval: getEnvInt("CONFIG_WATCH_INTERVAL", 10),
+
+		ShutdownTimeout: getEnvInt("SHUTDOWN_TIMEOUT", 30),
+		StateFile:       getEnvStr("STATE_FILE", "data/state.json"),
+
+		APIKeys:           getEnvStr("API_KEYS", ""),
+		APIIPAllowlist:    getEnvStr("API_IP_ALLOWLIST", ""),
+		APIRateLimit:      getEnvInt("API_RATE_LIMIT", 120),
+		APITrustedProxies: getEnvStr("API_TRUSTED_PROXIES", ""),
 	}
 }
 
@@ -138,22 +317,11 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
-func ReadTradingConfig() bool {
-	file, err := os.ReadFile("application.json")
-	if err != nil {
-		fmt.Println("Failed to open application.json")
-		return false
-	}
-
-	err = json.Unmarshal(file, &tradingConfig)
-	if err != nil {
-		fmt.Println("Failed to parse application.json")
-		return false
-	}
-
-	return true
+func GetTradingConfig() *TradingConfig {
+	return tradingConfig.Load()
 }
 
-func GetTradingConfig() *TradingConfig {
-	return tradingConfig
+// SetTradingConfig는 현재 트레이딩 설정을 원자적으로 교체합니다
+func SetTradingConfig(c *TradingConfig) {
+	tradingConfig.Store(c)
 }
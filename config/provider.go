@@ -0,0 +1,36 @@
+package config
+
+import "sync/atomic"
+
+// Provider는 환경 변수 설정(Config)과 트레이딩 설정(TradingConfig)을 제공합니다
+type Provider interface {
+	Config() *Config
+	TradingConfig() *TradingConfig
+	SetTradingConfig(c *TradingConfig)
+}
+
+type globalProvider struct{}
+
+func (globalProvider) Config() *Config                   { return GetConfig() }
+func (globalProvider) TradingConfig() *TradingConfig     { return GetTradingConfig() }
+func (globalProvider) SetTradingConfig(c *TradingConfig) { SetTradingConfig(c) }
+
+// Global은 패키지 전역 설정(GetConfig, GetTradingConfig)을 사용하는 Provider입니다
+var Global Provider = globalProvider{}
+
+// Static은 주어진 설정만 사용하는 Provider입니다. 전역 설정을 읽거나 바꾸지 않습니다
+type Static struct {
+	config        *Config
+	tradingConfig atomic.Pointer[TradingConfig]
+}
+
+// NewStatic은 c, tc를 제공하는 Static을 생성합니다
+func NewStatic(c *Config, tc *TradingConfig) *Static {
+	s := &Static{config: c}
+	s.tradingConfig.Store(tc)
+	return s
+}
+
+func (s *Static) Config() *Config                   { return s.config }
+func (s *Static) TradingConfig() *TradingConfig     { return s.tradingConfig.Load() }
+func (s *Static) SetTradingConfig(c *TradingConfig) { s.tradingConfig.Store(c) }
@@ -0,0 +1,122 @@
+package config
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// WatchTradingConfig는 설정 파일을 주기적으로 확인하고, 내용이 바뀌면 onChange를 호출합니다
+func WatchTradingConfig(path string, interval time.Duration, stopChan <-chan struct{}, onChange func()) {
+	lastModTime, lastHash := fileState(path)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			modTime, hash := fileState(path)
+			if modTime.Equal(lastModTime) || hash == nil {
+				continue
+			}
+			lastModTime = modTime
+			if bytes.Equal(hash, lastHash) {
+				continue
+			}
+			lastHash = hash
+			onChange()
+		case <-stopChan:
+			return
+		}
+	}
+}
+
+func fileState(path string) (time.Time, []byte) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, nil
+	}
+
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return info.ModTime(), nil
+	}
+
+	hash := sha256.Sum256(file)
+	return info.ModTime(), hash[:]
+}
+
+// DiffTradingConfig는 두 설정의 차이를 "경로: 이전 → 이후" 형식으로 반환합니다
+func DiffTradingConfig(oldConfig, newConfig *TradingConfig) []string {
+	oldValues := flattenConfig(oldConfig)
+	newValues := flattenConfig(newConfig)
+
+	keys := make(map[string]bool)
+	for k := range oldValues {
+		keys[k] = true
+	}
+	for k := range newValues {
+		keys[k] = true
+	}
+
+	var changes []string
+	for k := range keys {
+		oldValue, oldExists := oldValues[k]
+		newValue, newExists := newValues[k]
+		if oldExists && newExists && reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+		changes = append(changes, fmt.Sprintf("%s: %s → %s", k, formatConfigValue(oldValue, oldExists), formatConfigValue(newValue, newExists)))
+	}
+	sort.Strings(changes)
+
+	return changes
+}
+
+func flattenConfig(c *TradingConfig) map[string]any {
+	values := make(map[string]any)
+	if c == nil {
+		return values
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return values
+	}
+
+	var tree map[string]any
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return values
+	}
+
+	var walk func(prefix string, v any)
+	walk = func(prefix string, v any) {
+		if m, ok := v.(map[string]any); ok {
+			for k, child := range m {
+				walk(prefix+"."+k, child)
+			}
+			return
+		}
+		values[prefix[1:]] = v
+	}
+	walk("", tree)
+
+	return values
+}
+
+func formatConfigValue(v any, exists bool) string {
+	if !exists {
+		return "(없음)"
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
@@ -46,7 +46,7 @@ build() {
     mkdir -p bin
     
     # 빌드
-    go build -o "${BINARY_PATH}" ./cmd/server/main.go
+    go build -o "${BINARY_PATH}" ./cmd/server
     
     if [ $? -eq 0 ]; then
         echo -e "${GREEN}✓ Build successful${NC}"
@@ -127,8 +127,8 @@ stop() {
     # SIGTERM 전송
     kill -TERM "${PID}" 2>/dev/null
     
-    # 최대 10초 대기
-    for i in {1..10}; do
+    # 최대 40초 대기 (봇은 SHUTDOWN_TIMEOUT 동안 사이클, 주문, 알림 전송을 마무리합니다)
+    for i in {1..40}; do
         if ! ps -p "${PID}" > /dev/null 2>&1; then
             rm -f "${PID_FILE}"
             echo -e "${GREEN}✓ ${APP_NAME} stopped successfully${NC}"
@@ -151,6 +151,18 @@ stop() {
     fi
 }
 
+# 함수: 설정 파일 검증
+validate() {
+    # 바이너리가 없으면 빌드
+    if [ ! -f "${BINARY_PATH}" ]; then
+        echo -e "${YELLOW}Binary not found. Building...${NC}"
+        build || return 1
+    fi
+
+    cd "${SCRIPT_DIR}" || exit 1
+    "${BINARY_PATH}" validate-config
+}
+
 # 함수: 서비스 재시작
 restart() {
     echo -e "${BLUE}Restarting ${APP_NAME}...${NC}"
@@ -220,7 +232,7 @@ clean() {
 
 # 함수: 사용법 출력
 usage() {
-    echo "Usage: $0 {build|start|foreground|stop|restart|status|logs|follow|clean}"
+    echo "Usage: $0 {build|start|foreground|stop|restart|status|logs|follow|clean|validate}"
     echo ""
     echo "Commands:"
     echo "  build      - Build the application"
@@ -232,6 +244,7 @@ usage() {
     echo "  logs       - Show last 50 lines of log (use 'logs N' for N lines)"
     echo "  follow     - Follow log file in real-time"
     echo "  clean      - Clean up logs and pid files"
+    echo "  validate   - Validate application.json"
     echo ""
     echo "Examples:"
     echo "  $0 start          # Start in background"
@@ -269,6 +282,9 @@ case "${1}" in
     clean)
         clean
         ;;
+    validate)
+        validate
+        ;;
     *)
         usage
         exit 1
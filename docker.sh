@@ -129,7 +129,7 @@ case "$1" in
         PORT=$(grep "^PORT=" .env | cut -d '=' -f2)
         PORT=${PORT:-5000}
         
-        response=$(curl -s -w "\n%{http_code}" http://localhost:$PORT/api/v1/health)
+        response=$(curl -s -w "\n%{http_code}" http://localhost:$PORT/health/ready)
         http_code=$(echo "$response" | tail -n 1)
         body=$(echo "$response" | head -n -1)
         
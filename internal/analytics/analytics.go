@@ -0,0 +1,427 @@
+// Package analytics는 주문 기록(매매 장부)과 자산 곡선으로 성과 지표를 계산합니다.
+// 백테스트와 실거래 기록 모두 같은 지표를 쓰며, 마켓별/진입 단계별 집계와 JSON, Markdown, HTML 보고서를 만듭니다
+package analytics
+
+import (
+	"encoding/json"
+	"go-trading-bot/internal/model"
+	"math"
+	"sort"
+	"time"
+)
+
+// YEAR는 연율화에 사용하는 1년입니다
+const YEAR = 365 * 24 * time.Hour
+
+// NO_STAGE는 진입 단계가 없는 거래를 묶는 키입니다
+const NO_STAGE = "NONE"
+
+// Ratio는 0으로 나누면 무한대가 될 수 있는 비율입니다. JSON에서 무한대와 NaN은 null입니다
+type Ratio float64
+
+func (r Ratio) MarshalJSON() ([]byte, error) {
+	if math.IsInf(float64(r), 0) || math.IsNaN(float64(r)) {
+		return []byte("null"), nil
+	}
+	return json.Marshal(float64(r))
+}
+
+// Point는 자산 곡선의 한 점입니다
+type Point struct {
+	Time   time.Time
+	Equity float64
+}
+
+// Trade는 매수부터 매도까지 한 번의 거래입니다
+type Trade struct {
+	Market     string
+	Stage      string
+	EntryTime  time.Time // 매수 주문이 장부에 없으면 비어 있습니다
+	ExitTime   time.Time
+	EntryPrice float64
+	ExitPrice  float64
+	Quantity   float64
+	Profit     float64 // 매도 주문의 실현 손익
+	Return     float64 // 진입 금액 대비 손익
+}
+
+// Metrics는 성과 지표입니다. 비율은 모두 소수(0.1 = 10%)이며 샤프/소르티노는 무위험 수익률 0으로 연율화합니다
+type Metrics struct {
+	From                time.Time
+	To                  time.Time
+	Capital             float64
+	FinalEquity         float64
+	TotalReturn         float64
+	CAGR                Ratio // 기간이 짧으면 무한대가 될 수 있습니다
+	Sharpe              Ratio
+	Sortino             Ratio
+	Calmar              Ratio   // CAGR / 최대 낙폭
+	MaxDrawdown         float64 // 최대 낙폭 (양수)
+	MaxDrawdownDuration time.Duration
+	Trades              int // 청산된 거래 수
+	Wins                int
+	WinRate             float64
+	AverageWin          float64 // 이익 거래의 평균 손익
+	AverageLoss         float64 // 손실 거래의 평균 손익 (음수)
+	ProfitFactor        Ratio   // 총이익 / 총손실. 손실 거래가 없고 이익이 있으면 무한대
+	Expectancy          float64 // 거래당 평균 손익
+	Exposure            float64 // 기간 중 포지션을 하나 이상 보유한 시간 비율
+}
+
+// Breakdown은 마켓 또는 진입 단계별 거래 집계입니다
+type Breakdown struct {
+	Key            string
+	Trades         int
+	Wins           int
+	WinRate        float64
+	Profit         float64
+	AverageReturn  float64
+	AverageWin     float64
+	AverageLoss    float64
+	ProfitFactor   Ratio
+	Expectancy     float64
+	AverageHolding time.Duration // 매수 주문이 있는 거래만 계산합니다
+}
+
+// Report는 성과 보고서입니다
+type Report struct {
+	Title    string
+	Metrics  Metrics
+	ByMarket []Breakdown
+	ByStage  []Breakdown
+	Trades   []Trade
+	Equity   []Point
+}
+
+// Analyze는 capital로 시작한 주문 기록(시간순)과 자산 곡선으로 보고서를 만듭니다.
+// 자산 곡선이 없으면 capital에 실현 손익을 더한 곡선을 사용합니다
+func Analyze(title string, capital float64, orders []model.Order, equity []Point) *Report {
+	trades, open := Trades(orders)
+	if len(equity) == 0 {
+		equity = RealizedEquity(capital, orders)
+	}
+
+	m := Metrics{Capital: capital, FinalEquity: capital}
+	if len(equity) > 0 {
+		m.From, m.To = equity[0].Time, equity[len(equity)-1].Time
+		m.FinalEquity = equity[len(equity)-1].Equity
+	}
+	if capital > 0 {
+		m.TotalReturn = m.FinalEquity/capital - 1
+	}
+	if span := m.To.Sub(m.From); span > 0 && capital > 0 && m.FinalEquity > 0 {
+		m.CAGR = Ratio(math.Pow(m.FinalEquity/capital, float64(YEAR)/float64(span)) - 1)
+	}
+	m.MaxDrawdown, m.MaxDrawdownDuration = drawdown(capital, equity)
+	m.Sharpe, m.Sortino = sharpe(capital, equity)
+	if m.MaxDrawdown > 0 {
+		m.Calmar = m.CAGR / Ratio(m.MaxDrawdown)
+	} else if m.CAGR > 0 {
+		m.Calmar = Ratio(math.Inf(1))
+	}
+
+	all := summarize("", trades)
+	m.Trades, m.Wins, m.WinRate = all.Trades, all.Wins, all.WinRate
+	m.AverageWin, m.AverageLoss, m.ProfitFactor, m.Expectancy = all.AverageWin, all.AverageLoss, all.ProfitFactor, all.Expectancy
+	m.Exposure = exposure(trades, open, m.From, m.To)
+
+	return &Report{
+		Title:    title,
+		Metrics:  m,
+		ByMarket: breakdown(trades, func(t Trade) string { return t.Market }),
+		ByStage: breakdown(trades, func(t Trade) string {
+			if t.Stage == "" {
+				return NO_STAGE
+			}
+			return t.Stage
+		}),
+		Trades: trades,
+		Equity: equity,
+	}
+}
+
+// Trades는 마켓마다 매수 주문과 다음 매도 주문을 짝지어 거래로 만듭니다. 청산되지 않은 매수 주문은 open으로 반환합니다.
+// 짝이 되는 매수 주문이 없는 매도 주문(조회 기간 밖에서 진입)은 매도 주문의 손익으로 진입 가격을 추정합니다
+func Trades(orders []model.Order) (trades []Trade, open []model.Order) {
+	sorted := append([]model.Order(nil), orders...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].CreatedAt.Before(sorted[j].CreatedAt) })
+
+	entries := make(map[string]model.Order)
+	for _, order := range sorted {
+		switch order.Side {
+		case model.BUY.String():
+			entries[order.Market] = order
+		case model.SELL.String():
+			trade := Trade{
+				Market:    order.Market,
+				Stage:     order.Stage,
+				ExitTime:  order.CreatedAt,
+				ExitPrice: order.Price,
+				Quantity:  order.Quantity,
+				Profit:    order.Profit,
+			}
+			if entry, exists := entries[order.Market]; exists {
+				trade.EntryTime, trade.EntryPrice = entry.CreatedAt, entry.Price
+				if trade.Stage == "" {
+					trade.Stage = entry.Stage
+				}
+				delete(entries, order.Market)
+			} else if order.Quantity > 0 {
+				trade.EntryPrice = order.Price - order.Profit/order.Quantity
+			}
+			if cost := trade.EntryPrice * trade.Quantity; cost > 0 {
+				trade.Return = trade.Profit / cost
+			}
+			trades = append(trades, trade)
+		}
+	}
+
+	for _, entry := range entries {
+		open = append(open, entry)
+	}
+	sort.Slice(open, func(i, j int) bool { return open[i].CreatedAt.Before(open[j].CreatedAt) })
+	return trades, open
+}
+
+// RealizedEquity는 capital에 매도 주문의 실현 손익을 누적한 자산 곡선입니다. 첫 주문 시각에 capital로 시작합니다
+func RealizedEquity(capital float64, orders []model.Order) []Point {
+	sorted := append([]model.Order(nil), orders...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].CreatedAt.Before(sorted[j].CreatedAt) })
+	if len(sorted) == 0 {
+		return nil
+	}
+
+	equity := capital
+	points := []Point{{Time: sorted[0].CreatedAt, Equity: equity}}
+	for _, order := range sorted {
+		if order.Side != model.SELL.String() {
+			continue
+		}
+		equity += order.Profit
+		points = append(points, Point{Time: order.CreatedAt, Equity: equity})
+	}
+	return points
+}
+
+// Daily는 자산 곡선을 하루 단위(loc 기준 자정)로 다시 샘플링합니다. 첫 점은 그대로 두고, 이후에는 하루의 마지막 값을
+// 다음 날 자정 시각으로 쓰며 거래가 없는 날은 전날 값을 이어 씁니다. 마지막 날은 마지막 점의 시각을 씁니다 (첫 점과 같은 시각이어도 값이 바뀌었으면 남깁니다)
+func Daily(points []Point, loc *time.Location) []Point {
+	if len(points) == 0 {
+		return nil
+	}
+	day := func(t time.Time) time.Time {
+		t = t.In(loc)
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	}
+
+	daily := []Point{points[0]}
+	last, end := points[0].Equity, points[len(points)-1].Time
+	i := 1
+	for d := day(points[0].Time).AddDate(0, 0, 1); ; d = d.AddDate(0, 0, 1) {
+		for ; i < len(points) && points[i].Time.Before(d); i++ {
+			last = points[i].Equity
+		}
+		if !d.Before(end) {
+			for ; i < len(points); i++ {
+				last = points[i].Equity
+			}
+			if end.After(points[0].Time) || last != points[0].Equity {
+				daily = append(daily, Point{Time: end, Equity: last})
+			}
+			return daily
+		}
+		daily = append(daily, Point{Time: d, Equity: last})
+	}
+}
+
+// drawdown은 최대 낙폭과 그 낙폭이 시작된 고점부터 고점을 회복할 때까지(회복하지 못했으면 끝까지)의 기간입니다
+func drawdown(capital float64, equity []Point) (float64, time.Duration) {
+	if len(equity) == 0 {
+		return 0, 0
+	}
+	peak, peakTime := capital, equity[0].Time
+	var maxDrawdown, maxPeak float64
+	var maxPeakTime, troughTime time.Time
+	for _, point := range equity {
+		if point.Equity >= peak {
+			peak, peakTime = point.Equity, point.Time
+			continue
+		}
+		if depth := 1 - point.Equity/peak; peak > 0 && depth > maxDrawdown {
+			maxDrawdown, maxPeak, maxPeakTime, troughTime = depth, peak, peakTime, point.Time
+		}
+	}
+	if maxDrawdown == 0 {
+		return 0, 0
+	}
+
+	recovered := equity[len(equity)-1].Time
+	for _, point := range equity {
+		if point.Time.After(troughTime) && point.Equity >= maxPeak {
+			recovered = point.Time
+			break
+		}
+	}
+	return maxDrawdown, recovered.Sub(maxPeakTime)
+}
+
+// sharpe는 구간 수익률로 샤프와 소르티노 지수를 계산해 연간 구간 수의 제곱근을 곱합니다. 구간 길이는 자산 곡선 간격의 중앙값입니다
+func sharpe(capital float64, equity []Point) (Ratio, Ratio) {
+	if len(equity) < 3 {
+		return 0, 0
+	}
+	returns := make([]float64, 0, len(equity))
+	previous := capital
+	for _, point := range equity {
+		if previous > 0 {
+			returns = append(returns, point.Equity/previous-1)
+		}
+		previous = point.Equity
+	}
+	if len(returns) < 2 {
+		return 0, 0
+	}
+
+	var mean, variance, downside float64
+	for _, r := range returns {
+		mean += r
+	}
+	mean /= float64(len(returns))
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+		downside += min(r, 0) * min(r, 0)
+	}
+	std := math.Sqrt(variance / float64(len(returns)-1))
+	downsideDeviation := math.Sqrt(downside / float64(len(returns)))
+	annualize := math.Sqrt(periodsPerYear(equity))
+
+	var sharpeRatio, sortinoRatio Ratio
+	if std > 0 {
+		sharpeRatio = Ratio(mean / std * annualize)
+	}
+	switch {
+	case downsideDeviation > 0:
+		sortinoRatio = Ratio(mean / downsideDeviation * annualize)
+	case mean > 0:
+		sortinoRatio = Ratio(math.Inf(1))
+	}
+	return sharpeRatio, sortinoRatio
+}
+
+func periodsPerYear(equity []Point) float64 {
+	gaps := make([]time.Duration, 0, len(equity)-1)
+	for i := 1; i < len(equity); i++ {
+		gaps = append(gaps, equity[i].Time.Sub(equity[i-1].Time))
+	}
+	sort.Slice(gaps, func(i, j int) bool { return gaps[i] < gaps[j] })
+	if median := gaps[len(gaps)/2]; median > 0 {
+		return float64(YEAR) / float64(median)
+	}
+	return 1
+}
+
+// exposure는 from~to 중 포지션을 하나 이상 보유한 시간 비율입니다. 청산되지 않은 포지션은 to까지 보유한 것으로 봅니다
+func exposure(trades []Trade, open []model.Order, from, to time.Time) float64 {
+	span := to.Sub(from)
+	if span <= 0 {
+		return 0
+	}
+	type interval struct{ start, end time.Time }
+	var intervals []interval
+	for _, trade := range trades {
+		if !trade.EntryTime.IsZero() {
+			intervals = append(intervals, interval{trade.EntryTime, trade.ExitTime})
+		}
+	}
+	for _, order := range open {
+		intervals = append(intervals, interval{order.CreatedAt, to})
+	}
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i].start.Before(intervals[j].start) })
+
+	var held time.Duration
+	var end time.Time
+	for _, iv := range intervals {
+		start, stop := maxTime(iv.start, from, end), minTime(iv.end, to)
+		if stop.After(start) {
+			held += stop.Sub(start)
+			end = stop
+		}
+	}
+	return float64(held) / float64(span)
+}
+
+// summarize는 거래 목록의 승률, 평균 손익, 손익비를 계산합니다
+func summarize(key string, trades []Trade) Breakdown {
+	b := Breakdown{Key: key, Trades: len(trades)}
+	var grossProfit, grossLoss, returns float64
+	var holding time.Duration
+	held := 0
+	for _, trade := range trades {
+		b.Profit += trade.Profit
+		returns += trade.Return
+		if trade.Profit > 0 {
+			b.Wins++
+			grossProfit += trade.Profit
+		} else {
+			grossLoss -= trade.Profit
+		}
+		if !trade.EntryTime.IsZero() {
+			holding += trade.ExitTime.Sub(trade.EntryTime)
+			held++
+		}
+	}
+	if b.Trades == 0 {
+		return b
+	}
+
+	b.WinRate = float64(b.Wins) / float64(b.Trades)
+	b.AverageReturn = returns / float64(b.Trades)
+	b.Expectancy = b.Profit / float64(b.Trades)
+	if b.Wins > 0 {
+		b.AverageWin = grossProfit / float64(b.Wins)
+	}
+	if losses := b.Trades - b.Wins; losses > 0 {
+		b.AverageLoss = -grossLoss / float64(losses)
+	}
+	switch {
+	case grossLoss > 0:
+		b.ProfitFactor = Ratio(grossProfit / grossLoss)
+	case grossProfit > 0:
+		b.ProfitFactor = Ratio(math.Inf(1))
+	}
+	if held > 0 {
+		b.AverageHolding = holding / time.Duration(held)
+	}
+	return b
+}
+
+// breakdown은 key로 묶은 거래 집계를 키 순서로 반환합니다
+func breakdown(trades []Trade, key func(Trade) string) []Breakdown {
+	groups := make(map[string][]Trade)
+	for _, trade := range trades {
+		groups[key(trade)] = append(groups[key(trade)], trade)
+	}
+	result := make([]Breakdown, 0, len(groups))
+	for k, group := range groups {
+		result = append(result, summarize(k, group))
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Key < result[j].Key })
+	return result
+}
+
+func maxTime(times ...time.Time) time.Time {
+	latest := times[0]
+	for _, t := range times[1:] {
+		if t.After(latest) {
+			latest = t
+		}
+	}
+	return latest
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
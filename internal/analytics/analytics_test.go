@@ -0,0 +1,223 @@
+package analytics
+
+import (
+	"encoding/json"
+	"go-trading-bot/internal/model"
+	"go-trading-bot/internal/scheduler"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const epsilon = 1e-9
+
+var day0 = time.Date(2025, 1, 1, 0, 0, 0, 0, scheduler.KST)
+
+// days는 day0부터 하루 간격의 자산 곡선입니다
+func days(equity ...float64) []Point {
+	points := make([]Point, len(equity))
+	for i, e := range equity {
+		points[i] = Point{Time: day0.AddDate(0, 0, i), Equity: e}
+	}
+	return points
+}
+
+func near(got, want float64) bool {
+	if math.IsInf(want, 0) {
+		return got == want
+	}
+	return math.Abs(got-want) < epsilon
+}
+
+func TestAnalyzeReturns(t *testing.T) {
+	tests := []struct {
+		name   string
+		equity []Point
+		total  float64
+		cagr   float64
+	}{
+		// 2년 동안 100 → 121: (1.21)^(1/2) - 1 = 10%
+		{"two years", []Point{{day0, 100}, {day0.Add(2 * YEAR), 121}}, 0.21, 0.1},
+		// 반년 동안 100 → 110: 1.1^2 - 1 = 21%
+		{"half year", []Point{{day0, 100}, {day0.Add(YEAR / 2), 110}}, 0.1, 0.21},
+		{"single point", []Point{{day0, 110}}, 0.1, 0},
+		{"wiped out", []Point{{day0, 100}, {day0.Add(YEAR), 0}}, -1, 0},
+		// 짧은 기간의 큰 수익은 연율화하면 무한대가 됩니다 (JSON에서는 null)
+		{"one minute", []Point{{day0, 100}, {day0.Add(time.Minute), 200}}, 1, math.Inf(1)},
+	}
+	for _, tt := range tests {
+		m := Analyze(tt.name, 100, nil, tt.equity).Metrics
+		if !near(m.TotalReturn, tt.total) || !near(float64(m.CAGR), tt.cagr) {
+			t.Errorf("%s: total return %v, CAGR %v, want %v, %v", tt.name, m.TotalReturn, m.CAGR, tt.total, tt.cagr)
+		}
+	}
+}
+
+func TestReportJSONWithInfiniteRatios(t *testing.T) {
+	report := Analyze("test", 100, []model.Order{
+		order("KRW-BTC", model.BUY, day0, 100, 1, 0),
+		order("KRW-BTC", model.SELL, day0.Add(time.Minute), 200, 1, 100),
+	}, nil)
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	for _, field := range []string{`"CAGR":null`, `"Calmar":null`, `"ProfitFactor":null`} {
+		if !strings.Contains(string(data), field) {
+			t.Errorf("report JSON has no %s: %s", field, data)
+		}
+	}
+}
+
+func TestAnalyzeSharpeSortino(t *testing.T) {
+	tests := []struct {
+		name    string
+		equity  []Point
+		sharpe  float64
+		sortino float64
+	}{
+		// 일간 수익률 0, +10%, -10%, +10%: 평균 0.025, 표본 분산 0.0275/3, 하방 편차 √(0.01/4) = 0.05
+		{"daily", days(100, 110, 99, 108.9), 0.025 / math.Sqrt(0.0275/3) * math.Sqrt(365), 0.025 / 0.05 * math.Sqrt(365)},
+		// 같은 수익률을 7일 간격으로 기록하면 연 52.14구간으로 연율화합니다
+		{"weekly", []Point{{day0, 100}, {day0.AddDate(0, 0, 7), 110}, {day0.AddDate(0, 0, 14), 99}, {day0.AddDate(0, 0, 21), 108.9}},
+			0.025 / math.Sqrt(0.0275/3) * math.Sqrt(365.0/7), 0.025 / 0.05 * math.Sqrt(365.0/7)},
+		// 손실 구간이 없으면 소르티노는 무한대입니다: 수익률 0, +10%, +10%
+		{"no losses", days(100, 110, 121), (0.2 / 3) / math.Sqrt((2*(0.1-0.2/3)*(0.1-0.2/3)+(0.2/3)*(0.2/3))/2) * math.Sqrt(365), math.Inf(1)},
+		{"flat", days(100, 100, 100), 0, 0},
+		{"too short", days(100, 110), 0, 0},
+	}
+	for _, tt := range tests {
+		m := Analyze(tt.name, 100, nil, tt.equity).Metrics
+		if !near(float64(m.Sharpe), tt.sharpe) || !near(float64(m.Sortino), tt.sortino) {
+			t.Errorf("%s: sharpe %v, sortino %v, want %v, %v", tt.name, m.Sharpe, m.Sortino, tt.sharpe, tt.sortino)
+		}
+	}
+}
+
+func TestAnalyzeDrawdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		equity   []Point
+		depth    float64
+		duration time.Duration
+	}{
+		// 1일차 고점 110 → 2일차 99 (-10%) → 4일차 121로 회복
+		{"recovered", days(100, 110, 99, 108.9, 121), 0.1, 3 * 24 * time.Hour},
+		// 1일차 고점 120 → 2일차 90 (-25%), 끝까지 회복하지 못함
+		{"not recovered", days(100, 120, 90, 100), 0.25, 2 * 24 * time.Hour},
+		// 더 깊은 두 번째 낙폭을 기준으로 합니다: 110 → 104.5 (-5%), 130 → 91 (-30%)
+		{"deepest", days(100, 110, 104.5, 130, 91, 130), 0.3, 2 * 24 * time.Hour},
+		// 시작 자산보다 먼저 떨어진 경우: 100 → 80
+		{"below capital", days(80, 100), 0.2, 24 * time.Hour},
+		{"rising", days(100, 110, 120), 0, 0},
+	}
+	for _, tt := range tests {
+		m := Analyze(tt.name, 100, nil, tt.equity).Metrics
+		if !near(m.MaxDrawdown, tt.depth) || m.MaxDrawdownDuration != tt.duration {
+			t.Errorf("%s: drawdown %v over %v, want %v over %v", tt.name, m.MaxDrawdown, m.MaxDrawdownDuration, tt.depth, tt.duration)
+		}
+	}
+}
+
+func order(market string, side model.SignalType, at time.Time, price, quantity, profit float64) model.Order {
+	return model.Order{Market: market, Side: side.String(), CreatedAt: at, Price: price, Quantity: quantity, Profit: profit}
+}
+
+func TestAnalyzeExposure(t *testing.T) {
+	at := func(hours int) time.Time { return day0.Add(time.Duration(hours) * time.Hour) }
+	tests := []struct {
+		name     string
+		orders   []model.Order
+		exposure float64
+	}{
+		{"none", nil, 0},
+		// 0~24시간 보유: 96시간 중 24시간
+		{"single", []model.Order{
+			order("KRW-BTC", model.BUY, at(0), 10, 1, 0),
+			order("KRW-BTC", model.SELL, at(24), 11, 1, 1),
+		}, 0.25},
+		// 겹치는 보유 구간은 한 번만 셉니다: 0~24, 12~48 → 0~48
+		{"overlapping", []model.Order{
+			order("KRW-BTC", model.BUY, at(0), 10, 1, 0),
+			order("KRW-ETH", model.BUY, at(12), 10, 1, 0),
+			order("KRW-BTC", model.SELL, at(24), 11, 1, 1),
+			order("KRW-ETH", model.SELL, at(48), 9, 1, -1),
+		}, 0.5},
+		// 청산되지 않은 포지션은 끝(96시간)까지 보유한 것으로 봅니다: 0~48, 72~96
+		{"open", []model.Order{
+			order("KRW-BTC", model.BUY, at(0), 10, 1, 0),
+			order("KRW-BTC", model.SELL, at(48), 11, 1, 1),
+			order("KRW-XRP", model.BUY, at(72), 10, 1, 0),
+		}, 0.75},
+		// 매수 주문이 기간 밖이면 보유 시간을 알 수 없습니다
+		{"entry outside period", []model.Order{
+			order("KRW-BTC", model.SELL, at(24), 11, 1, 1),
+		}, 0},
+	}
+	for _, tt := range tests {
+		m := Analyze(tt.name, 100, tt.orders, days(100, 100, 100, 100, 100)).Metrics
+		if !near(m.Exposure, tt.exposure) {
+			t.Errorf("%s: exposure %v, want %v", tt.name, m.Exposure, tt.exposure)
+		}
+	}
+}
+
+func TestDaily(t *testing.T) {
+	at := func(day, hour int) time.Time { return day0.AddDate(0, 0, day).Add(time.Duration(hour) * time.Hour) }
+	tests := []struct {
+		name   string
+		points []Point
+		want   []Point
+	}{
+		{"empty", nil, nil},
+		{"single", []Point{{at(0, 10), 100}}, []Point{{at(0, 10), 100}}},
+		{"same day", []Point{{at(0, 10), 100}, {at(0, 15), 105}}, []Point{{at(0, 10), 100}, {at(0, 15), 105}}},
+		// 하루의 마지막 값을 다음 날 자정에 기록하고, 거래가 없는 날은 전날 값을 이어 씁니다
+		{"gap", []Point{{at(0, 10), 100}, {at(0, 15), 105}, {at(2, 9), 110}, {at(2, 18), 120}},
+			[]Point{{at(0, 10), 100}, {at(1, 0), 105}, {at(2, 0), 105}, {at(2, 18), 120}}},
+		// 첫 점과 같은 시각에 바뀐 값도 남깁니다
+		{"same instant", []Point{{at(0, 10), 100}, {at(0, 10), 120}}, []Point{{at(0, 10), 100}, {at(0, 10), 120}}},
+		{"same instant unchanged", []Point{{at(0, 10), 100}, {at(0, 10), 100}}, []Point{{at(0, 10), 100}}},
+		// 마지막 점이 자정이면 그 시각으로 끝납니다
+		{"ends at midnight", []Point{{at(0, 10), 100}, {at(1, 0), 105}}, []Point{{at(0, 10), 100}, {at(1, 0), 105}}},
+		// 자정은 KST 기준입니다: 2024-12-31 16:00 UTC는 2025-01-01 01:00 KST
+		{"kst midnight", []Point{{at(0, -10), 100}, {at(0, 1), 110}, {at(0, 5), 120}},
+			[]Point{{at(0, -10), 100}, {at(0, 0), 100}, {at(0, 5), 120}}},
+	}
+	for _, tt := range tests {
+		got := Daily(tt.points, scheduler.KST)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: Daily = %v, want %v", tt.name, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if !got[i].Time.Equal(tt.want[i].Time) || got[i].Equity != tt.want[i].Equity {
+				t.Errorf("%s: Daily = %v, want %v", tt.name, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestTradesPairsOrders(t *testing.T) {
+	orders := []model.Order{
+		order("KRW-BTC", model.BUY, day0, 100, 2, 0),
+		order("KRW-BTC", model.SELL, day0.Add(time.Hour), 110, 2, 20),
+		// 매수 주문이 없으면 손익으로 진입가를 추정합니다: 50 - (-10 / 1) = 60
+		order("KRW-ETH", model.SELL, day0.Add(2*time.Hour), 50, 1, -10),
+		order("KRW-XRP", model.BUY, day0.Add(3*time.Hour), 1, 10, 0),
+	}
+	trades, open := Trades(orders)
+	want := []Trade{
+		{Market: "KRW-BTC", EntryTime: day0, ExitTime: day0.Add(time.Hour), EntryPrice: 100, ExitPrice: 110, Quantity: 2, Profit: 20, Return: 0.1},
+		{Market: "KRW-ETH", ExitTime: day0.Add(2 * time.Hour), EntryPrice: 60, ExitPrice: 50, Quantity: 1, Profit: -10, Return: -10.0 / 60},
+	}
+	if !reflect.DeepEqual(trades, want) {
+		t.Errorf("trades = %+v, want %+v", trades, want)
+	}
+	if len(open) != 1 || open[0].Market != "KRW-XRP" {
+		t.Errorf("open = %+v, want the KRW-XRP entry", open)
+	}
+}
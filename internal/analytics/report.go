@@ -0,0 +1,266 @@
+package analytics
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-trading-bot/internal/scheduler"
+	"html/template"
+	"io"
+	"math"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// 보고서 형식
+const (
+	FORMAT_JSON     = "json"
+	FORMAT_MARKDOWN = "markdown"
+	FORMAT_HTML     = "html"
+)
+
+// Formats는 지원하는 보고서 형식 목록입니다
+func Formats() []string {
+	return []string{FORMAT_JSON, FORMAT_MARKDOWN, FORMAT_HTML}
+}
+
+// FormatFromPath는 파일 확장자로 보고서 형식을 정합니다 (.json, .md, .html). 모르는 확장자는 빈 문자열입니다
+func FormatFromPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FORMAT_JSON
+	case ".md", ".markdown":
+		return FORMAT_MARKDOWN
+	case ".html", ".htm":
+		return FORMAT_HTML
+	default:
+		return ""
+	}
+}
+
+// Write는 보고서를 format 형식으로 씁니다
+func (r *Report) Write(w io.Writer, format string) error {
+	switch format {
+	case FORMAT_JSON:
+		return r.WriteJSON(w)
+	case FORMAT_MARKDOWN:
+		return r.WriteMarkdown(w)
+	case FORMAT_HTML:
+		return r.WriteHTML(w)
+	default:
+		return fmt.Errorf("unknown report format %q (supported: %s)", format, strings.Join(Formats(), ", "))
+	}
+}
+
+// WriteJSON은 보고서를 JSON으로 씁니다
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// row는 표의 한 줄입니다. 보고서 형식마다 같은 항목과 표기를 씁니다
+type row struct {
+	Label string
+	Value string
+}
+
+func (r *Report) summaryRows() []row {
+	m := r.Metrics
+	return []row{
+		{"기간", fmt.Sprintf("%s ~ %s", formatTime(m.From), formatTime(m.To))},
+		{"시작 자산", formatAmount(m.Capital)},
+		{"최종 자산", formatAmount(m.FinalEquity)},
+		{"총 수익률", formatPercent(m.TotalReturn)},
+		{"CAGR", formatPercent(float64(m.CAGR))},
+		{"샤프 지수", formatRatio(m.Sharpe)},
+		{"소르티노 지수", formatRatio(m.Sortino)},
+		{"칼마 지수", formatRatio(m.Calmar)},
+		{"최대 낙폭", formatPercent(m.MaxDrawdown)},
+		{"최대 낙폭 기간", formatDuration(m.MaxDrawdownDuration)},
+		{"거래 수", fmt.Sprintf("%d", m.Trades)},
+		{"승률", formatPercent(m.WinRate)},
+		{"평균 이익", formatAmount(m.AverageWin)},
+		{"평균 손실", formatAmount(m.AverageLoss)},
+		{"손익비 (PF)", formatRatio(m.ProfitFactor)},
+		{"기대값 (거래당)", formatAmount(m.Expectancy)},
+		{"노출 시간", formatPercent(m.Exposure)},
+	}
+}
+
+var breakdownHeader = []string{"거래", "승률", "손익", "평균 수익률", "평균 이익", "평균 손실", "PF", "기대값", "평균 보유"}
+
+func breakdownRow(b Breakdown) []string {
+	return []string{
+		b.Key,
+		fmt.Sprintf("%d", b.Trades),
+		formatPercent(b.WinRate),
+		formatAmount(b.Profit),
+		formatPercent(b.AverageReturn),
+		formatAmount(b.AverageWin),
+		formatAmount(b.AverageLoss),
+		formatRatio(b.ProfitFactor),
+		formatAmount(b.Expectancy),
+		formatDuration(b.AverageHolding),
+	}
+}
+
+// WriteMarkdown은 보고서를 Markdown 표로 씁니다
+func (r *Report) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", r.Title)
+	b.WriteString("| 지표 | 값 |\n| --- | ---: |\n")
+	for _, row := range r.summaryRows() {
+		fmt.Fprintf(&b, "| %s | %s |\n", row.Label, row.Value)
+	}
+
+	for _, section := range []struct {
+		title string
+		rows  []Breakdown
+	}{
+		{"마켓별", r.ByMarket},
+		{"진입 단계별", r.ByStage},
+	} {
+		fmt.Fprintf(&b, "\n## %s\n\n", section.title)
+		if len(section.rows) == 0 {
+			b.WriteString("청산된 거래가 없습니다.\n")
+			continue
+		}
+		header := append([]string{""}, breakdownHeader...)
+		b.WriteString("| " + strings.Join(header, " | ") + " |\n")
+		b.WriteString("| --- |" + strings.Repeat(" ---: |", len(header)-1) + "\n")
+		for _, breakdown := range section.rows {
+			b.WriteString("| " + strings.Join(breakdownRow(breakdown), " | ") + " |\n")
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="ko">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", sans-serif; margin: 2rem; color: #222; }
+table { border-collapse: collapse; margin-bottom: 2rem; }
+th, td { border-bottom: 1px solid #ddd; padding: 0.3rem 0.8rem; text-align: right; }
+th:first-child, td:first-child { text-align: left; }
+svg { border: 1px solid #ddd; margin-bottom: 2rem; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{if .Curve}}<svg viewBox="0 0 {{.Width}} {{.Height}}" width="{{.Width}}" height="{{.Height}}"><polyline fill="none" stroke="#2b6cb0" stroke-width="1.5" points="{{.Curve}}"/></svg>{{end}}
+<table>
+{{range .Summary}}<tr><th>{{.Label}}</th><td>{{.Value}}</td></tr>
+{{end}}</table>
+{{range .Sections}}<h2>{{.Title}}</h2>
+{{if .Rows}}<table>
+<tr>{{range .Header}}<th>{{.}}</th>{{end}}</tr>
+{{range .Rows}}<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+{{end}}</table>
+{{else}}<p>청산된 거래가 없습니다.</p>
+{{end}}{{end}}</body>
+</html>
+`))
+
+const (
+	CHART_WIDTH  = 800
+	CHART_HEIGHT = 240
+)
+
+// WriteHTML은 자산 곡선 차트를 포함한 단일 HTML 보고서를 씁니다. 외부 파일이나 CDN을 쓰지 않습니다
+func (r *Report) WriteHTML(w io.Writer) error {
+	type section struct {
+		Title  string
+		Header []string
+		Rows   [][]string
+	}
+	var sections []section
+	for _, s := range []struct {
+		title string
+		rows  []Breakdown
+	}{
+		{"마켓별", r.ByMarket},
+		{"진입 단계별", r.ByStage},
+	} {
+		rows := make([][]string, 0, len(s.rows))
+		for _, breakdown := range s.rows {
+			rows = append(rows, breakdownRow(breakdown))
+		}
+		sections = append(sections, section{Title: s.title, Header: append([]string{""}, breakdownHeader...), Rows: rows})
+	}
+
+	return htmlTemplate.Execute(w, map[string]any{
+		"Title":    r.Title,
+		"Width":    CHART_WIDTH,
+		"Height":   CHART_HEIGHT,
+		"Curve":    polyline(r.Equity, CHART_WIDTH, CHART_HEIGHT),
+		"Summary":  r.summaryRows(),
+		"Sections": sections,
+	})
+}
+
+// polyline은 자산 곡선을 SVG polyline 좌표로 바꿉니다
+func polyline(equity []Point, width, height float64) string {
+	if len(equity) < 2 {
+		return ""
+	}
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, point := range equity {
+		low, high = min(low, point.Equity), max(high, point.Equity)
+	}
+	start, span := equity[0].Time, equity[len(equity)-1].Time.Sub(equity[0].Time)
+	if span <= 0 || high <= low {
+		high = low + 1
+	}
+
+	points := make([]string, len(equity))
+	for i, point := range equity {
+		x := float64(i) / float64(len(equity)-1) * width
+		if span > 0 {
+			x = float64(point.Time.Sub(start)) / float64(span) * width
+		}
+		y := height - (point.Equity-low)/(high-low)*(height-10) - 5
+		points[i] = fmt.Sprintf("%.1f,%.1f", x, y)
+	}
+	return strings.Join(points, " ")
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.In(scheduler.KST).Format("2006-01-02 15:04")
+}
+
+func formatAmount(value float64) string {
+	return fmt.Sprintf("%.0f", value)
+}
+
+func formatPercent(value float64) string {
+	if math.IsInf(value, 1) {
+		return "∞"
+	}
+	return fmt.Sprintf("%.2f%%", value*100)
+}
+
+func formatRatio(value Ratio) string {
+	if math.IsInf(float64(value), 1) {
+		return "∞"
+	}
+	return fmt.Sprintf("%.2f", float64(value))
+}
+
+func formatDuration(d time.Duration) string {
+	if d <= 0 {
+		return "-"
+	}
+	days, hours := int(d/(24*time.Hour)), int(d%(24*time.Hour)/time.Hour)
+	if days > 0 {
+		return fmt.Sprintf("%d일 %d시간", days, hours)
+	}
+	return fmt.Sprintf("%d시간 %d분", hours, int(d%time.Hour/time.Minute))
+}
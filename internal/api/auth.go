@@ -0,0 +1,339 @@
+package api
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"go-trading-bot/config"
+	"go-trading-bot/internal/handler"
+	"go-trading-bot/internal/logger"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Role string
+
+const (
+	ROLE_READ     Role = "read"     // 조회 API만 사용 가능
+	ROLE_OPERATOR Role = "operator" // 조회 및 제어 API 사용 가능
+)
+
+const (
+	roleKey            = "role"
+	signatureMaxSkew   = 5 * time.Minute
+	rateLimiterMaxIdle = 10 * time.Minute
+	maxRequestBodySize = 1 << 20 // 인증 전에 읽을 수 있는 요청 본문 최대 크기(바이트)
+)
+
+// APIKey는 API 인증 키입니다
+type APIKey struct {
+	ID     string
+	Secret string
+	Role   Role
+}
+
+// Authenticator는 API 키 또는 HMAC 서명으로 요청을 인증합니다.
+//
+// API 키 방식: X-API-Key: <secret> 또는 Authorization: Bearer <secret>
+// HMAC 방식: X-API-Key-Id: <id>, X-Timestamp: <unix 초>,
+// X-Signature: hex(HMAC-SHA256(secret, timestamp + "\n" + METHOD + "\n" + 경로?쿼리 + "\n" + body))
+// 같은 서명은 허용 시각 오차 동안 한 번만 사용할 수 있습니다 (재전송 방지)
+type Authenticator struct {
+	keys       map[string]APIKey
+	signatures *replayCache
+}
+
+// NewAuthenticator는 "id:secret:role,..." 형식의 키 목록으로 Authenticator를 생성합니다
+func NewAuthenticator(apiKeys string) *Authenticator {
+	a := &Authenticator{keys: make(map[string]APIKey), signatures: newReplayCache(2 * signatureMaxSkew)}
+	for _, entry := range splitList(apiKeys) {
+		parts := strings.Split(entry, ":")
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+			logger.Log.Errorf("API_KEYS 항목 형식이 올바르지 않습니다 (id:secret:role). 무시합니다. 🔴")
+			continue
+		}
+		role := Role(parts[2])
+		if role != ROLE_READ && role != ROLE_OPERATOR {
+			logger.Log.Errorf("[%v] API 키 역할은 read 또는 operator여야 합니다. 무시합니다. 🔴", parts[0])
+			continue
+		}
+		a.keys[parts[0]] = APIKey{ID: parts[0], Secret: parts[1], Role: role}
+	}
+
+	if len(a.keys) == 0 {
+		logger.Log.Warn("API_KEYS가 설정되지 않았습니다. /health 외의 모든 API 요청이 거부됩니다. 🟠")
+	}
+	return a
+}
+
+// Middleware는 요청을 인증하고 키 ID와 역할을 gin.Context에 저장합니다
+func (a *Authenticator) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Body != nil {
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxRequestBodySize)
+		}
+
+		key, ok := a.authenticate(c)
+		if !ok {
+			c.AbortWithStatusJSON(401, gin.H{
+				"success": false,
+				"message": "authentication required",
+			})
+			return
+		}
+
+		c.Set(handler.ActorKey, key.ID)
+		c.Set(roleKey, key.Role)
+		c.Next()
+	}
+}
+
+func (a *Authenticator) authenticate(c *gin.Context) (APIKey, bool) {
+	secret := c.GetHeader("X-API-Key")
+	if bearer, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); ok && secret == "" {
+		secret = strings.TrimSpace(bearer)
+	}
+	if secret != "" {
+		// 모든 키와 비교해 응답 시간으로 키 존재 여부가 드러나지 않게 합니다
+		var matched APIKey
+		found := false
+		for _, key := range a.keys {
+			if subtle.ConstantTimeCompare([]byte(secret), []byte(key.Secret)) == 1 {
+				matched, found = key, true
+			}
+		}
+		return matched, found
+	}
+
+	if id := c.GetHeader("X-API-Key-Id"); id != "" {
+		key, exists := a.keys[id]
+		if !exists {
+			return APIKey{}, false
+		}
+		return key, a.verifySignature(c, key)
+	}
+
+	return APIKey{}, false
+}
+
+func (a *Authenticator) verifySignature(c *gin.Context, key APIKey) bool {
+	timestamp := c.GetHeader("X-Timestamp")
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	now := time.Now()
+	if skew := now.Sub(time.Unix(unix, 0)); math.Abs(float64(skew)) > float64(signatureMaxSkew) {
+		return false
+	}
+
+	var body []byte
+	if c.Request.Body != nil {
+		if body, err = io.ReadAll(c.Request.Body); err != nil {
+			return false
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	mac := hmac.New(sha256.New, []byte(key.Secret))
+	mac.Write([]byte(timestamp + "\n" + c.Request.Method + "\n" + c.Request.URL.RequestURI() + "\n"))
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(expected), []byte(strings.ToLower(c.GetHeader("X-Signature")))) {
+		return false
+	}
+	if !a.signatures.add(key.ID+":"+expected, now) {
+		logger.Log.Warnf("[%v] 이미 사용된 서명의 API 요청입니다 (재전송 의심): %v %v 🟠", key.ID, c.ClientIP(), c.Request.URL.Path)
+		return false
+	}
+	return true
+}
+
+// replayCache는 유효 기간 동안 사용된 서명을 기억합니다
+type replayCache struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	seen      map[string]time.Time
+	lastSweep time.Time
+}
+
+func newReplayCache(ttl time.Duration) *replayCache {
+	return &replayCache{ttl: ttl, seen: make(map[string]time.Time)}
+}
+
+// add는 처음 본 서명이면 기록하고 true를, 유효 기간 안에 이미 사용된 서명이면 false를 반환합니다
+func (r *replayCache) add(signature string, now time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if now.Sub(r.lastSweep) > r.ttl/2 {
+		for k, seenAt := range r.seen {
+			if now.Sub(seenAt) > r.ttl {
+				delete(r.seen, k)
+			}
+		}
+		r.lastSweep = now
+	}
+
+	if seenAt, exists := r.seen[signature]; exists && now.Sub(seenAt) <= r.ttl {
+		return false
+	}
+	r.seen[signature] = now
+	return true
+}
+
+// RequireRole은 인증된 키의 역할이 role 이상인 요청만 통과시킵니다
+func RequireRole(role Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		current, _ := c.Get(roleKey)
+		if role == ROLE_OPERATOR && current != ROLE_OPERATOR {
+			c.AbortWithStatusJSON(403, gin.H{
+				"success": false,
+				"message": "operator role required",
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// IPAllowlist는 허용된 IP 또는 CIDR에서 온 요청만 통과시킵니다. 목록이 비어 있으면 모두 허용합니다
+func IPAllowlist(allowlist string) gin.HandlerFunc {
+	var networks []*net.IPNet
+	for _, entry := range splitList(allowlist) {
+		if !strings.Contains(entry, "/") {
+			if strings.Contains(entry, ":") {
+				entry += "/128"
+			} else {
+				entry += "/32"
+			}
+		}
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			logger.Log.Errorf("API_IP_ALLOWLIST 항목이 올바르지 않습니다 (%v). 무시합니다. 🔴", entry)
+			continue
+		}
+		networks = append(networks, network)
+	}
+
+	return func(c *gin.Context) {
+		if len(networks) == 0 {
+			c.Next()
+			return
+		}
+
+		ip := net.ParseIP(c.ClientIP())
+		for _, network := range networks {
+			if ip != nil && network.Contains(ip) {
+				c.Next()
+				return
+			}
+		}
+
+		logger.Log.Warnf("허용되지 않은 IP의 API 요청입니다: %v %v 🟠", c.ClientIP(), c.Request.URL.Path)
+		c.AbortWithStatusJSON(403, gin.H{
+			"success": false,
+			"message": "IP not allowed",
+		})
+	}
+}
+
+// RateLimit은 클라이언트 IP별로 분당 요청 수를 제한합니다. 인증 전에 적용되어 키 대입 시도도 제한합니다. 0이면 제한하지 않습니다
+func RateLimit(requestsPerMinute int) gin.HandlerFunc {
+	limiter := &rateLimiter{
+		rate:    float64(requestsPerMinute) / 60,
+		burst:   float64(requestsPerMinute),
+		buckets: make(map[string]*tokenBucket),
+	}
+
+	return func(c *gin.Context) {
+		if requestsPerMinute <= 0 {
+			c.Next()
+			return
+		}
+
+		if retryAfter, ok := limiter.allow(c.ClientIP(), time.Now()); !ok {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			c.AbortWithStatusJSON(429, gin.H{
+				"success": false,
+				"message": "too many requests",
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+type tokenBucket struct {
+	tokens   float64
+	updated  time.Time
+	lastSeen time.Time
+}
+
+type rateLimiter struct {
+	mu        sync.Mutex
+	rate      float64 // 초당 충전되는 토큰 수
+	burst     float64
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+func (r *rateLimiter) allow(client string, now time.Time) (time.Duration, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if now.Sub(r.lastSweep) > rateLimiterMaxIdle {
+		for k, b := range r.buckets {
+			if now.Sub(b.lastSeen) > rateLimiterMaxIdle {
+				delete(r.buckets, k)
+			}
+		}
+		r.lastSweep = now
+	}
+
+	bucket, exists := r.buckets[client]
+	if !exists {
+		bucket = &tokenBucket{tokens: r.burst, updated: now}
+		r.buckets[client] = bucket
+	}
+	bucket.lastSeen = now
+
+	bucket.tokens = math.Min(r.burst, bucket.tokens+now.Sub(bucket.updated).Seconds()*r.rate)
+	bucket.updated = now
+	if bucket.tokens < 1 {
+		return time.Duration((1 - bucket.tokens) / r.rate * float64(time.Second)), false
+	}
+	bucket.tokens--
+	return 0, true
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// newSecurityMiddlewares는 설정으로 인증 관련 미들웨어를 생성합니다. 적용 순서: IP 허용 목록 → 요청 수 제한 → 인증.
+// 요청 수 제한과 서명 재전송 확인이 라우트 그룹 사이에 공유되도록 한 번만 생성해 사용합니다
+func newSecurityMiddlewares(c *config.Config) []gin.HandlerFunc {
+	return []gin.HandlerFunc{
+		IPAllowlist(c.APIIPAllowlist),
+		RateLimit(c.APIRateLimit),
+		NewAuthenticator(c.APIKeys).Middleware(),
+	}
+}
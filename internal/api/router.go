@@ -1,29 +1,113 @@
 package api
 
 import (
+	"go-trading-bot/config"
+	"go-trading-bot/internal/dashboard"
 	"go-trading-bot/internal/handler"
+	"go-trading-bot/internal/logger"
+	"go-trading-bot/internal/metrics"
 	"go-trading-bot/internal/service"
 
 	"github.com/gin-gonic/gin"
 )
 
-// NewRouter는 TradingBot 인스턴스를 받아 라우터를 생성합니다
-func NewRouter(tradingBot *service.TradingBot) *gin.Engine {
+// NewRouter는 TradingBot 인스턴스를 받아 라우터를 생성합니다. 프록시, IP 허용 목록, 인증 설정은 provider에서 읽습니다
+func NewRouter(tradingBot *service.TradingBot, provider config.Provider) *gin.Engine {
 	router := gin.Default()
 
+	// X-Forwarded-For는 신뢰하는 프록시에서 온 경우에만 사용합니다 (IP 허용 목록, 요청 수 제한 우회 방지)
+	c := provider.Config()
+	if err := router.SetTrustedProxies(splitList(c.APITrustedProxies)); err != nil {
+		logger.Log.Errorf("API_TRUSTED_PROXIES 설정이 올바르지 않습니다: %v 🔴", err)
+	}
+
 	tradingBotHandler := handler.NewHandler(tradingBot)
+	controlHandler := handler.NewControlHandler(tradingBot)
+	eventHandler := handler.NewEventHandler(tradingBot.Events())
+	healthHandler := handler.NewHealthHandler(tradingBot)
+
+	// 대시보드 (정적 파일, 데이터는 API 키로 /api/v1에서 조회)
+	router.StaticFS("/dashboard", dashboard.FileSystem())
+	router.GET("/", func(c *gin.Context) {
+		c.Redirect(302, "/dashboard/")
+	})
+
+	// IP 허용 목록 → 요청 수 제한 → 인증 (read 또는 operator 역할)
+	security := newSecurityMiddlewares(c)
+
+	// Prometheus 메트릭 (인증 필요, 스크레이퍼는 authorization.credentials로 Authorization: Bearer <secret> 헤더 사용)
+	router.GET("/metrics", append(security, gin.WrapH(metrics.Handler()))...)
+
+	// 헬스 체크 (인증 없음, 컨테이너 오케스트레이터용)
+	// GET /health/live: 스케줄러 루프 동작 여부
+	// GET /health/ready: 사이클, 거래소 연결/시계 오차, API 키, 알림 채널, 설정 상태 (fail이 있으면 503)
+	router.GET("/health/live", healthHandler.Live)
+	router.GET("/health/ready", healthHandler.Ready)
 
 	v1Group := router.Group("/api/v1")
 	{
-		// 헬스체크
-		v1Group.GET("/health", func(c *gin.Context) {
-			c.JSON(200, gin.H{"message": "OK"})
-		})
+		// 헬스체크 (인증 없음, /health/live와 같음)
+		v1Group.GET("/health", healthHandler.Live)
+
+		// 이하 API는 인증 필요 (read 또는 operator 역할)
+		readGroup := v1Group.Group("", security...)
 
 		// 트레이딩 신호 조회
 		// GET /api/v1/signal?market=KRW-BTC (특정 마켓)
 		// GET /api/v1/signal (모든 마켓)
-		v1Group.GET("/signal", tradingBotHandler.GetSignal)
+		readGroup.GET("/signal", tradingBotHandler.GetSignal)
+
+		// 분석 사이클 상태 조회
+		readGroup.GET("/cycle", tradingBotHandler.GetCycleStatus)
+
+		// 검증된 마켓 목록 조회
+		readGroup.GET("/markets", tradingBotHandler.GetMarkets)
+
+		// 보유 포지션 조회 (평가손익 포함)
+		// GET /api/v1/positions?page=1&size=20
+		readGroup.GET("/positions", tradingBotHandler.GetPositions)
+
+		// 주문 기록 조회
+		// GET /api/v1/orders?market=KRW-BTC&side=SELL&from=2025-01-01&to=2025-01-31&page=1&size=20
+		readGroup.GET("/orders", tradingBotHandler.GetOrders)
+
+		// 실현 손익 요약 (마켓별, 일자별)
+		// GET /api/v1/pnl?from=2025-01-01&to=2025-01-31
+		readGroup.GET("/pnl", tradingBotHandler.GetPnL)
+
+		// 성과 분석 (CAGR, 샤프/소르티노/칼마, 낙폭, 승률, 손익비, 기대값, 노출 시간, 마켓별/진입 단계별 집계)
+		// GET /api/v1/analytics?market=KRW-BTC&from=2025-01-01&to=2025-01-31&capital=1000000&format=json|markdown|html
+		readGroup.GET("/analytics", tradingBotHandler.GetAnalytics)
+
+		// 현재 전략 및 파라미터 조회
+		readGroup.GET("/strategy", tradingBotHandler.GetStrategy)
+
+		// 대시보드 차트 (캔들 + 전략 이동평균선)
+		// GET /api/v1/chart?market=KRW-BTC&count=120
+		readGroup.GET("/chart", tradingBotHandler.GetChart)
+
+		// 봇 제어 상태 조회 (일시정지 여부, 거래 시간대, 사이클 상태)
+		readGroup.GET("/status", controlHandler.GetStatus)
+
+		// 신호, 주문, 리스크, 사이클 이벤트 스트림 (Server-Sent Events)
+		// GET /api/v1/events?market=KRW-BTC,KRW-ETH&type=order.filled,risk.exit
+		readGroup.GET("/events", eventHandler.Stream)
+
+		// 설정 리로드 (operator 역할 필요)
+		readGroup.POST("/config/reload", RequireRole(ROLE_OPERATOR), tradingBotHandler.ReloadConfig)
+
+		// 제어 API (operator 역할 필요, 모든 요청은 감사 로그에 기록)
+		controlGroup := readGroup.Group("/control", RequireRole(ROLE_OPERATOR))
+		{
+			controlGroup.POST("/pause", controlHandler.Pause)
+			controlGroup.POST("/resume", controlHandler.Resume)
+			controlGroup.POST("/run", controlHandler.Run)
+			controlGroup.POST("/positions/:market/close", controlHandler.ClosePosition)
+			controlGroup.POST("/positions/close-all", controlHandler.CloseAllPositions)
+			controlGroup.POST("/orders", controlHandler.PlaceOrder)
+			controlGroup.POST("/markets", controlHandler.AddMarket)
+			controlGroup.DELETE("/markets/:market", controlHandler.RemoveMarket)
+		}
 	}
 	return router
 }
@@ -0,0 +1,124 @@
+package api_test
+
+import (
+	"context"
+	"go-trading-bot/config"
+	"go-trading-bot/internal/api"
+	"go-trading-bot/internal/model"
+	"go-trading-bot/internal/service/servicetest"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const testSecret = "router-test-secret-5c1e9a"
+
+// TestRouterDuringCycles는 사이클을 돌리는 동안 여러 고루틴에서 API와 조회 메서드를 호출합니다.
+// 공유 상태 접근은 go test -race로 확인합니다
+func TestRouterDuringCycles(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := servicetest.New(&config.TradingConfig{
+		Markets:            []string{"BTC", "ETH"},
+		Strategy:           "moving-average-cross",
+		Candle:             config.Candle{Category: "minutes", Unit: 240},
+		MovingAverageCross: config.MovingAverageCross{ShortPeriod: 2, LongPeriod: 4},
+		OrderAmount:        1000000,
+	}, time.Date(2025, 1, 6, 1, 0, 0, 0, time.UTC))
+	h.Config.Config().APIKeys = "tester:" + testSecret + ":operator"
+	router := api.NewRouter(h.Bot, h.Config)
+
+	requests := []struct {
+		method, target, body string
+	}{
+		{http.MethodGet, "/api/v1/signal", ""},
+		{http.MethodGet, "/api/v1/cycle", ""},
+		{http.MethodGet, "/api/v1/markets", ""},
+		{http.MethodGet, "/api/v1/positions", ""},
+		{http.MethodGet, "/api/v1/orders", ""},
+		{http.MethodGet, "/api/v1/pnl", ""},
+		{http.MethodGet, "/api/v1/analytics", ""},
+		{http.MethodGet, "/api/v1/strategy", ""},
+		{http.MethodGet, "/api/v1/chart?market=KRW-BTC&count=10", ""},
+		{http.MethodGet, "/api/v1/status", ""},
+		{http.MethodGet, "/health/live", ""},
+		{http.MethodGet, "/health/ready", ""},
+		{http.MethodGet, "/metrics", ""},
+		{http.MethodPost, "/api/v1/control/pause", ""},
+		{http.MethodPost, "/api/v1/control/resume", ""},
+		{http.MethodPost, "/api/v1/control/orders", `{"market":"KRW-ETH","side":"BUY"}`},
+		{http.MethodPost, "/api/v1/control/positions/KRW-ETH/close", ""},
+	}
+
+	// 골든 크로스와 데드 크로스를 번갈아 만들어 사이클마다 주문이 생기게 합니다
+	cycles := [][]float64{{10, 10, 10, 10, 8, 20}, {20, 20, 20, 20, 22, 5}}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 20; i++ {
+			h.SetPrices("KRW-BTC", cycles[i%2]...)
+			h.SetPrices("KRW-ETH", cycles[(i+1)%2]...)
+			if _, err := h.RunCycle(); err != nil {
+				t.Errorf("cycle %d: %v", i, err)
+			}
+			h.Clock.Advance(4 * time.Hour)
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for _, r := range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				req := httptest.NewRequest(r.method, r.target, strings.NewReader(r.body))
+				req.Header.Set("Authorization", "Bearer "+testSecret)
+				req.Header.Set("Content-Type", "application/json")
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				if w.Code == http.StatusInternalServerError || w.Code == http.StatusUnauthorized {
+					t.Errorf("%s %s = %d: %s", r.method, r.target, w.Code, w.Body.String())
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			h.Bot.GetAllLatestSignals()
+			h.Bot.GetLatestSignal("KRW-BTC")
+			h.Bot.GetCycleStatus()
+			h.Bot.GetBotStatus()
+			h.Bot.GetValidateMarkets()
+			h.Bot.GetPositionSummaries()
+			h.Bot.GetOrders(model.OrderFilter{})
+			h.Bot.GetPnLSummary(model.OrderFilter{})
+			h.Bot.GetStrategyInfo()
+			h.Bot.Liveness()
+			h.Bot.Readiness(context.Background())
+		}
+	}()
+
+	<-done
+	wg.Wait()
+	if status := h.Bot.GetCycleStatus(); status.CycleCount != 20 {
+		t.Errorf("cycle count = %d, want 20", status.CycleCount)
+	}
+}
@@ -0,0 +1,304 @@
+// Package backtest는 저장해 둔 과거 캔들로 전략을 재생해 모의 주문과 자산 곡선을 만듭니다.
+// 주문 규칙은 모의 거래(OrderService, RiskManager)와 같습니다. BUY 신호에 order-amount만큼 진입하고 SELL 신호에 전량 청산하며,
+// max-positions, max-order-amount, 거래 시간대, 손절/익절을 적용합니다. 여기에 수수료와 보유 현금 한도를 더합니다
+package backtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-trading-bot/config"
+	"go-trading-bot/internal/analytics"
+	"go-trading-bot/internal/clock"
+	"go-trading-bot/internal/model"
+	"go-trading-bot/internal/scheduler"
+	"go-trading-bot/internal/strategy"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// DEFAULT_FEE_RATE는 업비트 원화 마켓 거래 수수료입니다
+const DEFAULT_FEE_RATE = 0.0005
+
+var (
+	ErrNoCandles   = errors.New("no candles for the configured markets")
+	ErrCandleOrder = errors.New("candles must be in ascending time order without duplicates")
+)
+
+// Options는 백테스트 설정입니다
+type Options struct {
+	Capital   float64   // 시작 현금. 0이면 order-amount × max(max-positions, 마켓 수)
+	FeeRate   float64   // 매수/매도 수수료율. 음수이면 수수료 없음, 0이면 DEFAULT_FEE_RATE
+	From      time.Time // 이 시각 전에 시작한 캔들은 분석에 필요한 과거 데이터로만 쓰고 주문과 자산 곡선에 넣지 않습니다
+	Liquidate bool      // 끝날 때 보유 포지션을 마지막 종가로 청산합니다
+}
+
+// EquityPoint는 캔들 마감 시점의 평가 자산입니다
+type EquityPoint = analytics.Point
+
+// Result는 백테스트 결과입니다
+type Result struct {
+	Strategy  string
+	Capital   float64
+	Orders    []model.Order   // 체결 순서
+	Equity    []EquityPoint   // 캔들 마감 시각 순서
+	Positions model.Positions // 끝날 때 보유 중인 포지션 (평가 자산에 포함)
+	Metrics   Metrics
+}
+
+// Run은 candles(마켓 코드별, 과거 → 최신)로 tc의 전략을 재생합니다. tc.Markets에 없는 마켓은 무시합니다.
+// 시각을 해석할 수 없거나 시각 순서가 아닌(중복 포함) 캔들이 있으면 오류를 반환합니다. LoadData로 읽은 캔들은 정렬되어 있습니다
+func Run(ctx context.Context, tc *config.TradingConfig, candles map[string][]model.Candle, opts Options) (*Result, error) {
+	fakeClock := clock.NewFake(time.Time{})
+	tradingStrategy := strategy.CreateStrategy(tc, fakeClock)
+	if tradingStrategy == nil {
+		return nil, fmt.Errorf("unknown strategy: %q", tc.Strategy)
+	}
+	tradingWindow, err := scheduler.NewTradingWindow(tc.TradingWindow)
+	if err != nil {
+		return nil, err
+	}
+
+	markets := marketsWithCandles(tc, candles)
+	if len(markets) == 0 {
+		return nil, ErrNoCandles
+	}
+	if err := checkCandleOrder(candles, markets); err != nil {
+		return nil, err
+	}
+
+	e := &engine{
+		tc:            tc,
+		feeRate:       opts.FeeRate,
+		tradingWindow: tradingWindow,
+		positions:     make(map[string]model.Position),
+		prices:        make(map[string]float64),
+	}
+	if e.feeRate == 0 {
+		e.feeRate = DEFAULT_FEE_RATE
+	}
+	e.feeRate = max(e.feeRate, 0)
+	e.cash = capital(tc, markets, opts)
+	result := &Result{Strategy: tradingStrategy.GetName(), Capital: e.cash}
+
+	required := tradingStrategy.GetRequiredCandleCount()
+	next := make(map[string]int, len(markets))
+	for _, start := range candleStarts(candles, markets) {
+		closeTime := candleClose(tc, start)
+		fakeClock.Set(closeTime)
+		history := start.Before(opts.From)
+
+		for _, market := range markets {
+			series := candles[market]
+			i := next[market]
+			if i >= len(series) || series[i].CandleDateTimeUTC != start.UTC().Format("2006-01-02T15:04:05") {
+				continue
+			}
+			next[market] = i + 1
+			e.prices[market] = series[i].TradePrice
+			if history || i+1 < required {
+				continue
+			}
+
+			window := make([]model.Candle, 0, required)
+			for j := i; j > i-required; j-- {
+				window = append(window, series[j])
+			}
+			signal := tradingStrategy.Analyze(ctx, market, window)
+			e.handleSignal(signal, closeTime)
+		}
+		if history {
+			continue
+		}
+		e.checkRiskExits(closeTime)
+		result.Equity = append(result.Equity, EquityPoint{Time: closeTime, Equity: e.equity()})
+	}
+
+	if opts.Liquidate && len(result.Equity) > 0 {
+		last := &result.Equity[len(result.Equity)-1]
+		for _, market := range markets {
+			if _, exists := e.positions[market]; exists {
+				e.sell(market, e.prices[market], last.Time)
+			}
+		}
+		last.Equity = e.equity()
+	}
+
+	result.Orders = e.orders
+	for _, market := range markets {
+		if position, exists := e.positions[market]; exists {
+			result.Positions = append(result.Positions, position)
+		}
+	}
+	result.Metrics = Evaluate(result)
+	return result, nil
+}
+
+// engine은 백테스트 중의 현금, 포지션, 주문 기록입니다
+type engine struct {
+	tc            *config.TradingConfig
+	feeRate       float64
+	tradingWindow *scheduler.TradingWindow
+	cash          float64
+	positions     map[string]model.Position
+	prices        map[string]float64 // 마켓별 마지막 종가
+	orders        []model.Order
+}
+
+func (e *engine) handleSignal(signal model.Signal, now time.Time) {
+	switch signal.Type {
+	case model.BUY:
+		e.buy(signal.Market, signal.CurrentPrice, signal.StageName(), now)
+	case model.SELL:
+		e.sell(signal.Market, signal.CurrentPrice, now)
+	}
+}
+
+// buy는 RiskManager.CheckEntry와 같은 규칙으로 진입하고, 현금이 부족하면 주문하지 않습니다
+func (e *engine) buy(market string, price float64, stage string, now time.Time) {
+	amount := e.tc.OrderAmount
+	risk := e.tc.Risk
+	if price <= 0 || amount <= 0 || !e.tradingWindow.Allows(now) {
+		return
+	}
+	if _, exists := e.positions[market]; exists {
+		return
+	}
+	if risk.MaxPositions > 0 && len(e.positions) >= risk.MaxPositions {
+		return
+	}
+	if risk.MaxOrderAmount > 0 && amount > risk.MaxOrderAmount {
+		return
+	}
+
+	// 모의 주문과 같이 수량은 소수점 넷째 자리에서 자릅니다
+	quantity := float64(int((amount/price)*10000)) / 10000
+	cost := price * quantity * (1 + e.feeRate)
+	if quantity <= 0 || cost > e.cash {
+		return
+	}
+	e.cash -= cost
+	e.positions[market] = model.Position{Market: market, Status: model.POSITION_BUY, Quantity: quantity, EntryPrice: price, Stage: stage}
+	e.recordOrder(market, model.BUY, price, quantity, 0, stage, now)
+}
+
+// sell은 포지션을 전량 청산합니다. 손익은 매수/매도 수수료를 뺀 금액입니다
+func (e *engine) sell(market string, price float64, now time.Time) {
+	position, exists := e.positions[market]
+	if !exists || price <= 0 {
+		return
+	}
+	proceeds := price * position.Quantity * (1 - e.feeRate)
+	e.cash += proceeds
+	profit := proceeds - position.EntryPrice*position.Quantity*(1+e.feeRate)
+	delete(e.positions, market)
+	e.recordOrder(market, model.SELL, price, position.Quantity, profit, position.Stage, now)
+}
+
+// checkRiskExits는 RiskManager.CheckExit와 같은 손절/익절 조건으로 마지막 종가에 청산합니다
+func (e *engine) checkRiskExits(now time.Time) {
+	risk := e.tc.Risk
+	markets := make([]string, 0, len(e.positions))
+	for market := range e.positions {
+		markets = append(markets, market)
+	}
+	sort.Strings(markets)
+
+	for _, market := range markets {
+		position, price := e.positions[market], e.prices[market]
+		if position.EntryPrice <= 0 || price <= 0 {
+			continue
+		}
+		rate := (price - position.EntryPrice) / position.EntryPrice * 100
+		if (risk.StopLossPercent > 0 && rate <= -risk.StopLossPercent) || (risk.TakeProfitPercent > 0 && rate >= risk.TakeProfitPercent) {
+			e.sell(market, price, now)
+		}
+	}
+}
+
+func (e *engine) equity() float64 {
+	equity := e.cash
+	for market, position := range e.positions {
+		equity += position.Quantity * e.prices[market]
+	}
+	return equity
+}
+
+func (e *engine) recordOrder(market string, signalType model.SignalType, price, quantity, profit float64, stage string, now time.Time) {
+	e.orders = append(e.orders, model.Order{
+		ID:        strconv.Itoa(len(e.orders) + 1),
+		Market:    market,
+		Side:      signalType.String(),
+		Price:     price,
+		Quantity:  quantity,
+		Amount:    price * quantity,
+		Profit:    profit,
+		Stage:     stage,
+		CreatedAt: now,
+	})
+}
+
+// marketsWithCandles는 tc.Markets 중 캔들이 있는 마켓 코드를 설정 순서대로 반환합니다
+func marketsWithCandles(tc *config.TradingConfig, candles map[string][]model.Candle) []string {
+	var markets []string
+	for _, market := range tc.Markets {
+		if len(candles["KRW-"+market]) > 0 {
+			markets = append(markets, "KRW-"+market)
+		}
+	}
+	return markets
+}
+
+// capital은 시작 현금입니다. opts.Capital이 없으면 order-amount × max(max-positions, 마켓 수)입니다
+func capital(tc *config.TradingConfig, markets []string, opts Options) float64 {
+	if opts.Capital > 0 {
+		return opts.Capital
+	}
+	return tc.OrderAmount * float64(max(tc.Risk.MaxPositions, len(markets)))
+}
+
+// candleClose는 start에 시작한 캔들이 마감되는 시각입니다
+func candleClose(tc *config.TradingConfig, start time.Time) time.Time {
+	if end := scheduler.NextCandleStart(tc.Candle.Category, tc.Candle.Unit, start); !end.IsZero() {
+		return end
+	}
+	return start
+}
+
+// checkCandleOrder는 마켓마다 캔들 시각이 해석되고 과거 → 최신 순서로 중복 없이 정렬되어 있는지 확인합니다.
+// 그렇지 않으면 Run이 그 캔들에서 멈춰 이후 캔들을 모두 건너뜁니다
+func checkCandleOrder(candles map[string][]model.Candle, markets []string) error {
+	for _, market := range markets {
+		var previous time.Time
+		for i, candle := range candles[market] {
+			start, err := candle.StartTime()
+			if err != nil {
+				return fmt.Errorf("%s: candle %d: %w", market, i, err)
+			}
+			if i > 0 && !start.After(previous) {
+				return fmt.Errorf("%s: candle %d (%s): %w", market, i, candle.CandleDateTimeUTC, ErrCandleOrder)
+			}
+			previous = start
+		}
+	}
+	return nil
+}
+
+// candleStarts는 마켓들의 캔들 시작 시각을 중복 없이 과거 → 최신 순서로 반환합니다
+func candleStarts(candles map[string][]model.Candle, markets []string) []time.Time {
+	seen := make(map[time.Time]bool)
+	var starts []time.Time
+	for _, market := range markets {
+		for _, candle := range candles[market] {
+			start, err := candle.StartTime()
+			if err != nil || seen[start] {
+				continue
+			}
+			seen[start] = true
+			starts = append(starts, start)
+		}
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+	return starts
+}
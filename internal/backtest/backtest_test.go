@@ -0,0 +1,241 @@
+package backtest
+
+import (
+	"context"
+	"errors"
+	"go-trading-bot/config"
+	"go-trading-bot/internal/model"
+	"math"
+	"testing"
+	"time"
+)
+
+// seriesStart는 합성 캔들의 첫 시작 시각입니다 (KST 09:00, 240분 캔들 경계)
+var seriesStart = time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+
+// crossPrices는 MA2/MA4 기준 5번째 캔들(20)에서 골든 크로스, 11번째 캔들(5)에서 데드 크로스가 나는 종가입니다
+var crossPrices = []float64{10, 10, 10, 10, 8, 20, 20, 20, 20, 22, 5}
+
+// series는 종가 목록(과거 → 최신)으로 seriesStart부터 4시간 간격의 캔들을 만듭니다
+func series(market string, prices ...float64) []model.Candle {
+	candles := make([]model.Candle, len(prices))
+	for i, price := range prices {
+		candles[i] = model.Candle{
+			Market:            market,
+			CandleDateTimeUTC: candleTime(i).Format("2006-01-02T15:04:05"),
+			OpeningPrice:      price,
+			HighPrice:         price,
+			LowPrice:          price,
+			TradePrice:        price,
+		}
+	}
+	return candles
+}
+
+// candleTime은 i번째 캔들의 시작 시각입니다. 마감 시각은 candleTime(i + 1)입니다
+func candleTime(i int) time.Time {
+	return seriesStart.Add(time.Duration(i) * 4 * time.Hour)
+}
+
+func crossConfig() *config.TradingConfig {
+	return &config.TradingConfig{
+		Markets:            []string{"BTC"},
+		Strategy:           "moving-average-cross",
+		Candle:             config.Candle{Category: "minutes", Unit: 240},
+		AnalysisInterval:   240,
+		MovingAverageCross: config.MovingAverageCross{ShortPeriod: 2, LongPeriod: 4},
+		OrderAmount:        1000000,
+	}
+}
+
+func run(t *testing.T, tc *config.TradingConfig, opts Options, prices ...float64) *Result {
+	t.Helper()
+	result, err := Run(context.Background(), tc, map[string][]model.Candle{"KRW-BTC": series("KRW-BTC", prices...)}, opts)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	return result
+}
+
+func near(got, want float64) bool {
+	return math.Abs(got-want) < 1e-6
+}
+
+func TestRunAppliesFees(t *testing.T) {
+	result := run(t, crossConfig(), Options{Capital: 2000000, FeeRate: 0.001}, crossPrices...)
+
+	if len(result.Orders) != 2 {
+		t.Fatalf("orders = %+v, want a BUY and a SELL", result.Orders)
+	}
+	buy, sell := result.Orders[0], result.Orders[1]
+	if buy.Side != "BUY" || buy.Price != 20 || buy.Quantity != 50000 || !buy.CreatedAt.Equal(candleTime(6)) {
+		t.Errorf("buy = %+v, want 50000 @20 at the close of candle 5", buy)
+	}
+	// 매도 금액 250,000 × 0.999 - 매수 비용 1,000,000 × 1.001
+	if sell.Side != "SELL" || sell.Price != 5 || !near(sell.Profit, 249750-1001000) {
+		t.Errorf("sell = %+v, want @5 with profit -751250", sell)
+	}
+
+	if len(result.Equity) != len(crossPrices) {
+		t.Fatalf("equity has %d points, want one per candle", len(result.Equity))
+	}
+	// 매수 직후: 현금 2,000,000 - 1,001,000 + 평가 50,000 × 20
+	if got := result.Equity[5].Equity; !near(got, 1999000) {
+		t.Errorf("equity after buy = %v, want 1999000", got)
+	}
+	if got := result.Metrics.FinalEquity; !near(got, 1248750) {
+		t.Errorf("final equity = %v, want 1248750", got)
+	}
+}
+
+func TestRunCashLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		ordered bool
+	}{
+		// 기본 현금은 order-amount × 마켓 수 = 1,000,000으로 수수료까지는 낼 수 없습니다
+		{"default capital with fees", Options{}, false},
+		{"default capital without fees", Options{FeeRate: -1}, true},
+		{"short of order amount", Options{Capital: 999999, FeeRate: -1}, false},
+	}
+	for _, tt := range tests {
+		result := run(t, crossConfig(), tt.opts, crossPrices...)
+		if got := len(result.Orders) > 0; got != tt.ordered {
+			t.Errorf("%s: orders = %+v, want ordered %v", tt.name, result.Orders, tt.ordered)
+		}
+	}
+}
+
+func TestRunRiskExits(t *testing.T) {
+	tests := []struct {
+		name   string
+		risk   config.Risk
+		prices []float64
+		price  float64
+		profit float64
+	}{
+		// 20에 매수 후 17(-15%)에 손절
+		{"stop loss", config.Risk{StopLossPercent: 10}, []float64{10, 10, 10, 10, 8, 20, 17}, 17, -150000},
+		// 20에 매수 후 22(+10%)에 익절
+		{"take profit", config.Risk{TakeProfitPercent: 10}, []float64{10, 10, 10, 10, 8, 20, 22}, 22, 100000},
+	}
+	for _, tt := range tests {
+		tc := crossConfig()
+		tc.Risk = tt.risk
+		result := run(t, tc, Options{FeeRate: -1}, tt.prices...)
+		if len(result.Orders) != 2 {
+			t.Errorf("%s: orders = %+v, want a BUY and a risk exit", tt.name, result.Orders)
+			continue
+		}
+		exit := result.Orders[1]
+		if exit.Side != "SELL" || exit.Price != tt.price || exit.Profit != tt.profit || !exit.CreatedAt.Equal(candleTime(7)) {
+			t.Errorf("%s: exit = %+v, want SELL @%v with profit %v at the close of candle 6", tt.name, exit, tt.price, tt.profit)
+		}
+		if len(result.Positions) != 0 {
+			t.Errorf("%s: positions = %+v, want none", tt.name, result.Positions)
+		}
+	}
+}
+
+func TestRunFromUsesHistoryForWarmUp(t *testing.T) {
+	tests := []struct {
+		name   string
+		from   int // 이 캔들부터 주문과 자산 곡선에 넣습니다
+		orders int
+	}{
+		// 골든 크로스가 난 캔들부터 시작하면 앞의 캔들로 이동평균을 계산해 매수합니다
+		{"from the cross", 5, 2},
+		// 크로스가 지난 뒤에 시작하면 그 매수 신호는 과거 데이터입니다
+		{"after the cross", 6, 0},
+	}
+	for _, tt := range tests {
+		result := run(t, crossConfig(), Options{FeeRate: -1, From: candleTime(tt.from)}, crossPrices...)
+		if len(result.Orders) != tt.orders {
+			t.Errorf("%s: orders = %+v, want %d", tt.name, result.Orders, tt.orders)
+		}
+		if len(result.Equity) != len(crossPrices)-tt.from || !result.Equity[0].Time.Equal(candleTime(tt.from+1)) {
+			t.Errorf("%s: equity starts at %v with %d points, want %v with %d", tt.name,
+				result.Equity[0].Time, len(result.Equity), candleTime(tt.from+1), len(crossPrices)-tt.from)
+		}
+		if result.Equity[0].Equity != result.Capital {
+			t.Errorf("%s: first equity = %v, want the starting capital", tt.name, result.Equity[0].Equity)
+		}
+	}
+}
+
+func TestRunLiquidate(t *testing.T) {
+	// 20에 매수한 뒤 매도 신호 없이 24로 끝납니다
+	prices := []float64{10, 10, 10, 10, 8, 20, 20, 20, 24}
+
+	held := run(t, crossConfig(), Options{FeeRate: -1}, prices...)
+	if len(held.Orders) != 1 || len(held.Positions) != 1 || held.Positions[0].Quantity != 50000 {
+		t.Errorf("without liquidate: orders %+v, positions %+v, want the position held", held.Orders, held.Positions)
+	}
+	if held.Metrics.Trades != 0 || held.Metrics.FinalEquity != 1200000 {
+		t.Errorf("without liquidate: %d trades, final equity %v, want 0 and 1200000 marked to market", held.Metrics.Trades, held.Metrics.FinalEquity)
+	}
+
+	liquidated := run(t, crossConfig(), Options{FeeRate: -1, Liquidate: true}, prices...)
+	if len(liquidated.Orders) != 2 || len(liquidated.Positions) != 0 {
+		t.Fatalf("with liquidate: orders %+v, positions %+v, want the position sold", liquidated.Orders, liquidated.Positions)
+	}
+	if sell := liquidated.Orders[1]; sell.Price != 24 || sell.Profit != 200000 || !sell.CreatedAt.Equal(candleTime(len(prices))) {
+		t.Errorf("liquidation = %+v, want SELL @24 with profit 200000 at the last close", sell)
+	}
+	if liquidated.Metrics.Trades != 1 || liquidated.Metrics.FinalEquity != 1200000 {
+		t.Errorf("with liquidate: %d trades, final equity %v, want 1 and 1200000", liquidated.Metrics.Trades, liquidated.Metrics.FinalEquity)
+	}
+}
+
+func TestRunRejectsUnorderedCandles(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(candles []model.Candle)
+		order  bool // ErrCandleOrder인지, 아니면 시각 해석 오류인지
+	}{
+		{"duplicate", func(c []model.Candle) { c[3].CandleDateTimeUTC = c[2].CandleDateTimeUTC }, true},
+		{"out of order", func(c []model.Candle) { c[2], c[3] = c[3], c[2] }, true},
+		{"bad time", func(c []model.Candle) { c[3].CandleDateTimeUTC = "2025-01-06 12:00" }, false},
+	}
+	for _, tt := range tests {
+		candles := series("KRW-BTC", crossPrices...)
+		tt.modify(candles)
+		_, err := Run(context.Background(), crossConfig(), map[string][]model.Candle{"KRW-BTC": candles}, Options{})
+		if err == nil {
+			t.Errorf("%s: Run succeeded, want an error instead of skipping the remaining candles", tt.name)
+			continue
+		}
+		if errors.Is(err, ErrCandleOrder) != tt.order {
+			t.Errorf("%s: err = %v, want ErrCandleOrder %v", tt.name, err, tt.order)
+		}
+	}
+}
+
+func TestRunMultipleMarkets(t *testing.T) {
+	tc := crossConfig()
+	tc.Markets = []string{"BTC", "ETH", "XRP"}
+	candles := map[string][]model.Candle{
+		"KRW-BTC": series("KRW-BTC", crossPrices...),
+		// ETH는 앞에 캔들이 하나 더 있어 한 캔들 늦게 크로스가 납니다
+		"KRW-ETH": series("KRW-ETH", append([]float64{10}, crossPrices...)...),
+	}
+
+	result, err := Run(context.Background(), tc, candles, Options{FeeRate: -1})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	// 캔들이 없는 XRP는 제외하므로 기본 현금은 2,000,000입니다
+	if result.Capital != 2000000 || len(result.Equity) != len(crossPrices)+1 {
+		t.Errorf("capital %v with %d equity points, want 2000000 with %d", result.Capital, len(result.Equity), len(crossPrices)+1)
+	}
+	var buys []string
+	for _, order := range result.Orders {
+		if order.Side == "BUY" {
+			buys = append(buys, order.Market+"@"+order.CreatedAt.UTC().Format("15:04"))
+		}
+	}
+	if len(buys) != 2 || buys[0] != "KRW-BTC@00:00" || buys[1] != "KRW-ETH@04:00" {
+		t.Errorf("buys = %v, want BTC then ETH one candle later", buys)
+	}
+}
@@ -0,0 +1,85 @@
+package backtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-trading-bot/internal/model"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// LoadCandles는 업비트 캔들 API 응답 형식(JSON 배열)의 파일을 읽어 과거 → 최신 순서로 정렬합니다.
+// 같은 시각의 캔들은 하나만 남기고, 시각을 해석할 수 없는 캔들은 오류로 처리합니다
+func LoadCandles(path string) ([]model.Candle, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var candles []model.Candle
+	if err := json.Unmarshal(data, &candles); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for i, candle := range candles {
+		if _, err := candle.StartTime(); err != nil {
+			return nil, fmt.Errorf("%s: candle %d: %w", path, i, err)
+		}
+	}
+	return sortCandles(candles), nil
+}
+
+// LoadData는 paths(파일 또는 디렉터리)의 캔들 파일을 모두 읽어 마켓 코드별로 묶습니다.
+// 마켓은 캔들의 market 값으로 정하며, 같은 마켓의 파일이 여러 개이면 합칩니다
+func LoadData(paths ...string) (map[string][]model.Candle, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(path, "*.json"))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+
+	merged := make(map[string][]model.Candle)
+	for _, file := range files {
+		candles, err := LoadCandles(file)
+		if err != nil {
+			return nil, err
+		}
+		if len(candles) == 0 {
+			continue
+		}
+		market := candles[0].Market
+		if market == "" {
+			market = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		}
+		merged[market] = append(merged[market], candles...)
+	}
+
+	for market, candles := range merged {
+		merged[market] = sortCandles(candles)
+	}
+	return merged, nil
+}
+
+// sortCandles는 캔들을 과거 → 최신 순서로 정렬하고 같은 시각의 캔들은 처음 것만 남깁니다
+func sortCandles(candles []model.Candle) []model.Candle {
+	sort.SliceStable(candles, func(i, j int) bool { return candles[i].CandleDateTimeUTC < candles[j].CandleDateTimeUTC })
+	unique := candles[:0]
+	for _, candle := range candles {
+		if len(unique) > 0 && unique[len(unique)-1].CandleDateTimeUTC == candle.CandleDateTimeUTC {
+			continue
+		}
+		unique = append(unique, candle)
+	}
+	return unique
+}
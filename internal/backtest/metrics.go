@@ -0,0 +1,43 @@
+package backtest
+
+import "go-trading-bot/internal/analytics"
+
+// Metrics는 최적화에서 비교하는 백테스트 성과 지표입니다. 비율은 모두 소수(0.1 = 10%)이며 계산은 analytics와 같습니다
+type Metrics struct {
+	FinalEquity    float64
+	TotalReturn    float64 // 시작 현금 대비 수익률
+	Sharpe         float64 // 캔들 단위 수익률로 계산해 연율화한 샤프 지수 (무위험 수익률 0)
+	MaxDrawdown    float64 // 최대 낙폭 (양수)
+	ReturnDrawdown float64 // 수익률 / 최대 낙폭. 낙폭이 없으면 수익률
+	ProfitFactor   float64 // 총이익 / 총손실. 손실 거래가 없고 이익이 있으면 +Inf
+	Trades         int     // 청산된 거래 수
+	WinRate        float64
+}
+
+// Evaluate는 결과의 주문과 자산 곡선으로 지표를 계산합니다
+func Evaluate(result *Result) Metrics {
+	return metricsOf(result.Report())
+}
+
+// Report는 결과의 전체 성과 보고서입니다
+func (r *Result) Report() *analytics.Report {
+	return analytics.Analyze(r.Strategy+" 백테스트", r.Capital, r.Orders, r.Equity)
+}
+
+func metricsOf(report *analytics.Report) Metrics {
+	m := report.Metrics
+	metrics := Metrics{
+		FinalEquity:    m.FinalEquity,
+		TotalReturn:    m.TotalReturn,
+		Sharpe:         float64(m.Sharpe),
+		MaxDrawdown:    m.MaxDrawdown,
+		ReturnDrawdown: m.TotalReturn,
+		ProfitFactor:   float64(m.ProfitFactor),
+		Trades:         m.Trades,
+		WinRate:        m.WinRate,
+	}
+	if m.MaxDrawdown > 0 {
+		metrics.ReturnDrawdown = m.TotalReturn / m.MaxDrawdown
+	}
+	return metrics
+}
@@ -0,0 +1,319 @@
+package backtest
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"go-trading-bot/config"
+	"go-trading-bot/internal/model"
+	"go-trading-bot/internal/validator"
+	"io"
+	"math"
+	"math/rand/v2"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// 최적화 목표
+const (
+	OBJECTIVE_SHARPE          = "sharpe"
+	OBJECTIVE_RETURN          = "return"
+	OBJECTIVE_RETURN_DRAWDOWN = "return-drawdown"
+	OBJECTIVE_PROFIT_FACTOR   = "profit-factor"
+)
+
+// Objectives는 지원하는 최적화 목표 목록입니다
+func Objectives() []string {
+	return []string{OBJECTIVE_SHARPE, OBJECTIVE_RETURN, OBJECTIVE_RETURN_DRAWDOWN, OBJECTIVE_PROFIT_FACTOR}
+}
+
+// Score는 objective 기준 점수입니다. 높을수록 좋습니다
+func Score(objective string, m Metrics) (float64, error) {
+	switch objective {
+	case OBJECTIVE_SHARPE:
+		return m.Sharpe, nil
+	case OBJECTIVE_RETURN:
+		return m.TotalReturn, nil
+	case OBJECTIVE_RETURN_DRAWDOWN:
+		return m.ReturnDrawdown, nil
+	case OBJECTIVE_PROFIT_FACTOR:
+		return m.ProfitFactor, nil
+	default:
+		return 0, fmt.Errorf("unknown objective %q (supported: %s)", objective, strings.Join(Objectives(), ", "))
+	}
+}
+
+// Param은 탐색할 설정 값입니다. Path는 application.json의 키를 점으로 이은 경로입니다 (예: moving-average-cycle.short-period)
+type Param struct {
+	Path   string
+	Values []float64
+}
+
+// ParseParam은 "경로=값,값,..." 또는 "경로=시작:끝:간격" 형식을 해석합니다. 간격을 생략하면 1입니다
+func ParseParam(s string) (Param, error) {
+	path, spec, found := strings.Cut(s, "=")
+	if !found || path == "" || spec == "" {
+		return Param{}, fmt.Errorf("invalid parameter %q: expected path=v1,v2 or path=start:end[:step]", s)
+	}
+	param := Param{Path: strings.TrimSpace(path)}
+
+	if parts := strings.Split(spec, ":"); len(parts) > 1 {
+		if len(parts) > 3 {
+			return Param{}, fmt.Errorf("invalid range %q", spec)
+		}
+		numbers := []float64{0, 0, 1}
+		for i, part := range parts {
+			number, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+			if err != nil {
+				return Param{}, fmt.Errorf("invalid range %q: %w", spec, err)
+			}
+			numbers[i] = number
+		}
+		start, end, step := numbers[0], numbers[1], numbers[2]
+		if step <= 0 || end < start {
+			return Param{}, fmt.Errorf("invalid range %q: need start <= end and step > 0", spec)
+		}
+		for i := 0; start+float64(i)*step <= end+step*1e-9; i++ {
+			// 0.1 같은 간격의 부동소수점 오차를 없앱니다
+			value, _ := strconv.ParseFloat(strconv.FormatFloat(start+float64(i)*step, 'f', 10, 64), 64)
+			param.Values = append(param.Values, value)
+		}
+		return param, nil
+	}
+
+	for _, part := range strings.Split(spec, ",") {
+		number, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return Param{}, fmt.Errorf("invalid value %q for %s: %w", part, param.Path, err)
+		}
+		param.Values = append(param.Values, number)
+	}
+	return param, nil
+}
+
+// Grid는 모든 값의 조합을 반환합니다
+func Grid(params []Param) [][]float64 {
+	combinations := [][]float64{{}}
+	for _, param := range params {
+		var next [][]float64
+		for _, combination := range combinations {
+			for _, value := range param.Values {
+				next = append(next, append(append([]float64(nil), combination...), value))
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
+
+// Random은 각 값을 무작위로 골라 서로 다른 조합을 최대 samples개 만듭니다. 전체 조합 수보다 많이 요청하면 Grid와 같습니다
+func Random(params []Param, samples int, seed uint64) [][]float64 {
+	total := 1
+	for _, param := range params {
+		total *= len(param.Values)
+	}
+	if samples >= total {
+		return Grid(params)
+	}
+
+	rng := rand.New(rand.NewPCG(seed, seed))
+	seen := make(map[string]bool, samples)
+	var combinations [][]float64
+	for len(combinations) < samples {
+		combination := make([]float64, len(params))
+		for i, param := range params {
+			combination[i] = param.Values[rng.IntN(len(param.Values))]
+		}
+		key := fmt.Sprint(combination)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		combinations = append(combinations, combination)
+	}
+	return combinations
+}
+
+// Apply는 base를 복사해 params 경로에 values를 넣은 설정을 반환합니다. 없는 경로나 타입이 맞지 않는 값은 오류입니다
+func Apply(base *config.TradingConfig, params []Param, values []float64) (*config.TradingConfig, error) {
+	data, err := json.Marshal(base)
+	if err != nil {
+		return nil, err
+	}
+	var tree map[string]any
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return nil, err
+	}
+
+	for i, param := range params {
+		keys := strings.Split(param.Path, ".")
+		node := tree
+		for _, key := range keys[:len(keys)-1] {
+			child, ok := node[key].(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("unknown parameter %q", param.Path)
+			}
+			node = child
+		}
+		last := keys[len(keys)-1]
+		if _, ok := node[last]; !ok {
+			return nil, fmt.Errorf("unknown parameter %q", param.Path)
+		}
+		node[last] = values[i]
+	}
+
+	if data, err = json.Marshal(tree); err != nil {
+		return nil, err
+	}
+	var tc config.TradingConfig
+	if err := json.Unmarshal(data, &tc); err != nil {
+		return nil, fmt.Errorf("invalid parameter value: %w", err)
+	}
+	return &tc, nil
+}
+
+// OptimizeOptions는 최적화 설정입니다
+type OptimizeOptions struct {
+	Objective string
+	Workers   int // 0이면 CPU 수
+	MinTrades int // 거래 수가 이보다 적은 조합은 순위에서 뒤로 보냅니다
+	Backtest  Options
+}
+
+// Trial은 한 조합의 결과입니다. Err가 있으면 설정 검증이나 백테스트에 실패한 조합입니다
+type Trial struct {
+	Values  []float64
+	Score   float64
+	Metrics Metrics
+	Err     error
+}
+
+// Optimize는 combinations의 각 조합으로 base 설정을 바꿔 병렬로 백테스트하고 점수 순으로 정렬해 반환합니다.
+// 검증에 실패한 조합(예: short-period >= long-period)은 실행하지 않고 Err를 채워 맨 뒤에 둡니다
+func Optimize(ctx context.Context, base *config.TradingConfig, candles map[string][]model.Candle, params []Param, combinations [][]float64, opts OptimizeOptions) ([]Trial, error) {
+	if _, err := Score(opts.Objective, Metrics{}); err != nil {
+		return nil, err
+	}
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	trials := make([]Trial, len(combinations))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(workers, max(len(combinations), 1)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				trials[i] = runTrial(ctx, base, candles, params, combinations[i], opts)
+			}
+		}()
+	}
+	for i := range combinations {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	Rank(trials, opts.MinTrades)
+	return trials, nil
+}
+
+func runTrial(ctx context.Context, base *config.TradingConfig, candles map[string][]model.Candle, params []Param, values []float64, opts OptimizeOptions) Trial {
+	trial := Trial{Values: values}
+	tc, err := Apply(base, params, values)
+	if err != nil {
+		trial.Err = err
+		return trial
+	}
+	if issues := validator.ValidateTradingConfig(tc, nil); issues.HasFatal() {
+		for _, issue := range issues {
+			if issue.Fatal {
+				trial.Err = fmt.Errorf("%s", issue.String())
+				return trial
+			}
+		}
+	}
+
+	result, err := Run(ctx, tc, candles, opts.Backtest)
+	if err != nil {
+		trial.Err = err
+		return trial
+	}
+	trial.Metrics = result.Metrics
+	trial.Score, _ = Score(opts.Objective, result.Metrics)
+	return trial
+}
+
+// Rank는 실패한 조합, 거래 수가 minTrades보다 적은 조합을 뒤로 보내고 점수가 높은 순으로 정렬합니다
+func Rank(trials []Trial, minTrades int) {
+	group := func(t Trial) int {
+		switch {
+		case t.Err != nil:
+			return 2
+		case t.Metrics.Trades < minTrades:
+			return 1
+		default:
+			return 0
+		}
+	}
+	sort.SliceStable(trials, func(i, j int) bool {
+		gi, gj := group(trials[i]), group(trials[j])
+		if gi != gj {
+			return gi < gj
+		}
+		si, sj := trials[i].Score, trials[j].Score
+		if math.IsNaN(sj) {
+			return !math.IsNaN(si)
+		}
+		return si > sj
+	})
+}
+
+// WriteCSV는 전체 결과표를 순위 순서로 CSV로 씁니다
+func WriteCSV(w io.Writer, params []Param, trials []Trial) error {
+	writer := csv.NewWriter(w)
+	header := []string{"rank"}
+	for _, param := range params {
+		header = append(header, param.Path)
+	}
+	header = append(header, "score", "total_return", "sharpe", "max_drawdown", "return_drawdown", "profit_factor", "trades", "win_rate", "final_equity", "error")
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for i, trial := range trials {
+		record := []string{strconv.Itoa(i + 1)}
+		for _, value := range trial.Values {
+			record = append(record, formatFloat(value))
+		}
+		if trial.Err != nil {
+			record = append(record, "", "", "", "", "", "", "", "", "", trial.Err.Error())
+		} else {
+			m := trial.Metrics
+			record = append(record, formatFloat(trial.Score), formatFloat(m.TotalReturn), formatFloat(m.Sharpe), formatFloat(m.MaxDrawdown),
+				formatFloat(m.ReturnDrawdown), formatFloat(m.ProfitFactor), strconv.Itoa(m.Trades), formatFloat(m.WinRate), formatFloat(m.FinalEquity), "")
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
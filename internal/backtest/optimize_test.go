@@ -0,0 +1,112 @@
+package backtest
+
+import (
+	"context"
+	"errors"
+	"go-trading-bot/internal/model"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestScoreObjectives(t *testing.T) {
+	m := Metrics{Sharpe: 1.5, TotalReturn: 0.2, ReturnDrawdown: 2, ProfitFactor: 3}
+	for objective, want := range map[string]float64{
+		OBJECTIVE_SHARPE:          1.5,
+		OBJECTIVE_RETURN:          0.2,
+		OBJECTIVE_RETURN_DRAWDOWN: 2,
+		OBJECTIVE_PROFIT_FACTOR:   3,
+	} {
+		if got, err := Score(objective, m); err != nil || got != want {
+			t.Errorf("Score(%s) = %v, %v, want %v", objective, got, err, want)
+		}
+	}
+	if _, err := Score("calmar", m); err == nil {
+		t.Error("Score accepted an unknown objective")
+	}
+}
+
+func TestRankOrdersTrials(t *testing.T) {
+	trial := func(name float64, score float64, trades int, err error) Trial {
+		return Trial{Values: []float64{name}, Score: score, Metrics: Metrics{Trades: trades}, Err: err}
+	}
+	trials := []Trial{
+		trial(1, 0.5, 3, nil),
+		trial(2, 9, 0, errors.New("invalid")), // 실패한 조합은 맨 뒤
+		trial(3, 5, 1, nil),                   // 거래 수 부족은 성공한 조합 뒤
+		trial(4, math.NaN(), 3, nil),          // NaN은 같은 그룹의 맨 뒤
+		trial(5, 2, 3, nil),
+		trial(6, math.Inf(1), 2, nil), // 손실 없는 손익비
+	}
+	Rank(trials, 2)
+
+	var order []float64
+	for _, trial := range trials {
+		order = append(order, trial.Values[0])
+	}
+	if want := []float64{6, 5, 1, 4, 3, 2}; !reflect.DeepEqual(order, want) {
+		t.Errorf("ranked = %v, want %v", order, want)
+	}
+}
+
+func TestOptimizeRanksByObjective(t *testing.T) {
+	params := []Param{{Path: "moving-average-cross.short-period", Values: []float64{1, 2, 4}}}
+	candles := map[string][]model.Candle{"KRW-BTC": series("KRW-BTC", crossPrices...)}
+	trials, err := Optimize(context.Background(), crossConfig(), candles, params, Grid(params), OptimizeOptions{
+		Objective: OBJECTIVE_RETURN,
+		Workers:   2,
+		Backtest:  Options{Capital: 2000000, FeeRate: -1},
+	})
+	if err != nil {
+		t.Fatalf("Optimize: %v", err)
+	}
+
+	// 점수는 목표 지표(총 수익률)이고, 검증에 실패한 short-period 4는 맨 뒤입니다
+	var got []string
+	for _, trial := range trials {
+		got = append(got, formatFloat(trial.Values[0]))
+		if trial.Values[0] == 4 {
+			continue
+		}
+		if trial.Err != nil || trial.Metrics.Trades != 1 {
+			t.Errorf("short-period %v: %d trades, err %v, want one trade", trial.Values[0], trial.Metrics.Trades, trial.Err)
+		}
+		if want, _ := Score(OBJECTIVE_RETURN, trial.Metrics); trial.Score != want {
+			t.Errorf("short-period %v: score %v, want total return %v", trial.Values[0], trial.Score, want)
+		}
+	}
+	if last := trials[len(trials)-1]; last.Values[0] != 4 || last.Err == nil {
+		t.Errorf("last trial = %+v, want short-period 4 rejected by the validator", last)
+	}
+	for i := 1; i < len(trials)-1; i++ {
+		if trials[i-1].Score < trials[i].Score {
+			t.Errorf("trials %v are not sorted by score", got)
+		}
+	}
+
+	if _, err := Optimize(context.Background(), crossConfig(), candles, params, Grid(params), OptimizeOptions{Objective: "calmar"}); err == nil {
+		t.Error("Optimize accepted an unknown objective")
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	params := []Param{{Path: "moving-average-cross.short-period"}, {Path: "risk.stop-loss-percent"}}
+	trials := []Trial{
+		{Values: []float64{2, 5}, Score: 0.25, Metrics: Metrics{
+			FinalEquity: 1250000, TotalReturn: 0.25, Sharpe: 1.5, MaxDrawdown: 0.1, ReturnDrawdown: 2.5, ProfitFactor: math.Inf(1), Trades: 2, WinRate: 1,
+		}},
+		{Values: []float64{4, 5}, Err: errors.New("moving-average-cross.short-period: must be less than long-period")},
+	}
+
+	var out strings.Builder
+	if err := WriteCSV(&out, params, trials); err != nil {
+		t.Fatalf("WriteCSV: %v", err)
+	}
+	want := "rank,moving-average-cross.short-period,risk.stop-loss-percent,score,total_return,sharpe,max_drawdown,return_drawdown,profit_factor,trades,win_rate,final_equity,error\n" +
+		"1,2,5,0.25,0.25,1.5,0.1,2.5,+Inf,2,1,1250000,\n" +
+		"2,4,5,,,,,,,,,,moving-average-cross.short-period: must be less than long-period\n"
+	if out.String() != want {
+		t.Errorf("CSV =\n%s\nwant\n%s", out.String(), want)
+	}
+}
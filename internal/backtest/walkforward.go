@@ -0,0 +1,289 @@
+package backtest
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"go-trading-bot/config"
+	"go-trading-bot/internal/analytics"
+	"go-trading-bot/internal/model"
+	"io"
+	"math"
+	"strconv"
+	"time"
+)
+
+// WalkForwardOptions는 워크포워드 분석 설정입니다. 구간 길이는 캔들 수입니다
+type WalkForwardOptions struct {
+	InSample    int  // 최적화에 쓰는 구간 길이
+	OutOfSample int  // 최적화한 설정을 검증하는 구간 길이. 다음 구간은 이만큼 밀려서 시작합니다
+	Anchored    bool // true이면 최적화 구간의 시작을 처음에 고정하고 끝만 늘립니다
+	Optimize    OptimizeOptions
+}
+
+// Window는 워크포워드 구간 하나의 결과입니다. 시각은 캔들 시작 기준이며 끝은 포함하지 않습니다
+type Window struct {
+	InSampleFrom    time.Time
+	InSampleTo      time.Time
+	OutOfSampleFrom time.Time
+	OutOfSampleTo   time.Time
+	Values          []float64 // 최적화 구간에서 고른 설정 값. Err가 있으면 nil
+	Score           float64   // 최적화 구간의 목표 점수
+	InSample        Metrics
+	OutOfSample     Metrics
+	Err             error // 고를 수 있는 조합이 없으면 검증 구간 동안 현금으로 보유합니다
+}
+
+// Stability는 구간마다 고른 설정 값이 얼마나 흔들리는지 보여줍니다
+type Stability struct {
+	Path    string
+	Values  []float64 // 구간 순서. 조합을 고르지 못한 구간은 빠집니다
+	Mean    float64
+	StdDev  float64
+	Min     float64
+	Max     float64
+	Changes int // 바로 앞 구간과 값이 달라진 횟수
+}
+
+// WalkForwardResult는 검증 구간만 이어 붙인 성과와 같은 기간의 단순 보유 성과입니다
+type WalkForwardResult struct {
+	Strategy          string
+	Capital           float64
+	Windows           []Window
+	Orders            []model.Order
+	Equity            []EquityPoint
+	Metrics           Metrics
+	BuyAndHold        []EquityPoint // 첫 검증 구간 시작에 시작 현금을 마켓마다 똑같이 나눠 사서 끝까지 보유
+	BuyAndHoldMetrics Metrics
+	Stability         []Stability
+}
+
+// WalkForward는 과거 데이터를 최적화 구간과 검증 구간으로 나눠 굴리며, 최적화 구간에서 Optimize로 고른 설정을 바로 다음 검증 구간에서 평가합니다.
+// 검증 구간은 앞 구간이 끝난 자산으로 시작하고, 끝날 때 보유 포지션은 청산합니다. 분석에 필요한 이전 캔들은 검증 구간 앞의 데이터를 씁니다
+func WalkForward(ctx context.Context, base *config.TradingConfig, candles map[string][]model.Candle, params []Param, combinations [][]float64, opts WalkForwardOptions) (*WalkForwardResult, error) {
+	if opts.InSample <= 0 || opts.OutOfSample <= 0 {
+		return nil, errors.New("in-sample and out-of-sample lengths must be positive")
+	}
+	markets := marketsWithCandles(base, candles)
+	if len(markets) == 0 {
+		return nil, ErrNoCandles
+	}
+	starts := candleStarts(candles, markets)
+	if len(starts) <= opts.InSample {
+		return nil, fmt.Errorf("need more than %d candles for one walk-forward window, have %d", opts.InSample, len(starts))
+	}
+
+	feeRate := opts.Optimize.Backtest.FeeRate
+	if feeRate == 0 {
+		feeRate = DEFAULT_FEE_RATE
+	}
+	result := &WalkForwardResult{Capital: capital(base, markets, opts.Optimize.Backtest)}
+	equity := result.Capital
+
+	for from := opts.InSample; from < len(starts); from += opts.OutOfSample {
+		to := min(from+opts.OutOfSample, len(starts))
+		first := from - opts.InSample
+		if opts.Anchored {
+			first = 0
+		}
+		window := Window{
+			InSampleFrom:    starts[first],
+			InSampleTo:      starts[from],
+			OutOfSampleFrom: starts[from],
+			OutOfSampleTo:   candleClose(base, starts[to-1]),
+		}
+		if to < len(starts) {
+			window.OutOfSampleTo = starts[to]
+		}
+
+		trials, err := Optimize(ctx, base, sliceCandles(candles, window.InSampleFrom, window.InSampleTo), params, combinations, opts.Optimize)
+		if err != nil {
+			return nil, err
+		}
+		best := trials[0]
+		if best.Err != nil {
+			window.Err = fmt.Errorf("no valid parameter combination: %w", best.Err)
+			for _, start := range starts[from:to] {
+				result.Equity = append(result.Equity, EquityPoint{Time: candleClose(base, start), Equity: equity})
+			}
+			result.Windows = append(result.Windows, window)
+			continue
+		}
+		window.Values, window.Score, window.InSample = best.Values, best.Score, best.Metrics
+
+		tc, err := Apply(base, params, best.Values)
+		if err != nil {
+			return nil, err
+		}
+		backtestOptions := opts.Optimize.Backtest
+		backtestOptions.Capital = equity
+		backtestOptions.From = window.OutOfSampleFrom
+		backtestOptions.Liquidate = true
+		outOfSample, err := Run(ctx, tc, sliceCandles(candles, time.Time{}, window.OutOfSampleTo), backtestOptions)
+		if err != nil {
+			return nil, err
+		}
+		result.Strategy = outOfSample.Strategy
+		window.OutOfSample = outOfSample.Metrics
+		equity = outOfSample.Metrics.FinalEquity
+		for _, order := range outOfSample.Orders {
+			order.ID = strconv.Itoa(len(result.Orders) + 1)
+			result.Orders = append(result.Orders, order)
+		}
+		result.Equity = append(result.Equity, outOfSample.Equity...)
+		result.Windows = append(result.Windows, window)
+	}
+
+	result.Metrics = Evaluate(&Result{Capital: result.Capital, Orders: result.Orders, Equity: result.Equity})
+	result.BuyAndHold = buyAndHold(base, candles, markets, result.Capital, max(feeRate, 0), result.Windows[0].OutOfSampleFrom, result.Equity)
+	result.BuyAndHoldMetrics = Evaluate(&Result{Capital: result.Capital, Equity: result.BuyAndHold})
+	result.Stability = stability(params, result.Windows)
+	return result, nil
+}
+
+// Report는 검증 구간을 이어 붙인 성과 보고서입니다
+func (r *WalkForwardResult) Report() *analytics.Report {
+	return analytics.Analyze(r.Strategy+" 워크포워드 (검증 구간)", r.Capital, r.Orders, r.Equity)
+}
+
+// sliceCandles는 마켓마다 from 이후 to 전에 시작한 캔들만 남깁니다. from이 비어 있으면 처음부터입니다
+func sliceCandles(candles map[string][]model.Candle, from, to time.Time) map[string][]model.Candle {
+	sliced := make(map[string][]model.Candle, len(candles))
+	for market, series := range candles {
+		var kept []model.Candle
+		for _, candle := range series {
+			start, err := candle.StartTime()
+			if err != nil || start.Before(from) || !start.Before(to) {
+				continue
+			}
+			kept = append(kept, candle)
+		}
+		sliced[market] = kept
+	}
+	return sliced
+}
+
+// buyAndHold는 from에 시작한 첫 캔들의 종가로 마켓마다 같은 금액을 사서 보유했을 때의 자산을 points 시각마다 계산합니다
+func buyAndHold(tc *config.TradingConfig, candles map[string][]model.Candle, markets []string, capital, feeRate float64, from time.Time, points []EquityPoint) []EquityPoint {
+	allocation := capital / float64(len(markets))
+	quantities := make([]float64, len(markets))
+	prices := make([]float64, len(markets))
+	next := make([]int, len(markets))
+
+	curve := make([]EquityPoint, 0, len(points))
+	for _, point := range points {
+		equity := 0.0
+		for m, market := range markets {
+			series := candles[market]
+			for ; next[m] < len(series); next[m]++ {
+				start, err := series[next[m]].StartTime()
+				if err != nil || start.Before(from) {
+					continue
+				}
+				if candleClose(tc, start).After(point.Time) {
+					break
+				}
+				prices[m] = series[next[m]].TradePrice
+			}
+			if quantities[m] == 0 && prices[m] > 0 {
+				quantities[m] = allocation * (1 - feeRate) / prices[m]
+			}
+			if quantities[m] == 0 {
+				equity += allocation
+				continue
+			}
+			equity += quantities[m] * prices[m]
+		}
+		curve = append(curve, EquityPoint{Time: point.Time, Equity: equity})
+	}
+	return curve
+}
+
+// stability는 설정 값마다 구간별로 고른 값의 평균, 표준편차, 범위, 변경 횟수를 계산합니다
+func stability(params []Param, windows []Window) []Stability {
+	report := make([]Stability, len(params))
+	for i, param := range params {
+		s := Stability{Path: param.Path, Min: math.Inf(1), Max: math.Inf(-1)}
+		for _, window := range windows {
+			if window.Values == nil {
+				continue
+			}
+			value := window.Values[i]
+			if len(s.Values) > 0 && s.Values[len(s.Values)-1] != value {
+				s.Changes++
+			}
+			s.Values = append(s.Values, value)
+			s.Mean += value
+			s.Min = min(s.Min, value)
+			s.Max = max(s.Max, value)
+		}
+		if len(s.Values) == 0 {
+			s.Min, s.Max = 0, 0
+			report[i] = s
+			continue
+		}
+		s.Mean /= float64(len(s.Values))
+		for _, value := range s.Values {
+			s.StdDev += (value - s.Mean) * (value - s.Mean)
+		}
+		s.StdDev = math.Sqrt(s.StdDev / float64(len(s.Values)))
+		report[i] = s
+	}
+	return report
+}
+
+// WriteWindowsCSV는 구간별 선택 값과 최적화/검증 성과를 CSV로 씁니다
+func WriteWindowsCSV(w io.Writer, params []Param, result *WalkForwardResult) error {
+	writer := csv.NewWriter(w)
+	header := []string{"window", "in_sample_from", "in_sample_to", "out_of_sample_from", "out_of_sample_to"}
+	for _, param := range params {
+		header = append(header, param.Path)
+	}
+	header = append(header, "score", "in_sample_return", "in_sample_sharpe", "out_of_sample_return", "out_of_sample_sharpe", "out_of_sample_max_drawdown", "out_of_sample_trades", "error")
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for i, window := range result.Windows {
+		record := []string{strconv.Itoa(i + 1), formatTime(window.InSampleFrom), formatTime(window.InSampleTo), formatTime(window.OutOfSampleFrom), formatTime(window.OutOfSampleTo)}
+		for j := range params {
+			if window.Values == nil {
+				record = append(record, "")
+				continue
+			}
+			record = append(record, formatFloat(window.Values[j]))
+		}
+		if window.Err != nil {
+			record = append(record, "", "", "", "", "", "", "", window.Err.Error())
+		} else {
+			record = append(record, formatFloat(window.Score), formatFloat(window.InSample.TotalReturn), formatFloat(window.InSample.Sharpe),
+				formatFloat(window.OutOfSample.TotalReturn), formatFloat(window.OutOfSample.Sharpe), formatFloat(window.OutOfSample.MaxDrawdown),
+				strconv.Itoa(window.OutOfSample.Trades), "")
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteEquityCSV는 이어 붙인 검증 구간 자산 곡선과 단순 보유 자산 곡선을 CSV로 씁니다
+func WriteEquityCSV(w io.Writer, result *WalkForwardResult) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"time", "equity", "buy_and_hold"}); err != nil {
+		return err
+	}
+	for i, point := range result.Equity {
+		if err := writer.Write([]string{formatTime(point.Time), formatFloat(point.Equity), formatFloat(result.BuyAndHold[i].Equity)}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,83 @@
+package backtest
+
+import (
+	"context"
+	"go-trading-bot/internal/model"
+	"strconv"
+	"testing"
+)
+
+func TestWalkForward(t *testing.T) {
+	// 검증 구간 1(6~11): 7번째 캔들(20)에서 골든 크로스로 매수, 11번째 캔들(25)에 청산
+	// 검증 구간 2(12~17): 16번째 캔들(40)에서 골든 크로스로 매수, 17번째 캔들(50)에 청산
+	prices := []float64{10, 10, 10, 10, 10, 10, 8, 20, 20, 20, 40, 25, 25, 20, 10, 20, 40, 50}
+	candles := map[string][]model.Candle{"KRW-BTC": series("KRW-BTC", prices...)}
+	// short-period 4는 검증에 실패하므로 구간마다 2를 고릅니다
+	params := []Param{{Path: "moving-average-cross.short-period", Values: []float64{2, 4}}}
+
+	result, err := WalkForward(context.Background(), crossConfig(), candles, params, Grid(params), WalkForwardOptions{
+		InSample:    6,
+		OutOfSample: 6,
+		Optimize:    OptimizeOptions{Objective: OBJECTIVE_RETURN, Backtest: Options{FeeRate: -1}},
+	})
+	if err != nil {
+		t.Fatalf("WalkForward: %v", err)
+	}
+
+	if len(result.Windows) != 2 {
+		t.Fatalf("windows = %+v, want 2", result.Windows)
+	}
+	for i, w := range result.Windows {
+		from := 6 * (i + 1)
+		if !w.InSampleFrom.Equal(candleTime(from-6)) || !w.InSampleTo.Equal(candleTime(from)) ||
+			!w.OutOfSampleFrom.Equal(candleTime(from)) || !w.OutOfSampleTo.Equal(candleTime(from+6)) {
+			t.Errorf("window %d = [%v, %v) / [%v, %v), want in-sample from candle %d and out-of-sample from candle %d",
+				i+1, w.InSampleFrom, w.InSampleTo, w.OutOfSampleFrom, w.OutOfSampleTo, from-6, from)
+		}
+		if w.Err != nil || len(w.Values) != 1 || w.Values[0] != 2 {
+			t.Errorf("window %d: values %v, err %v, want short-period 2", i+1, w.Values, w.Err)
+		}
+	}
+	if w := result.Windows[1]; !near(w.OutOfSample.TotalReturn, 0.2) || w.OutOfSample.Trades != 1 {
+		t.Errorf("window 2 out-of-sample = %+v, want 20%% from the equity window 1 ended with", w.OutOfSample)
+	}
+
+	// 검증 구간만 캔들 마감 시각마다 이어 붙이고, 구간 2는 구간 1이 끝난 자산 1,250,000으로 시작합니다
+	want := []float64{
+		1000000, 1000000, 1000000, 1000000, 2000000, 1250000,
+		1250000, 1250000, 1250000, 1250000, 1250000, 1500000,
+	}
+	if len(result.Equity) != len(want) {
+		t.Fatalf("equity has %d points, want %d", len(result.Equity), len(want))
+	}
+	for i, point := range result.Equity {
+		if !point.Time.Equal(candleTime(i+7)) || point.Equity != want[i] {
+			t.Errorf("equity[%d] = %v at %v, want %v at the close of candle %d", i, point.Equity, point.Time, want[i], i+6)
+		}
+	}
+	for i, order := range result.Orders {
+		if order.ID != strconv.Itoa(i+1) {
+			t.Errorf("order %d has id %q, want ids renumbered across windows", i, order.ID)
+		}
+	}
+	if m := result.Metrics; len(result.Orders) != 4 || m.Trades != 2 || m.FinalEquity != 1500000 || !near(m.TotalReturn, 0.5) {
+		t.Errorf("stitched metrics = %+v with %d orders, want 2 trades ending at 1500000", m, len(result.Orders))
+	}
+
+	// 단순 보유는 검증 구간 1의 첫 종가 8에 1,000,000을 모두 사서 보유합니다
+	if len(result.BuyAndHold) != len(result.Equity) {
+		t.Fatalf("buy and hold has %d points, want %d", len(result.BuyAndHold), len(result.Equity))
+	}
+	for i, point := range result.BuyAndHold {
+		if !point.Time.Equal(result.Equity[i].Time) || !near(point.Equity, 125000*prices[i+6]) {
+			t.Errorf("buy and hold[%d] = %v at %v, want %v", i, point.Equity, point.Time, 125000*prices[i+6])
+		}
+	}
+	if m := result.BuyAndHoldMetrics; !near(m.FinalEquity, 6250000) || !near(m.TotalReturn, 5.25) {
+		t.Errorf("buy and hold metrics = %+v, want 6250000", m)
+	}
+
+	if s := result.Stability[0]; s.Path != params[0].Path || len(s.Values) != 2 || s.Mean != 2 || s.Changes != 0 {
+		t.Errorf("stability = %+v, want short-period 2 in both windows", s)
+	}
+}
@@ -1,25 +1,67 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"go-trading-bot/internal/metrics"
 	"go-trading-bot/internal/model"
 	"net/http"
+	"time"
 )
 
+// binanceHTTPClient는 요청 지연 시간, 상태 코드, 사용한 요청 가중치를 메트릭으로 기록합니다
+var binanceHTTPClient = metrics.NewClient("binance")
+
 type BinanceAPIClient struct {
 }
 
-func (b *BinanceAPIClient) GetPrices() ([]model.PriceTicker, error) {
-	resp, err := http.Get("https://api.binance.com/api/v3/ticker/price")
+func (b *BinanceAPIClient) GetPrices(ctx context.Context) ([]model.PriceTicker, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.binance.com/api/v3/ticker/price", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := binanceHTTPClient.Do(req)
     if err != nil {
-        panic(err)
+        return nil, err
     }
     defer resp.Body.Close()
 
+    if resp.StatusCode != http.StatusOK {
+        return nil, fmt.Errorf("binance: status code %d", resp.StatusCode)
+    }
+
     var tickers []model.PriceTicker
     if err := json.NewDecoder(resp.Body).Decode(&tickers); err != nil {
-        panic(err)
+        return nil, err
     }
 
 	return tickers, nil
-}
\ No newline at end of file
+}
+
+// ServerTime은 바이낸스 서버 시각을 조회합니다. 연결 확인과 시계 오차 계산에 사용합니다
+func (b *BinanceAPIClient) ServerTime(ctx context.Context) (time.Time, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.binance.com/api/v3/time", nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	resp, err := binanceHTTPClient.Do(req)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return time.Time{}, fmt.Errorf("binance: status code %d", resp.StatusCode)
+	}
+
+	var response struct {
+		ServerTime int64 `json:"serverTime"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return time.Time{}, err
+	}
+	return time.UnixMilli(response.ServerTime), nil
+}
@@ -0,0 +1,27 @@
+package client
+
+import (
+	"context"
+	"go-trading-bot/internal/model"
+	"time"
+)
+
+// ExchangeClient는 분석과 계좌 조회에 사용하는 업비트 API입니다. UpbitAPIClient가 구현합니다
+type ExchangeClient interface {
+	GetAllMarkets(ctx context.Context) ([]model.MarketInfo, error)
+	FetchCandles(ctx context.Context, market, path string, count int) ([]model.Candle, error)
+	FetchTickers(ctx context.Context, markets []string) ([]model.Ticker, error)
+	FetchBalance(ctx context.Context, accessKey, secretKey string) ([]model.Position, error)
+	ServerTime(ctx context.Context) (time.Time, error)
+}
+
+// PriceClient는 해외 거래소 시세 API입니다. BinanceAPIClient가 구현합니다
+type PriceClient interface {
+	GetPrices(ctx context.Context) ([]model.PriceTicker, error)
+	ServerTime(ctx context.Context) (time.Time, error)
+}
+
+var (
+	_ ExchangeClient = (*UpbitAPIClient)(nil)
+	_ PriceClient    = (*BinanceAPIClient)(nil)
+)
@@ -1,4 +1,145 @@
 package client
 
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-trading-bot/internal/logger"
+	"go-trading-bot/internal/model"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// OrderClient는 업비트 주문 API입니다. UpbitAPIClient가 구현합니다
 type OrderClient interface {
+	GetOrderChance(ctx context.Context, accessKey, secretKey, market string) (*model.UpbitOrderChance, error)
+	PlaceOrder(ctx context.Context, accessKey, secretKey string, request model.UpbitOrderRequest) (*model.UpbitOrder, error)
+	GetOrder(ctx context.Context, accessKey, secretKey, uuid string) (*model.UpbitOrder, error)
+	CancelOrder(ctx context.Context, accessKey, secretKey, uuid string) (*model.UpbitOrder, error)
+}
+
+var _ OrderClient = (*UpbitAPIClient)(nil)
+
+// APIError는 업비트가 반환한 오류 응답입니다 ({"error": {"name", "message"}})
+type APIError struct {
+	StatusCode int
+	Name       string
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("upbit: %s: %s (status code %d)", e.Name, e.Message, e.StatusCode)
+}
+
+// Unwrap은 401 응답이면 ErrUnauthorized를 반환해 errors.Is로 인증 실패를 확인할 수 있게 합니다
+func (e *APIError) Unwrap() error {
+	if e.StatusCode == http.StatusUnauthorized {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+// GetOrderChance는 마켓의 수수료, 최소 주문 금액, 주문 가능 잔고를 조회합니다
+func (u *UpbitAPIClient) GetOrderChance(ctx context.Context, accessKey, secretKey, market string) (*model.UpbitOrderChance, error) {
+	var chance model.UpbitOrderChance
+	if err := u.doPrivate(ctx, http.MethodGet, "/orders/chance", accessKey, secretKey, map[string]string{"market": market}, &chance); err != nil {
+		return nil, err
+	}
+	return &chance, nil
+}
+
+// PlaceOrder는 주문을 생성합니다
+func (u *UpbitAPIClient) PlaceOrder(ctx context.Context, accessKey, secretKey string, request model.UpbitOrderRequest) (*model.UpbitOrder, error) {
+	var order model.UpbitOrder
+	if err := u.doPrivate(ctx, http.MethodPost, "/orders", accessKey, secretKey, request.Params(), &order); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// GetOrder는 주문과 체결 내역을 조회합니다
+func (u *UpbitAPIClient) GetOrder(ctx context.Context, accessKey, secretKey, uuid string) (*model.UpbitOrder, error) {
+	var order model.UpbitOrder
+	if err := u.doPrivate(ctx, http.MethodGet, "/order", accessKey, secretKey, map[string]string{"uuid": uuid}, &order); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// CancelOrder는 대기 중인 주문을 취소합니다
+func (u *UpbitAPIClient) CancelOrder(ctx context.Context, accessKey, secretKey, uuid string) (*model.UpbitOrder, error) {
+	var order model.UpbitOrder
+	if err := u.doPrivate(ctx, http.MethodDelete, "/order", accessKey, secretKey, map[string]string{"uuid": uuid}, &order); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// doPrivate는 인증이 필요한 API를 호출합니다. GET/DELETE는 쿼리 문자열로, POST는 JSON 본문으로 params를 보내며
+// 두 경우 모두 params로 query_hash를 계산합니다
+func (u *UpbitAPIClient) doPrivate(ctx context.Context, method, path, accessKey, secretKey string, params map[string]string, out any) error {
+	log := logger.FromContext(ctx)
+	baseURL := u.BaseURL + path
+
+	var body io.Reader
+	if method == http.MethodPost {
+		data, err := json.Marshal(params)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, baseURL, body)
+	if err != nil {
+		log.Errorf("Failed to create request: %v", err)
+		return err
+	}
+	if method != http.MethodPost {
+		values := url.Values{}
+		for key, value := range params {
+			values.Set(key, value)
+		}
+		req.URL.RawQuery = values.Encode()
+	}
+
+	token, err := createJwt(accessKey, secretKey, params)
+	if err != nil {
+		log.Errorf("Failed to create JWT: %v", err)
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := upbitHTTPClient.Do(req)
+	if err != nil {
+		log.Errorf("Failed to call %v %v: %v", method, path, err)
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		log.Errorf("Failed to read response body: %v", err)
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		log.Errorf("Failed to call %v %v -> statusCode: %v, msg: %v", method, path, resp.StatusCode, string(data))
+		var response struct {
+			Error struct {
+				Name    string `json:"name"`
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		_ = json.Unmarshal(data, &response)
+		return &APIError{StatusCode: resp.StatusCode, Name: response.Error.Name, Message: response.Error.Message}
+	}
+
+	return json.Unmarshal(data, out)
 }
@@ -0,0 +1,230 @@
+package client_test
+
+import (
+	"context"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"go-trading-bot/internal/client"
+	"go-trading-bot/internal/client/upbittest"
+	"go-trading-bot/internal/model"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	accessKey = "test-access-key"
+	secretKey = "test-secret-key"
+)
+
+// request는 가짜 거래소가 받은 인증 요청과 JWT 클레임입니다
+type request struct {
+	method   string
+	query    string
+	body     string
+	claims   jwt.MapClaims
+	verified bool
+}
+
+// recorder는 업비트 가짜 거래소 앞에서 인증 요청을 기록합니다
+type recorder struct {
+	mu       sync.Mutex
+	requests []request
+}
+
+func (rec *recorder) last(t *testing.T) request {
+	t.Helper()
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	if len(rec.requests) == 0 {
+		t.Fatal("no authenticated request was recorded")
+	}
+	return rec.requests[len(rec.requests)-1]
+}
+
+func newExchange(t *testing.T) (*client.UpbitAPIClient, *upbittest.Server, *recorder) {
+	t.Helper()
+	exchange := upbittest.New(upbittest.Options{
+		AccessKey: accessKey,
+		SecretKey: secretKey,
+		Markets:   map[string]float64{"KRW-BTC": 50_000_000},
+		Balance:   1_000_000,
+	})
+	rec := &recorder{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+			body, _ := io.ReadAll(r.Body)
+			r.Body = io.NopCloser(strings.NewReader(string(body)))
+			claims := jwt.MapClaims{}
+			_, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (any, error) { return []byte(secretKey), nil })
+			rec.mu.Lock()
+			rec.requests = append(rec.requests, request{method: r.Method, query: r.URL.RawQuery, body: string(body), claims: claims, verified: err == nil})
+			rec.mu.Unlock()
+		}
+		exchange.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return &client.UpbitAPIClient{BaseURL: server.URL + "/v1"}, exchange, rec
+}
+
+// queryHash는 업비트 문서대로 파라미터를 키 순서로 정렬한 쿼리 문자열의 SHA-512입니다
+func queryHash(params map[string]string) string {
+	values := url.Values{}
+	for key, value := range params {
+		values.Set(key, value)
+	}
+	hash := sha512.Sum512([]byte(values.Encode()))
+	return hex.EncodeToString(hash[:])
+}
+
+func checkQueryHash(t *testing.T, req request, params map[string]string) {
+	t.Helper()
+	if !req.verified {
+		t.Errorf("%s request JWT is not signed with the secret key", req.method)
+	}
+	if req.claims["access_key"] != accessKey || req.claims["nonce"] == "" {
+		t.Errorf("claims = %v, want access_key and nonce", req.claims)
+	}
+	if req.claims["query_hash_alg"] != "SHA512" {
+		t.Errorf("query_hash_alg = %v, want SHA512", req.claims["query_hash_alg"])
+	}
+	if got, want := req.claims["query_hash"], queryHash(params); got != want {
+		t.Errorf("query_hash = %v, want %v for %v", got, want, params)
+	}
+}
+
+func TestPlaceOrderRoundTrip(t *testing.T) {
+	upbit, exchange, rec := newExchange(t)
+	ctx := context.Background()
+
+	request := model.UpbitOrderRequest{Market: "KRW-BTC", Side: model.UPBIT_SIDE_BID, OrdType: model.UPBIT_ORD_TYPE_PRICE, Price: "100000", Identifier: "cycle-1"}
+	placed, err := upbit.PlaceOrder(ctx, accessKey, secretKey, request)
+	if err != nil {
+		t.Fatalf("PlaceOrder: %v", err)
+	}
+	if placed.UUID == "" || placed.Market != "KRW-BTC" || placed.Side != model.UPBIT_SIDE_BID || placed.Identifier != "cycle-1" {
+		t.Errorf("placed order = %+v", placed)
+	}
+
+	// POST는 JSON 본문으로 보내고, query_hash는 같은 파라미터의 쿼리 문자열로 계산합니다
+	post := rec.last(t)
+	if post.method != http.MethodPost || post.query != "" || !strings.Contains(post.body, `"identifier":"cycle-1"`) {
+		t.Errorf("POST /orders sent query %q body %q", post.query, post.body)
+	}
+	checkQueryHash(t, post, request.Params())
+
+	order, err := upbit.GetOrder(ctx, accessKey, secretKey, placed.UUID)
+	if err != nil {
+		t.Fatalf("GetOrder: %v", err)
+	}
+	if order.State != model.UPBIT_ORDER_DONE || order.TradesCount != 1 || len(order.Trades) != 1 {
+		t.Errorf("market buy = %+v, want done with one trade", order)
+	}
+	get := rec.last(t)
+	if get.query != "uuid="+placed.UUID {
+		t.Errorf("GET /order query = %q", get.query)
+	}
+	checkQueryHash(t, get, map[string]string{"uuid": placed.UUID})
+
+	if orders := exchange.Orders(); len(orders) != 1 || orders[0].UUID != placed.UUID {
+		t.Errorf("exchange orders = %+v, want the placed order", orders)
+	}
+	balance, err := upbit.FetchBalance(ctx, accessKey, secretKey)
+	if err != nil {
+		t.Fatalf("FetchBalance: %v", err)
+	}
+	if !holds(balance, "BTC") {
+		t.Errorf("balance = %+v, want BTC after the buy", balance)
+	}
+}
+
+func TestCancelOrderRoundTrip(t *testing.T) {
+	upbit, _, rec := newExchange(t)
+	ctx := context.Background()
+
+	chance, err := upbit.GetOrderChance(ctx, accessKey, secretKey, "KRW-BTC")
+	if err != nil {
+		t.Fatalf("GetOrderChance: %v", err)
+	}
+	if chance.Market.ID != "KRW-BTC" || chance.BidAccount.Currency != "KRW" || chance.BidAccount.Balance != "1000000" {
+		t.Errorf("order chance = %+v", chance)
+	}
+	checkQueryHash(t, rec.last(t), map[string]string{"market": "KRW-BTC"})
+
+	// 현재가보다 낮은 지정가 매수는 대기 상태로 남습니다
+	placed, err := upbit.PlaceOrder(ctx, accessKey, secretKey, model.UpbitOrderRequest{
+		Market: "KRW-BTC", Side: model.UPBIT_SIDE_BID, OrdType: model.UPBIT_ORD_TYPE_LIMIT, Price: "40000000", Volume: "0.01",
+	})
+	if err != nil {
+		t.Fatalf("PlaceOrder: %v", err)
+	}
+	if placed.State != model.UPBIT_ORDER_WAIT {
+		t.Fatalf("limit buy state = %q, want wait", placed.State)
+	}
+
+	canceled, err := upbit.CancelOrder(ctx, accessKey, secretKey, placed.UUID)
+	if err != nil {
+		t.Fatalf("CancelOrder: %v", err)
+	}
+	if canceled.State != model.UPBIT_ORDER_CANCEL {
+		t.Errorf("canceled state = %q, want cancel", canceled.State)
+	}
+	del := rec.last(t)
+	if del.method != http.MethodDelete {
+		t.Errorf("cancel used %s, want DELETE", del.method)
+	}
+	checkQueryHash(t, del, map[string]string{"uuid": placed.UUID})
+
+	chance, err = upbit.GetOrderChance(ctx, accessKey, secretKey, "KRW-BTC")
+	if err != nil {
+		t.Fatalf("GetOrderChance after cancel: %v", err)
+	}
+	if chance.BidAccount.Balance != "1000000" || chance.BidAccount.Locked != "0" {
+		t.Errorf("bid account after cancel = %+v, want the locked KRW returned", chance.BidAccount)
+	}
+}
+
+func TestOrderErrors(t *testing.T) {
+	upbit, _, _ := newExchange(t)
+	ctx := context.Background()
+
+	_, err := upbit.PlaceOrder(ctx, accessKey, secretKey, model.UpbitOrderRequest{
+		Market: "KRW-BTC", Side: model.UPBIT_SIDE_BID, OrdType: model.UPBIT_ORD_TYPE_PRICE, Price: "5000000",
+	})
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest || apiErr.Name != "insufficient_funds_bid" {
+		t.Errorf("buy over balance = %v, want insufficient_funds_bid", err)
+	}
+	if errors.Is(err, client.ErrUnauthorized) {
+		t.Errorf("buy over balance matched ErrUnauthorized")
+	}
+
+	_, err = upbit.GetOrder(ctx, accessKey, "wrong-secret", "missing")
+	if !errors.Is(err, client.ErrUnauthorized) {
+		t.Errorf("wrong secret = %v, want ErrUnauthorized", err)
+	}
+	if !errors.As(err, &apiErr) || apiErr.Name != "jwt_verification" {
+		t.Errorf("wrong secret = %v, want jwt_verification", err)
+	}
+
+	_, err = upbit.GetOrder(ctx, accessKey, secretKey, "missing")
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound || apiErr.Name != "order_not_found" {
+		t.Errorf("unknown uuid = %v, want order_not_found", err)
+	}
+}
+
+func holds(positions []model.Position, currency string) bool {
+	for _, p := range positions {
+		if strings.HasSuffix(p.Market, currency) && p.Quantity > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -1,14 +1,19 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"go-trading-bot/internal/logger"
+	"go-trading-bot/internal/metrics"
 	"go-trading-bot/internal/model"
 	"io"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	"crypto/sha512"
 	"encoding/hex"
@@ -18,121 +23,181 @@ import (
 	"github.com/google/uuid"
 )
 
+// ErrUnauthorized는 API 키가 없거나 잘못되어 거래소가 401로 응답한 경우입니다
+var ErrUnauthorized = errors.New("unauthorized: check the API keys")
+
+// upbitHTTPClient는 요청 지연 시간, 상태 코드, 남은 요청 수를 메트릭으로 기록합니다
+var upbitHTTPClient = metrics.NewClient("upbit")
+
 type UpbitAPIClient struct {
 	BaseURL string
 }
 
-func (u *UpbitAPIClient) GetAllMarkets() ([]model.MarketInfo, error) {
+func (u *UpbitAPIClient) GetAllMarkets(ctx context.Context) ([]model.MarketInfo, error) {
+	log := logger.FromContext(ctx)
 	url := u.BaseURL + "/market/all"
 
-	resp, err := http.Get(url)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		log.Errorf("Failed to create request: %v", err)
+		return nil, err
+	}
+
+	resp, err := upbitHTTPClient.Do(req)
 	if err != nil {
-		logger.Log.Errorf("Failed to get all markets: %v", err)
+		log.Errorf("Failed to get all markets: %v", err)
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		logger.Log.Errorf("Failed to parse all markets: %v", err)
+		log.Errorf("Failed to parse all markets: %v", err)
 		return nil, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		logger.Log.Errorf("Failed to fetch candles -> statusCode: %v, msg: %v", resp.StatusCode, string(body))
+		log.Errorf("Failed to fetch candles -> statusCode: %v, msg: %v", resp.StatusCode, string(body))
 		return nil, errors.New("failed to fetch /market/all")
 	}
 
 	var marketInfo []model.MarketInfo
 	if err := json.Unmarshal(body, &marketInfo); err != nil {
-		logger.Log.Errorf("Failed to convert data(all markets): %v", err)
+		log.Errorf("Failed to convert data(all markets): %v", err)
 		return nil, err
 	}
 
 	return marketInfo, nil
 }
 
-func (u *UpbitAPIClient) FetchCandles(market string, path string, requireCandleCount int) ([]model.Candle, error) {
+func (u *UpbitAPIClient) FetchCandles(ctx context.Context, market string, path string, requireCandleCount int) ([]model.Candle, error) {
+	log := logger.FromContext(ctx)
 	baseURL := u.BaseURL + path
 
 	params := url.Values{}
 	params.Add("market", market)
 	params.Add("count", strconv.Itoa(requireCandleCount))
 
-	req, err := http.NewRequest("GET", baseURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", baseURL, nil)
 	if err != nil {
-		logger.Log.Errorf("Failed to create request: %v", err)
+		log.Errorf("Failed to create request: %v", err)
 		return nil, err
 	}
 
 	req.URL.RawQuery = params.Encode()
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := upbitHTTPClient.Do(req)
 	if err != nil {
-		logger.Log.Errorf("Failed to fetch candles: %v", err)
+		log.Errorf("Failed to fetch candles: %v", err)
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		logger.Log.Errorf("Failed to read response body: %v", err)
+		log.Errorf("Failed to read response body: %v", err)
 		return nil, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		logger.Log.Errorf("Failed to fetch candles -> url: %v, statusCode: %v, msg: %v", baseURL, resp.StatusCode, string(body))
+		log.Errorf("Failed to fetch candles -> url: %v, statusCode: %v, msg: %v", baseURL, resp.StatusCode, string(body))
 		return nil, errors.New("failed to fetch candles")
 	}
 
 	var candles []model.Candle
 	if err := json.Unmarshal(body, &candles); err != nil {
-		logger.Log.Errorf("Failed to convert data(candles): %v", err)
+		log.Errorf("Failed to convert data(candles): %v", err)
 		return nil, err
 	}
 
 	return candles, nil
 }
 
-func (u *UpbitAPIClient) FetchBalance(accessKey, secretKey string) ([]model.Position, error) {
+func (u *UpbitAPIClient) FetchTickers(ctx context.Context, markets []string) ([]model.Ticker, error) {
+	log := logger.FromContext(ctx)
+	baseURL := u.BaseURL + "/ticker"
+
+	params := url.Values{}
+	params.Add("markets", strings.Join(markets, ","))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", baseURL, nil)
+	if err != nil {
+		log.Errorf("Failed to create request: %v", err)
+		return nil, err
+	}
+
+	req.URL.RawQuery = params.Encode()
+
+	resp, err := upbitHTTPClient.Do(req)
+	if err != nil {
+		log.Errorf("Failed to fetch tickers: %v", err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		log.Errorf("Failed to read response body: %v", err)
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		log.Errorf("Failed to fetch tickers -> url: %v, statusCode: %v, msg: %v", baseURL, resp.StatusCode, string(body))
+		return nil, errors.New("failed to fetch tickers")
+	}
+
+	var tickers []model.Ticker
+	if err := json.Unmarshal(body, &tickers); err != nil {
+		log.Errorf("Failed to convert data(tickers): %v", err)
+		return nil, err
+	}
+
+	return tickers, nil
+}
+
+func (u *UpbitAPIClient) FetchBalance(ctx context.Context, accessKey, secretKey string) ([]model.Position, error) {
+	log := logger.FromContext(ctx)
 	baseURL := u.BaseURL + "/accounts"
 
-	req, err := http.NewRequest("GET", baseURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", baseURL, nil)
 	if err != nil {
-		logger.Log.Errorf("Failed to create request: %v", err)
+		log.Errorf("Failed to create request: %v", err)
 		return nil, err
 	}
 
 	token, err := createJwt(accessKey, secretKey, nil)
 	if err != nil {
-		logger.Log.Errorf("Failed to create JWT: %v", err)
+		log.Errorf("Failed to create JWT: %v", err)
 		return nil, err
 	}
 
 	req.Header.Set("Authorization", "Bearer "+token)
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := upbitHTTPClient.Do(req)
 	if err != nil {
-		logger.Log.Errorf("Failed to fetch balance: %v", err)
+		log.Errorf("Failed to fetch balance: %v", err)
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		logger.Log.Errorf("Failed to read response body: %v", err)
+		log.Errorf("Failed to read response body: %v", err)
 		return nil, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		logger.Log.Errorf("Failed to fetch balance -> url: %v, statusCode: %v, msg: %v", baseURL, resp.StatusCode, string(body))
+		log.Errorf("Failed to fetch balance -> url: %v, statusCode: %v, msg: %v", baseURL, resp.StatusCode, string(body))
+		if resp.StatusCode == http.StatusUnauthorized {
+			return nil, ErrUnauthorized
+		}
 		return nil, errors.New("failed to fetch balance")
 	}
 
 	var positions []any
 	if err := json.Unmarshal(body, &positions); err != nil {
-		logger.Log.Errorf("Failed to convert data(positions): %v", err)
+		log.Errorf("Failed to convert data(positions): %v", err)
 		return nil, err
 	}
 
@@ -141,19 +206,19 @@ func (u *UpbitAPIClient) FetchBalance(accessKey, secretKey string) ([]model.Posi
 		positionMap := position.(map[string]any)
 		balance, err := strconv.ParseFloat(positionMap["balance"].(string), 64)
 		if err != nil {
-			logger.Log.Errorf("Failed to parse balance: %v", err)
+			log.Errorf("Failed to parse balance: %v", err)
 			continue
 		}
 
 		locked, err := strconv.ParseFloat(positionMap["locked"].(string), 64)
 		if err != nil {
-			logger.Log.Errorf("Failed to parse locked: %v", err)
+			log.Errorf("Failed to parse locked: %v", err)
 			continue
 		}
 
 		avgBuyPrice, err := strconv.ParseFloat(positionMap["avg_buy_price"].(string), 64)
 		if err != nil {
-			logger.Log.Errorf("Failed to parse avg_buy_price: %v", err)
+			log.Errorf("Failed to parse avg_buy_price: %v", err)
 			continue
 		}
 
@@ -169,6 +234,25 @@ func (u *UpbitAPIClient) FetchBalance(accessKey, secretKey string) ([]model.Posi
 	return result, nil
 }
 
+// ServerTime은 업비트 응답의 Date 헤더로 서버 시각을 조회합니다. 연결 확인과 시계 오차 계산에 사용합니다
+func (u *UpbitAPIClient) ServerTime(ctx context.Context) (time.Time, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, u.BaseURL+"/market/all", nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	resp, err := upbitHTTPClient.Do(req)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return time.Time{}, fmt.Errorf("upbit: status code %d", resp.StatusCode)
+	}
+	return http.ParseTime(resp.Header.Get("Date"))
+}
+
 func createJwt(accessKey, secretKey string, params map[string]string) (string, error) {
 	claims := make(jwt.MapClaims)
 	claims["access_key"] = accessKey
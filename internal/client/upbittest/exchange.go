@@ -0,0 +1,416 @@
+package upbittest
+
+import (
+	"fmt"
+	"go-trading-bot/internal/model"
+	"go-trading-bot/internal/scheduler"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// account는 통화별 잔고입니다. locked는 대기 중인 주문에 묶인 금액(수량)입니다
+type account struct {
+	balance     float64
+	locked      float64
+	avgBuyPrice float64
+}
+
+// order는 가짜 거래소의 주문입니다. 전량 체결만 지원합니다
+type order struct {
+	uuid       string
+	side       string
+	ordType    string
+	market     string
+	price      float64 // 지정가 주문의 가격, 시장가 매수 주문의 총액
+	volume     float64
+	executed   float64
+	paidFee    float64
+	locked     float64 // 주문에 묶어 둔 원화(매수) 또는 수량(매도)
+	state      string
+	identifier string
+	createdAt  time.Time
+	trades     []model.UpbitTrade
+}
+
+// SetBalance는 통화의 잔고와 평균 매수가를 지정합니다. 원화는 "KRW"입니다
+func (s *Server) SetBalance(currency string, balance, avgBuyPrice float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a := s.account(currency)
+	a.balance = balance
+	a.avgBuyPrice = avgBuyPrice
+}
+
+// Orders는 지금까지 받은 주문을 생성 순서대로 반환합니다
+func (s *Server) Orders() []model.UpbitOrder {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	orders := make([]model.UpbitOrder, 0, len(s.orders))
+	for _, o := range s.orders {
+		orders = append(orders, o.response(true))
+	}
+	return orders
+}
+
+func (s *Server) account(currency string) *account {
+	a, ok := s.accounts[currency]
+	if !ok {
+		a = &account{}
+		s.accounts[currency] = a
+	}
+	return a
+}
+
+func (s *Server) handleAccounts(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.matchOrders()
+
+	currencies := make([]string, 0, len(s.accounts))
+	for currency, a := range s.accounts {
+		if currency != "KRW" && a.balance+a.locked == 0 {
+			continue
+		}
+		currencies = append(currencies, currency)
+	}
+	sort.Slice(currencies, func(i, j int) bool {
+		if currencies[i] == "KRW" || currencies[j] == "KRW" {
+			return currencies[i] == "KRW"
+		}
+		return currencies[i] < currencies[j]
+	})
+
+	accounts := make([]model.UpbitAccount, 0, len(currencies))
+	for _, currency := range currencies {
+		accounts = append(accounts, s.accountResponse(currency))
+	}
+	writeJSON(w, http.StatusOK, accounts)
+}
+
+func (s *Server) accountResponse(currency string) model.UpbitAccount {
+	a := s.account(currency)
+	return model.UpbitAccount{
+		Currency:     currency,
+		Balance:      formatNumber(a.balance),
+		Locked:       formatNumber(a.locked),
+		AvgBuyPrice:  formatNumber(a.avgBuyPrice),
+		UnitCurrency: "KRW",
+	}
+}
+
+func (s *Server) handleOrderChance(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.matchOrders()
+
+	market := params["market"]
+	if _, ok := s.markets[market]; !ok {
+		writeError(w, http.StatusNotFound, "market_does_not_exist", "market does not exist: "+market)
+		return
+	}
+	_, symbol, _ := strings.Cut(market, "-")
+	minTotal := formatNumber(MIN_ORDER_TOTAL)
+	writeJSON(w, http.StatusOK, model.UpbitOrderChance{
+		BidFee: formatNumber(FEE_RATE),
+		AskFee: formatNumber(FEE_RATE),
+		Market: model.UpbitChanceMarket{
+			ID:         market,
+			Name:       symbol + "/KRW",
+			OrderSides: []string{model.UPBIT_SIDE_ASK, model.UPBIT_SIDE_BID},
+			BidTypes:   []string{model.UPBIT_ORD_TYPE_LIMIT, model.UPBIT_ORD_TYPE_PRICE},
+			AskTypes:   []string{model.UPBIT_ORD_TYPE_LIMIT, model.UPBIT_ORD_TYPE_MARKET},
+			Bid:        model.UpbitChanceLimit{Currency: "KRW", MinTotal: minTotal},
+			Ask:        model.UpbitChanceLimit{Currency: symbol, MinTotal: minTotal},
+			MaxTotal:   "1000000000",
+			State:      "active",
+		},
+		BidAccount: s.accountResponse("KRW"),
+		AskAccount: s.accountResponse(symbol),
+	})
+}
+
+// handlePlaceOrder는 주문을 받아 잔고를 묶습니다. 시장가 주문과 현재가로 체결 가능한 지정가 주문은 바로 체결합니다
+func (s *Server) handlePlaceOrder(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.matchOrders()
+
+	market := params["market"]
+	current, ok := s.currentPrice(market)
+	if !ok {
+		writeError(w, http.StatusNotFound, "market_does_not_exist", "market does not exist: "+market)
+		return
+	}
+	_, symbol, _ := strings.Cut(market, "-")
+
+	o := &order{
+		uuid:       uuid.NewString(),
+		side:       params["side"],
+		ordType:    params["ord_type"],
+		market:     market,
+		state:      model.UPBIT_ORDER_WAIT,
+		identifier: params["identifier"],
+		createdAt:  s.now(),
+	}
+	if o.identifier != "" {
+		for _, existing := range s.orders {
+			if existing.identifier == o.identifier {
+				writeError(w, http.StatusBadRequest, "duplicated_identifier", "identifier already exists: "+o.identifier)
+				return
+			}
+		}
+	}
+
+	var err error
+	if o.price, err = parseParam(params, "price", o.ordType != model.UPBIT_ORD_TYPE_MARKET); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+	if o.volume, err = parseParam(params, "volume", o.ordType != model.UPBIT_ORD_TYPE_PRICE); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+
+	switch {
+	case o.side == model.UPBIT_SIDE_BID && o.ordType == model.UPBIT_ORD_TYPE_LIMIT,
+		o.side == model.UPBIT_SIDE_BID && o.ordType == model.UPBIT_ORD_TYPE_PRICE:
+		total := o.price
+		if o.ordType == model.UPBIT_ORD_TYPE_LIMIT {
+			total = o.price * o.volume
+		}
+		if total < MIN_ORDER_TOTAL {
+			writeError(w, http.StatusBadRequest, "under_min_total_bid", "최소주문금액 이상으로 주문해주세요")
+			return
+		}
+		krw := s.account("KRW")
+		o.locked = total * (1 + FEE_RATE)
+		if o.locked > krw.balance {
+			writeError(w, http.StatusBadRequest, "insufficient_funds_bid", "매수가능 금액이 부족합니다")
+			return
+		}
+		krw.balance -= o.locked
+		krw.locked += o.locked
+
+	case o.side == model.UPBIT_SIDE_ASK && o.ordType == model.UPBIT_ORD_TYPE_LIMIT,
+		o.side == model.UPBIT_SIDE_ASK && o.ordType == model.UPBIT_ORD_TYPE_MARKET:
+		price := o.price
+		if o.ordType == model.UPBIT_ORD_TYPE_MARKET {
+			price = current
+		}
+		if price*o.volume < MIN_ORDER_TOTAL {
+			writeError(w, http.StatusBadRequest, "under_min_total_ask", "최소주문금액 이상으로 주문해주세요")
+			return
+		}
+		asset := s.account(symbol)
+		if o.volume > asset.balance {
+			writeError(w, http.StatusBadRequest, "insufficient_funds_ask", "매도가능 잔고가 부족합니다")
+			return
+		}
+		o.locked = o.volume
+		asset.balance -= o.volume
+		asset.locked += o.volume
+
+	default:
+		writeError(w, http.StatusBadRequest, "invalid_parameter", "unsupported side/ord_type: "+o.side+"/"+o.ordType)
+		return
+	}
+
+	s.orders = append(s.orders, o)
+	s.matchOrder(o, current)
+	writeJSON(w, http.StatusCreated, o.response(false))
+}
+
+func (s *Server) handleOrders(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.matchOrders()
+
+	state := params["state"]
+	if state == "" {
+		state = params["states[]"]
+	}
+	if state == "" {
+		state = model.UPBIT_ORDER_WAIT
+	}
+
+	orders := []model.UpbitOrder{}
+	for i := len(s.orders) - 1; i >= 0; i-- {
+		o := s.orders[i]
+		if o.state != state || (params["market"] != "" && o.market != params["market"]) {
+			continue
+		}
+		orders = append(orders, o.response(false))
+	}
+	writeJSON(w, http.StatusOK, orders)
+}
+
+func (s *Server) handleOrder(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.matchOrders()
+
+	o := s.findOrder(params)
+	if o == nil {
+		writeError(w, http.StatusNotFound, "order_not_found", "주문을 찾지 못했습니다")
+		return
+	}
+	writeJSON(w, http.StatusOK, o.response(true))
+}
+
+// handleCancelOrder는 대기 중인 주문을 취소하고 묶인 잔고를 돌려줍니다
+func (s *Server) handleCancelOrder(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.matchOrders()
+
+	o := s.findOrder(params)
+	if o == nil {
+		writeError(w, http.StatusNotFound, "order_not_found", "주문을 찾지 못했습니다")
+		return
+	}
+	if o.state != model.UPBIT_ORDER_WAIT {
+		writeError(w, http.StatusBadRequest, "invalid_parameter", "order is not waiting: "+o.state)
+		return
+	}
+
+	a := s.account("KRW")
+	if o.side == model.UPBIT_SIDE_ASK {
+		_, symbol, _ := strings.Cut(o.market, "-")
+		a = s.account(symbol)
+	}
+	a.locked -= o.locked
+	a.balance += o.locked
+	o.locked = 0
+	o.state = model.UPBIT_ORDER_CANCEL
+	writeJSON(w, http.StatusOK, o.response(false))
+}
+
+func (s *Server) findOrder(params map[string]string) *order {
+	for _, o := range s.orders {
+		if (params["uuid"] != "" && o.uuid == params["uuid"]) ||
+			(params["uuid"] == "" && params["identifier"] != "" && o.identifier == params["identifier"]) {
+			return o
+		}
+	}
+	return nil
+}
+
+// matchOrders는 대기 중인 지정가 주문을 현재가로 다시 확인합니다. 호출하는 쪽에서 s.mu를 잡고 있어야 합니다
+func (s *Server) matchOrders() {
+	for _, o := range s.orders {
+		if o.state != model.UPBIT_ORDER_WAIT {
+			continue
+		}
+		if current, ok := s.currentPrice(o.market); ok {
+			s.matchOrder(o, current)
+		}
+	}
+}
+
+// matchOrder는 현재가로 체결 가능한 주문을 전량 체결합니다. 지정가 주문은 지정한 가격보다 유리한 현재가로 체결됩니다
+func (s *Server) matchOrder(o *order, current float64) {
+	switch o.ordType {
+	case model.UPBIT_ORD_TYPE_LIMIT:
+		if (o.side == model.UPBIT_SIDE_BID && current > o.price) || (o.side == model.UPBIT_SIDE_ASK && current < o.price) {
+			return
+		}
+	case model.UPBIT_ORD_TYPE_PRICE:
+		o.volume = o.price / current
+	}
+
+	_, symbol, _ := strings.Cut(o.market, "-")
+	krw, asset := s.account("KRW"), s.account(symbol)
+	funds := current * o.volume
+	fee := funds * FEE_RATE
+
+	if o.side == model.UPBIT_SIDE_BID {
+		krw.locked -= o.locked
+		krw.balance += o.locked - funds - fee
+		asset.avgBuyPrice = (asset.avgBuyPrice*(asset.balance+asset.locked) + funds) / (asset.balance + asset.locked + o.volume)
+		asset.balance += o.volume
+	} else {
+		asset.locked -= o.locked
+		krw.balance += funds - fee
+		if asset.balance+asset.locked == 0 {
+			asset.avgBuyPrice = 0
+		}
+	}
+
+	o.locked = 0
+	o.executed = o.volume
+	o.paidFee = fee
+	o.state = model.UPBIT_ORDER_DONE
+	o.trades = append(o.trades, model.UpbitTrade{
+		Market:    o.market,
+		UUID:      uuid.NewString(),
+		Price:     formatNumber(current),
+		Volume:    formatNumber(o.volume),
+		Funds:     formatNumber(funds),
+		Side:      o.side,
+		CreatedAt: formatTime(s.now()),
+	})
+}
+
+// response는 업비트 주문 응답으로 변환합니다. 체결 내역은 단건 조회에만 포함됩니다
+func (o *order) response(withTrades bool) model.UpbitOrder {
+	response := model.UpbitOrder{
+		UUID:           o.uuid,
+		Side:           o.side,
+		OrdType:        o.ordType,
+		State:          o.state,
+		Market:         o.market,
+		CreatedAt:      formatTime(o.createdAt),
+		ReservedFee:    "0",
+		RemainingFee:   "0",
+		PaidFee:        formatNumber(o.paidFee),
+		Locked:         formatNumber(o.locked),
+		ExecutedVolume: formatNumber(o.executed),
+		TradesCount:    len(o.trades),
+		Identifier:     o.identifier,
+	}
+	if o.ordType != model.UPBIT_ORD_TYPE_MARKET {
+		response.Price = formatNumber(o.price)
+	}
+	if o.ordType != model.UPBIT_ORD_TYPE_PRICE {
+		response.Volume = formatNumber(o.volume)
+		response.RemainingVolume = formatNumber(o.volume - o.executed)
+	}
+	if o.side == model.UPBIT_SIDE_BID && o.state == model.UPBIT_ORDER_WAIT {
+		reserved := o.locked * FEE_RATE / (1 + FEE_RATE)
+		response.ReservedFee = formatNumber(reserved)
+		response.RemainingFee = formatNumber(reserved)
+	}
+	if withTrades {
+		response.Trades = o.trades
+	}
+	return response
+}
+
+// parseParam은 숫자 파라미터를 읽습니다. required가 아니면 비어 있어도 됩니다
+func parseParam(params map[string]string, key string, required bool) (float64, error) {
+	value, ok := params[key]
+	if !ok || value == "" {
+		if required {
+			return 0, fmt.Errorf("%s is required", key)
+		}
+		return 0, nil
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || number <= 0 {
+		return 0, fmt.Errorf("%s must be a positive number", key)
+	}
+	return number, nil
+}
+
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func formatTime(t time.Time) string {
+	return t.In(scheduler.KST).Format(time.RFC3339)
+}
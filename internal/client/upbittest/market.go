@@ -0,0 +1,239 @@
+package upbittest
+
+import (
+	"go-trading-bot/internal/model"
+	"go-trading-bot/internal/scheduler"
+	"hash/fnv"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MAX_CANDLE_COUNT는 캔들 API가 한 번에 반환하는 최대 개수입니다
+const MAX_CANDLE_COUNT = 200
+
+// series는 한 마켓의 틱 가격입니다. prices[i]는 [origin + i*tick, origin + (i+1)*tick) 구간의 가격입니다
+type series struct {
+	origin time.Time
+	prices []float64
+	rng    *rand.Rand
+}
+
+// addMarket은 현재 틱의 가격이 price가 되도록 History 기간만큼 가격을 거꾸로 만들어 둡니다
+func (s *Server) addMarket(market string, price float64) {
+	if price <= 0 {
+		price = DEFAULT_PRICE
+	}
+	hash := fnv.New64a()
+	hash.Write([]byte(market))
+
+	count := int(s.opts.History / s.opts.Tick)
+	prices := make([]float64, count+1)
+	prices[count] = price
+	rng := rand.New(rand.NewPCG(s.opts.Seed, hash.Sum64()))
+	for i := count; i > 0; i-- {
+		prices[i-1] = prices[i] * math.Exp(-s.opts.Volatility*rng.NormFloat64())
+	}
+
+	s.markets[market] = &series{
+		origin: s.now().Truncate(s.opts.Tick).Add(-time.Duration(count) * s.opts.Tick),
+		prices: prices,
+		rng:    rng,
+	}
+	s.names = append(s.names, market)
+}
+
+// index는 t가 속한 틱 번호입니다
+func (s *Server) index(m *series, t time.Time) int {
+	return int(math.Floor(float64(t.Sub(m.origin)) / float64(s.opts.Tick)))
+}
+
+// advance는 현재 시각까지 가격을 이어서 만들고 현재 틱 번호를 반환합니다
+func (s *Server) advance(m *series) int {
+	current := s.index(m, s.now())
+	for len(m.prices) <= current {
+		last := m.prices[len(m.prices)-1]
+		m.prices = append(m.prices, last*math.Exp(s.opts.Volatility*m.rng.NormFloat64()))
+	}
+	return current
+}
+
+// currentPrice는 마켓의 현재 가격입니다. 호출하는 쪽에서 s.mu를 잡고 있어야 합니다
+func (s *Server) currentPrice(market string) (float64, bool) {
+	m, ok := s.markets[market]
+	if !ok {
+		return 0, false
+	}
+	return m.prices[s.advance(m)], true
+}
+
+// Price는 마켓의 현재 가격을 반환합니다
+func (s *Server) Price(market string) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	price, _ := s.currentPrice(market)
+	return price
+}
+
+// SetPrices는 틱 가격을 과거 → 최신 순서로 지정합니다. 마지막 가격이 현재 틱이며, 없는 마켓이면 새로 등록합니다.
+// 이후 틱은 Volatility가 0이면 마지막 가격을 유지합니다. 캔들 하나에 가격 하나를 두려면 Tick을 캔들 단위와 같게 설정하세요
+func (s *Server) SetPrices(market string, prices ...float64) {
+	if len(prices) == 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.markets[market]; !ok {
+		s.addMarket(market, prices[len(prices)-1])
+	}
+	m := s.markets[market]
+	current := s.advance(m)
+	m.prices = m.prices[:current+1]
+	for i := 0; i < len(prices) && current-i >= 0; i++ {
+		m.prices[current-i] = prices[len(prices)-1-i]
+	}
+	s.matchOrders()
+}
+
+func (s *Server) handleMarkets(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	markets := make([]model.MarketInfo, 0, len(s.names))
+	for _, market := range s.names {
+		_, symbol, _ := strings.Cut(market, "-")
+		markets = append(markets, model.MarketInfo{Market: market, KoreanName: symbol, EnglishName: symbol})
+	}
+	writeJSON(w, http.StatusOK, markets)
+}
+
+func (s *Server) handleTicker(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var tickers []model.Ticker
+	for _, market := range strings.Split(r.URL.Query().Get("markets"), ",") {
+		price, ok := s.currentPrice(market)
+		if !ok {
+			writeError(w, http.StatusNotFound, "not_found_market", "Code not found: "+market)
+			return
+		}
+		tickers = append(tickers, model.Ticker{Market: market, TradePrice: price, Timestamp: s.now().UnixMilli()})
+	}
+	writeJSON(w, http.StatusOK, tickers)
+}
+
+// candle은 업비트 캔들 응답입니다
+type candle struct {
+	Market               string  `json:"market"`
+	CandleDateTimeUTC    string  `json:"candle_date_time_utc"`
+	CandleDateTimeKST    string  `json:"candle_date_time_kst"`
+	OpeningPrice         float64 `json:"opening_price"`
+	HighPrice            float64 `json:"high_price"`
+	LowPrice             float64 `json:"low_price"`
+	TradePrice           float64 `json:"trade_price"`
+	Timestamp            int64   `json:"timestamp"`
+	CandleAccTradePrice  float64 `json:"candle_acc_trade_price"`
+	CandleAccTradeVolume float64 `json:"candle_acc_trade_volume"`
+	Unit                 int     `json:"unit,omitempty"`
+}
+
+// handleCandles는 /candles/minutes/{unit}, /candles/days, /candles/weeks, /candles/months를 처리합니다.
+// 현재 진행 중인 캔들부터 과거 순서로 반환하며, to가 있으면 to 이전에 시작한 캔들부터 반환합니다
+func (s *Server) handleCandles(w http.ResponseWriter, r *http.Request) {
+	category := r.PathValue("category")
+	unit := 0
+	switch category {
+	case "minutes":
+		var err error
+		if unit, err = strconv.Atoi(r.PathValue("unit")); err != nil || !slices.Contains([]int{1, 3, 5, 10, 15, 30, 60, 240}, unit) {
+			writeError(w, http.StatusBadRequest, "invalid_parameter", "unit must be one of 1, 3, 5, 10, 15, 30, 60, 240")
+			return
+		}
+	case "days", "weeks", "months":
+		if r.PathValue("unit") != "" {
+			http.NotFound(w, r)
+			return
+		}
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	query := r.URL.Query()
+	count := 1
+	if value := query.Get("count"); value != "" {
+		var err error
+		if count, err = strconv.Atoi(value); err != nil || count < 1 || count > MAX_CANDLE_COUNT {
+			writeError(w, http.StatusBadRequest, "invalid_parameter", "count must be between 1 and 200")
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	market := query.Get("market")
+	m, ok := s.markets[market]
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found_market", "Code not found: "+market)
+		return
+	}
+	current := s.advance(m)
+
+	end := s.now()
+	if value := query.Get("to"); value != "" {
+		to, err := parseTo(value)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_parameter", "invalid to: "+value)
+			return
+		}
+		end = to.Add(-time.Nanosecond)
+	}
+
+	candles := make([]candle, 0, count)
+	start := scheduler.CandleStart(category, unit, end)
+	for len(candles) < count {
+		next := scheduler.NextCandleStart(category, unit, start)
+		first := max(s.index(m, start), 0)
+		last := min(s.index(m, next.Add(-time.Nanosecond)), current)
+		if first > last {
+			break
+		}
+
+		c := candle{
+			Market:            market,
+			CandleDateTimeUTC: start.UTC().Format("2006-01-02T15:04:05"),
+			CandleDateTimeKST: start.In(scheduler.KST).Format("2006-01-02T15:04:05"),
+			OpeningPrice:      m.prices[first],
+			HighPrice:         m.prices[first],
+			LowPrice:          m.prices[first],
+			TradePrice:        m.prices[last],
+			Timestamp:         min(next.UnixMilli(), s.now().UnixMilli()),
+			Unit:              unit,
+		}
+		for _, price := range m.prices[first : last+1] {
+			c.HighPrice = max(c.HighPrice, price)
+			c.LowPrice = min(c.LowPrice, price)
+			c.CandleAccTradePrice += price
+			c.CandleAccTradeVolume++
+		}
+		candles = append(candles, c)
+
+		start = scheduler.CandleStart(category, unit, start.Add(-time.Nanosecond))
+	}
+	writeJSON(w, http.StatusOK, candles)
+}
+
+// parseTo는 캔들 API의 to 파라미터를 해석합니다. 시간대가 없으면 UTC입니다
+func parseTo(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("2006-01-02T15:04:05", strings.Replace(value, " ", "T", 1), time.UTC)
+}
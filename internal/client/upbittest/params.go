@@ -0,0 +1,85 @@
+package upbittest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// requestParams는 요청 파라미터와 query_hash로 인정할 쿼리 문자열 목록을 반환합니다.
+// 업비트와 같이 클라이언트가 보낸 순서 그대로의 문자열과 키를 정렬한 문자열을 모두 인정하며, 각각 URL 인코딩 전/후를 허용합니다.
+// 파라미터가 없으면 빈 목록을 반환합니다
+func requestParams(r *http.Request) ([]string, map[string]string, error) {
+	var ordered []string
+	values := url.Values{}
+
+	if r.Method == http.MethodPost {
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(bytes.TrimSpace(data)) > 0 {
+			if ordered, values, err = bodyParams(data); err != nil {
+				return nil, nil, err
+			}
+		}
+	} else if r.URL.RawQuery != "" {
+		ordered = []string{r.URL.RawQuery}
+		values = r.URL.Query()
+	}
+
+	params := make(map[string]string, len(values))
+	for key := range values {
+		params[key] = values.Get(key)
+	}
+	if len(values) == 0 {
+		return nil, params, nil
+	}
+
+	candidates := append(ordered, values.Encode())
+	for _, query := range append([]string(nil), candidates...) {
+		if unescaped, err := url.QueryUnescape(query); err == nil && unescaped != query {
+			candidates = append(candidates, unescaped)
+		}
+	}
+	return candidates, params, nil
+}
+
+// bodyParams는 JSON 본문을 키 순서를 유지한 쿼리 문자열과 url.Values로 변환합니다
+func bodyParams(data []byte) ([]string, url.Values, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil, nil, fmt.Errorf("request body must be a JSON object")
+	}
+
+	values := url.Values{}
+	var pairs []string
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		key, _ := token.(string)
+
+		var value any
+		if err := decoder.Decode(&value); err != nil {
+			return nil, nil, err
+		}
+		switch v := value.(type) {
+		case []any:
+			for _, item := range v {
+				values.Add(key, fmt.Sprint(item))
+				pairs = append(pairs, url.QueryEscape(key)+"="+url.QueryEscape(fmt.Sprint(item)))
+			}
+		default:
+			values.Add(key, fmt.Sprint(v))
+			pairs = append(pairs, url.QueryEscape(key)+"="+url.QueryEscape(fmt.Sprint(v)))
+		}
+	}
+	return []string{strings.Join(pairs, "&")}, values, nil
+}
@@ -0,0 +1,208 @@
+// Package upbittest는 업비트 Open API를 대신하는 로컬 거래소입니다.
+// 시세(마켓 목록, 캔들, 현재가)는 정해 둔 가격이나 랜덤워크로 만들고, 계좌와 주문은 메모리에서 체결합니다.
+// 인증 API는 실제 업비트와 같이 JWT 서명(HS256/HS512), nonce 재사용, query_hash를 검증합니다.
+// UpbitAPIClient의 BaseURL(UPBIT_API_URL)을 Server.URL로 지정하면 네트워크 없이 봇 전체를 실행할 수 있습니다
+package upbittest
+
+import (
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"go-trading-bot/internal/clock"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// 기본값
+const (
+	DEFAULT_TICK    = time.Minute
+	DEFAULT_HISTORY = 200 * 24 * time.Hour
+	DEFAULT_BALANCE = 10_000_000.0
+	DEFAULT_PRICE   = 100_000.0
+	FEE_RATE        = 0.0005 // 업비트 원화 마켓 거래 수수료
+	MIN_ORDER_TOTAL = 5000.0 // 최소 주문 금액(원)
+)
+
+// Options는 가짜 거래소 설정입니다. 비어 있는 값은 기본값을 사용합니다
+type Options struct {
+	AccessKey  string
+	SecretKey  string
+	Markets    map[string]float64 // 마켓(KRW-XXX)별 현재 가격
+	Balance    float64            // 시작 원화 잔고 (기본값 DEFAULT_BALANCE)
+	Tick       time.Duration      // 가격이 바뀌는 간격 (기본값 1분)
+	History    time.Duration      // 시작 시점 이전에 만들어 둘 가격 기록 (기본값 200일)
+	Volatility float64            // 틱당 로그 수익률의 표준편차, 0이면 SetPrices로 정한 가격 외에는 변하지 않음
+	Seed       uint64             // 랜덤워크 시드, 같은 시드와 시작 시각이면 같은 가격이 만들어집니다
+	Clock      clock.Clock        // 기본값 clock.Real
+}
+
+// Server는 업비트 API를 흉내 내는 http.Handler입니다
+type Server struct {
+	URL string // NewServer로 시작한 경우의 API 주소 (.../v1)
+
+	opts    Options
+	handler http.Handler
+	server  *httptest.Server
+
+	mu       sync.Mutex
+	markets  map[string]*series
+	names    []string // 마켓 등록 순서
+	accounts map[string]*account
+	orders   []*order
+	nonces   map[string]bool
+}
+
+// New는 요청을 처리할 Server를 생성합니다. 직접 http.Server에 연결할 때 사용합니다
+func New(opts Options) *Server {
+	if opts.Tick <= 0 {
+		opts.Tick = DEFAULT_TICK
+	}
+	if opts.History <= 0 {
+		opts.History = DEFAULT_HISTORY
+	}
+	if opts.Balance <= 0 {
+		opts.Balance = DEFAULT_BALANCE
+	}
+	if opts.Clock == nil {
+		opts.Clock = clock.Real
+	}
+
+	s := &Server{
+		opts:     opts,
+		markets:  make(map[string]*series),
+		accounts: map[string]*account{"KRW": {balance: opts.Balance}},
+		nonces:   make(map[string]bool),
+	}
+	for _, market := range slices.Sorted(maps.Keys(opts.Markets)) {
+		s.addMarket(market, opts.Markets[market])
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /market/all", s.handleMarkets)
+	mux.HandleFunc("GET /candles/{category}", s.handleCandles)
+	mux.HandleFunc("GET /candles/{category}/{unit}", s.handleCandles)
+	mux.HandleFunc("GET /ticker", s.handleTicker)
+	mux.HandleFunc("GET /accounts", s.private(s.handleAccounts))
+	mux.HandleFunc("GET /orders/chance", s.private(s.handleOrderChance))
+	mux.HandleFunc("POST /orders", s.private(s.handlePlaceOrder))
+	mux.HandleFunc("GET /orders", s.private(s.handleOrders))
+	mux.HandleFunc("GET /order", s.private(s.handleOrder))
+	mux.HandleFunc("DELETE /order", s.private(s.handleCancelOrder))
+
+	// 실제 API 주소와 같이 /v1 아래에서도 응답합니다
+	root := http.NewServeMux()
+	root.Handle("/v1/", http.StripPrefix("/v1", mux))
+	root.Handle("/", mux)
+	s.handler = root
+	return s
+}
+
+// NewServer는 로컬 포트에서 Server를 시작합니다. URL은 /v1까지 포함합니다
+func NewServer(opts Options) *Server {
+	s := New(opts)
+	s.server = httptest.NewServer(s)
+	s.URL = s.server.URL + "/v1"
+	return s
+}
+
+// Close는 NewServer로 시작한 서버를 종료합니다
+func (s *Server) Close() {
+	if s.server != nil {
+		s.server.Close()
+	}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.handler.ServeHTTP(w, r)
+}
+
+// private는 JWT 인증을 확인한 뒤 next를 호출합니다. params는 query_hash 검증에 사용한 요청 파라미터입니다
+func (s *Server) private(next func(w http.ResponseWriter, r *http.Request, params map[string]string)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		candidates, params, err := requestParams(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_parameter", err.Error())
+			return
+		}
+		if name, err := s.authenticate(r, candidates); err != nil {
+			writeError(w, http.StatusUnauthorized, name, err.Error())
+			return
+		}
+		next(w, r, params)
+	}
+}
+
+// authenticate는 Authorization 헤더의 JWT를 검증합니다. 실패하면 업비트 오류 이름을 함께 반환합니다
+func (s *Server) authenticate(r *http.Request, queryStrings []string) (string, error) {
+	header := r.Header.Get("Authorization")
+	tokenString, found := strings.CutPrefix(header, "Bearer ")
+	if !found || tokenString == "" {
+		return "jwt_verification", errors.New("authorization header is missing")
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (any, error) {
+		if claims["access_key"] != s.opts.AccessKey {
+			return nil, errors.New("unknown access key")
+		}
+		return []byte(s.opts.SecretKey), nil
+	}, jwt.WithValidMethods([]string{"HS256", "HS512"}))
+	if err != nil {
+		if claims["access_key"] != s.opts.AccessKey {
+			return "invalid_access_key", errors.New("unknown access key")
+		}
+		return "jwt_verification", err
+	}
+
+	nonce, _ := claims["nonce"].(string)
+	if nonce == "" {
+		return "jwt_verification", errors.New("nonce is missing")
+	}
+	s.mu.Lock()
+	used := s.nonces[nonce]
+	s.nonces[nonce] = true
+	s.mu.Unlock()
+	if used {
+		return "nonce_used", errors.New("nonce has already been used")
+	}
+
+	if len(queryStrings) == 0 {
+		return "", nil
+	}
+	queryHash, _ := claims["query_hash"].(string)
+	if queryHash == "" {
+		return "invalid_query_payload", errors.New("query_hash is missing")
+	}
+	if alg, _ := claims["query_hash_alg"].(string); alg != "" && alg != "SHA512" {
+		return "invalid_query_payload", errors.New("query_hash_alg must be SHA512")
+	}
+	for _, query := range queryStrings {
+		hash := sha512.Sum512([]byte(query))
+		if hex.EncodeToString(hash[:]) == queryHash {
+			return "", nil
+		}
+	}
+	return "invalid_query_payload", errors.New("query_hash does not match the request")
+}
+
+func (s *Server) now() time.Time {
+	return s.opts.Clock.Now()
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, name, message string) {
+	writeJSON(w, status, map[string]any{"error": map[string]string{"name": name, "message": message}})
+}
@@ -0,0 +1,153 @@
+// Package clock은 현재 시각과 타이머를 주입할 수 있도록 time.Now, time.NewTimer를 감쌉니다
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock은 현재 시각과 타이머를 제공합니다
+type Clock interface {
+	Now() time.Time
+	// NewTimer는 d 뒤에 그 시각을 C()로 한 번 보내는 Timer를 생성합니다
+	NewTimer(d time.Duration) Timer
+	// After는 d 뒤에 그 시각을 한 번 보내는 채널을 반환합니다
+	After(d time.Duration) <-chan time.Time
+}
+
+// Timer는 Clock이 만든 타이머입니다. time.Timer와 같이 동작합니다
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) NewTimer(d time.Duration) Timer         { return realTimer{time.NewTimer(d)} }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+type realTimer struct{ *time.Timer }
+
+func (t realTimer) C() <-chan time.Time { return t.Timer.C }
+
+// Real은 시스템 시각(time.Now)을 사용하는 Clock입니다
+var Real Clock = realClock{}
+
+// Fake는 Set, Advance로만 시각이 바뀌는 Clock입니다. 타이머는 시각이 만료 시각에 도달하면 발동합니다
+type Fake struct {
+	mu      sync.Mutex
+	now     time.Time
+	timers  []*fakeTimer
+	changed *sync.Cond // 타이머가 추가되거나 멈출 때 BlockUntilTimers를 깨웁니다
+}
+
+// NewFake는 now에 멈춰 있는 Fake를 생성합니다
+func NewFake(now time.Time) *Fake {
+	f := &Fake{now: now}
+	f.changed = sync.NewCond(&f.mu)
+	return f
+}
+
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Set은 현재 시각을 now로 바꾸고 만료된 타이머를 발동합니다
+func (f *Fake) Set(now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = now
+	f.fire()
+}
+
+// Advance는 현재 시각을 d만큼 앞으로 옮기고 만료된 타이머를 발동합니다
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+	f.fire()
+}
+
+func (f *Fake) NewTimer(d time.Duration) Timer {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t := &fakeTimer{clock: f, c: make(chan time.Time, 1)}
+	f.schedule(t, d)
+	return t
+}
+
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	return f.NewTimer(d).C()
+}
+
+// BlockUntilTimers는 대기 중인 타이머가 n개 이상이 될 때까지 기다립니다.
+// 다른 고루틴이 타이머를 건 뒤에 Advance하도록 테스트에서 사용합니다
+func (f *Fake) BlockUntilTimers(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for len(f.timers) < n {
+		f.changed.Wait()
+	}
+}
+
+// schedule은 t를 d 뒤에 발동하도록 등록합니다. f.mu를 잡은 상태에서 호출해야 합니다
+func (f *Fake) schedule(t *fakeTimer, d time.Duration) {
+	t.when = f.now.Add(d)
+	f.timers = append(f.timers, t)
+	f.fire()
+	f.changed.Broadcast()
+}
+
+// fire는 만료 시각에 도달한 타이머를 발동합니다. f.mu를 잡은 상태에서 호출해야 합니다
+func (f *Fake) fire() {
+	active := f.timers[:0]
+	for _, t := range f.timers {
+		if t.when.After(f.now) {
+			active = append(active, t)
+			continue
+		}
+		select {
+		case t.c <- f.now:
+		default:
+		}
+	}
+	f.timers = active
+}
+
+// remove는 대기 중인 t를 제거하고 제거했는지 반환합니다. f.mu를 잡은 상태에서 호출해야 합니다
+func (f *Fake) remove(t *fakeTimer) bool {
+	for i, timer := range f.timers {
+		if timer == t {
+			f.timers = append(f.timers[:i], f.timers[i+1:]...)
+			f.changed.Broadcast()
+			return true
+		}
+	}
+	return false
+}
+
+type fakeTimer struct {
+	clock *Fake
+	c     chan time.Time
+	when  time.Time
+}
+
+func (t *fakeTimer) C() <-chan time.Time { return t.c }
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	return t.clock.remove(t)
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	active := t.clock.remove(t)
+	t.clock.schedule(t, d)
+	return active
+}
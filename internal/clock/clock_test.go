@@ -0,0 +1,52 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeTimer(t *testing.T) {
+	start := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	fake := NewFake(start)
+	timer := fake.NewTimer(time.Minute)
+	after := fake.After(2 * time.Minute)
+
+	fake.Advance(59 * time.Second)
+	select {
+	case <-timer.C():
+		t.Fatal("timer fired early")
+	default:
+	}
+
+	fake.Advance(time.Second)
+	if got := <-timer.C(); !got.Equal(start.Add(time.Minute)) {
+		t.Errorf("timer fired at %v, want %v", got, start.Add(time.Minute))
+	}
+	if timer.Stop() {
+		t.Error("Stop of a fired timer = true, want false")
+	}
+
+	if timer.Reset(time.Minute) {
+		t.Error("Reset of a fired timer = true, want false")
+	}
+	if !timer.Stop() {
+		t.Error("Stop of a pending timer = false, want true")
+	}
+
+	fake.Set(start.Add(5 * time.Minute))
+	select {
+	case <-timer.C():
+		t.Error("stopped timer fired")
+	default:
+	}
+	if got := <-after; !got.Equal(start.Add(5 * time.Minute)) {
+		t.Errorf("After fired at %v, want the time it was reached", got)
+	}
+
+	// 0 이하의 타이머는 바로 발동합니다
+	select {
+	case <-fake.After(0):
+	default:
+		t.Error("After(0) did not fire immediately")
+	}
+}
@@ -0,0 +1,371 @@
+// go-trading-bot 대시보드
+// 봇의 JSON API(/api/v1)만 사용하며, 외부 라이브러리 없이 canvas로 차트를 그립니다.
+(function () {
+  "use strict";
+
+  const API = "/api/v1";
+  const KEY_STORAGE = "go-trading-bot.apiKey";
+  const REFRESH_INTERVAL = 60 * 1000;
+  const EVENT_REFRESH_DELAY = 2 * 1000;
+
+  const SIGNAL_TYPES = ["BUY", "SELL", "HOLD"];
+  const STAGE_NAMES = ["-", "STAGE_1", "STAGE_2", "STAGE_3", "STAGE_4", "STAGE_5", "STAGE_6"];
+  const MA_COLORS = ["#f2c94c", "#56ccf2", "#bb6bd9"];
+  const PRICE_COLOR = "#d8dee9";
+
+  const numberFormat = new Intl.NumberFormat("ko-KR", { maximumFractionDigits: 2 });
+  const quantityFormat = new Intl.NumberFormat("ko-KR", { maximumFractionDigits: 8 });
+
+  let apiKey = localStorage.getItem(KEY_STORAGE) || "";
+  let refreshTimer = null;
+  let eventAbort = null;
+
+  // ===== API =====
+
+  async function api(path) {
+    const res = await fetch(API + path, { headers: { "X-API-Key": apiKey } });
+    const body = await res.json().catch(() => ({}));
+    if (!res.ok || body.success === false) {
+      throw new Error(body.message || res.status + " " + res.statusText);
+    }
+    return body.data;
+  }
+
+  // /events(SSE)는 EventSource가 헤더를 보낼 수 없어 fetch 스트림으로 읽습니다.
+  // 신호, 주문, 사이클 종료 이벤트를 받으면 화면을 갱신합니다.
+  async function subscribeEvents() {
+    if (eventAbort) {
+      eventAbort.abort();
+    }
+    eventAbort = new AbortController();
+
+    const types = "signal.generated,order.filled,risk.exit,cycle.finished";
+    try {
+      const res = await fetch(API + "/events?type=" + types, {
+        headers: { "X-API-Key": apiKey },
+        signal: eventAbort.signal,
+      });
+      if (!res.ok || !res.body) {
+        return;
+      }
+
+      const reader = res.body.getReader();
+      const decoder = new TextDecoder();
+      let buffer = "";
+      for (;;) {
+        const { value, done } = await reader.read();
+        if (done) {
+          break;
+        }
+        buffer += decoder.decode(value, { stream: true });
+        let index;
+        while ((index = buffer.indexOf("\n\n")) >= 0) {
+          const chunk = buffer.slice(0, index);
+          buffer = buffer.slice(index + 2);
+          if (chunk.split("\n").some((line) => line.startsWith("data:"))) {
+            scheduleRefresh(EVENT_REFRESH_DELAY);
+          }
+        }
+      }
+    } catch (e) {
+      if (e.name === "AbortError") {
+        return;
+      }
+    }
+    // 연결이 끊기면 잠시 후 다시 구독합니다
+    setTimeout(subscribeEvents, 10 * 1000);
+  }
+
+  // ===== 화면 갱신 =====
+
+  function scheduleRefresh(delay) {
+    clearTimeout(refreshTimer);
+    refreshTimer = setTimeout(refresh, delay);
+  }
+
+  async function refresh() {
+    scheduleRefresh(REFRESH_INTERVAL);
+    if (!apiKey) {
+      showError("API 키를 입력하세요.");
+      return;
+    }
+
+    try {
+      const [status, signals, positions, pnl, orders] = await Promise.all([
+        api("/status"),
+        api("/signal"),
+        api("/positions?size=100"),
+        api("/pnl"),
+        api("/orders?size=20"),
+      ]);
+      showError("");
+      renderStatus(status);
+      renderSignals(signals);
+      renderPositions(positions);
+      renderPnL(pnl);
+      renderOrders(orders);
+      await renderMarkets(status.Markets, signals);
+    } catch (e) {
+      showError(e.message);
+    }
+  }
+
+  function showError(message) {
+    const el = document.getElementById("error");
+    el.textContent = message;
+    el.hidden = !message;
+  }
+
+  function renderStatus(status) {
+    const el = document.getElementById("bot-status");
+    el.replaceChildren(
+      badge(status.Paused ? "일시정지" : "실행 중", !status.Paused),
+      badge(status.EntryAllowed ? "진입 가능" : "진입 불가", status.EntryAllowed),
+      text("사이클 " + status.Cycle.CycleCount + "회"),
+      text(status.Cycle.EndedAt ? "최근 " + formatTime(status.Cycle.EndedAt) : "")
+    );
+  }
+
+  async function renderMarkets(markets, signals) {
+    const container = document.getElementById("markets");
+    const template = document.getElementById("market-card");
+    const signalByMarket = Object.fromEntries(signals.map((s) => [s.Market, s]));
+
+    const charts = await Promise.all(
+      markets.map((market) => api("/chart?market=" + encodeURIComponent(market)).catch(() => null))
+    );
+
+    container.replaceChildren();
+    markets.forEach((market, i) => {
+      const card = template.content.firstElementChild.cloneNode(true);
+      card.querySelector(".market").textContent = market;
+
+      const signal = signalByMarket[market];
+      const stage = card.querySelector(".stage");
+      if (signal && signal.Stage) {
+        stage.textContent = STAGE_NAMES[signal.Stage.StageNumber] + " (" + signal.Stage.StageDir + ")";
+        stage.title = signal.Stage.Description;
+      } else if (signal) {
+        stage.textContent = SIGNAL_TYPES[signal.Type];
+      }
+
+      container.appendChild(card);
+      if (charts[i]) {
+        drawPriceChart(card.querySelector("canvas"), card.querySelector(".legend"), charts[i]);
+      }
+    });
+  }
+
+  function renderSignals(signals) {
+    signals.sort((a, b) => a.Market.localeCompare(b.Market));
+    fillTable("signals", signals, (s) => [
+      s.Market,
+      cell(SIGNAL_TYPES[s.Type], s.Type === 0 ? "up" : s.Type === 1 ? "down" : ""),
+      s.Stage ? STAGE_NAMES[s.Stage.StageNumber] : "-",
+      numberFormat.format(s.CurrentPrice),
+      cell(s.Description, "desc"),
+      s.Timestamp,
+    ]);
+  }
+
+  function renderPositions(positions) {
+    fillTable("positions", positions, (p) => [
+      p.Market,
+      quantityFormat.format(p.Quantity),
+      numberFormat.format(p.EntryPrice),
+      numberFormat.format(p.CurrentPrice),
+      profitCell(p.UnrealizedProfit),
+      profitCell(p.UnrealizedProfitRate, "%"),
+    ]);
+  }
+
+  function renderOrders(orders) {
+    fillTable("orders", orders, (o) => [
+      formatTime(o.CreatedAt),
+      o.Market,
+      cell(o.Side, o.Side === "BUY" ? "up" : "down"),
+      numberFormat.format(o.Price),
+      quantityFormat.format(o.Quantity),
+      o.Side === "SELL" ? profitCell(o.Profit) : "",
+    ]);
+  }
+
+  function renderPnL(pnl) {
+    const winRate = pnl.TradeCount > 0 ? (pnl.WinCount / pnl.TradeCount) * 100 : 0;
+    document.getElementById("pnl-summary").replaceChildren(
+      text("총 손익 "),
+      profitCell(pnl.TotalProfit),
+      text("거래 " + pnl.TradeCount + "회"),
+      text("승률 " + numberFormat.format(winRate) + "%")
+    );
+
+    // 일자별 실현 손익 누적합으로 자산 곡선을 그립니다
+    let equity = 0;
+    const points = pnl.ByDay.map((d) => {
+      equity += d.Profit;
+      return { label: d.Key, value: equity };
+    });
+    drawChart(document.getElementById("equity"), points.map((p) => p.label), [
+      { values: points.map((p) => p.value), color: equity >= 0 ? "#e5534b" : "#4c8ee6" },
+    ]);
+  }
+
+  // ===== 차트 =====
+
+  function drawPriceChart(canvas, legend, chart) {
+    const labels = chart.Candles.map((c) => c.candle_date_time_utc);
+    const index = Object.fromEntries(labels.map((label, i) => [label, i]));
+
+    const series = [{ values: chart.Candles.map((c) => c.trade_price), color: PRICE_COLOR }];
+    legend.replaceChildren(colored("가격", PRICE_COLOR));
+    (chart.MovingAverages || []).forEach((ma, i) => {
+      const values = new Array(labels.length).fill(null);
+      ma.Points.forEach((p) => {
+        if (p.Time in index) {
+          values[index[p.Time]] = p.Value;
+        }
+      });
+      const color = MA_COLORS[i % MA_COLORS.length];
+      series.push({ values: values, color: color });
+      legend.appendChild(colored("MA" + ma.Period, color));
+    });
+
+    drawChart(canvas, labels.map((label) => label.replace("T", " ").slice(0, 16) + " UTC"), series);
+  }
+
+  // drawChart는 labels(x축)와 series(y값 배열, null은 건너뜀)로 선 차트를 그립니다
+  function drawChart(canvas, labels, series) {
+    const ctx = canvas.getContext("2d");
+    const width = canvas.width;
+    const height = canvas.height;
+    const pad = { top: 10, right: 70, bottom: 20, left: 8 };
+
+    ctx.clearRect(0, 0, width, height);
+    const values = series.flatMap((s) => s.values).filter((v) => v !== null && v !== undefined);
+    if (labels.length === 0 || values.length === 0) {
+      ctx.fillStyle = "#7b8494";
+      ctx.font = "12px sans-serif";
+      ctx.fillText("데이터 없음", width / 2 - 30, height / 2);
+      return;
+    }
+
+    let minValue = Math.min(...values);
+    let maxValue = Math.max(...values);
+    if (minValue === maxValue) {
+      minValue -= 1;
+      maxValue += 1;
+    }
+
+    const plotWidth = width - pad.left - pad.right;
+    const plotHeight = height - pad.top - pad.bottom;
+    const x = (i) => pad.left + (labels.length === 1 ? plotWidth / 2 : (i / (labels.length - 1)) * plotWidth);
+    const y = (v) => pad.top + (1 - (v - minValue) / (maxValue - minValue)) * plotHeight;
+
+    // 가로 눈금선과 값
+    ctx.strokeStyle = "#2a3240";
+    ctx.fillStyle = "#7b8494";
+    ctx.font = "11px sans-serif";
+    ctx.lineWidth = 1;
+    for (let i = 0; i <= 4; i++) {
+      const v = minValue + ((maxValue - minValue) * i) / 4;
+      ctx.beginPath();
+      ctx.moveTo(pad.left, y(v));
+      ctx.lineTo(pad.left + plotWidth, y(v));
+      ctx.stroke();
+      ctx.fillText(numberFormat.format(v), pad.left + plotWidth + 4, y(v) + 4);
+    }
+
+    // x축 처음/마지막 라벨
+    ctx.fillText(labels[0], pad.left, height - 4);
+    const last = labels[labels.length - 1];
+    ctx.fillText(last, pad.left + plotWidth - ctx.measureText(last).width, height - 4);
+
+    series.forEach((s) => {
+      ctx.strokeStyle = s.color;
+      ctx.lineWidth = 1.5;
+      ctx.beginPath();
+      let drawing = false;
+      s.values.forEach((v, i) => {
+        if (v === null || v === undefined) {
+          drawing = false;
+          return;
+        }
+        if (drawing) {
+          ctx.lineTo(x(i), y(v));
+        } else {
+          ctx.moveTo(x(i), y(v));
+          drawing = true;
+        }
+      });
+      ctx.stroke();
+    });
+  }
+
+  // ===== DOM 유틸 =====
+
+  function fillTable(id, rows, columns) {
+    const tbody = document.querySelector("#" + id + " tbody");
+    tbody.replaceChildren(
+      ...rows.map((row) => {
+        const tr = document.createElement("tr");
+        columns(row).forEach((value) => {
+          const td = document.createElement("td");
+          if (value instanceof Node) {
+            td.className = value.className;
+            td.textContent = value.textContent;
+          } else {
+            td.textContent = value;
+          }
+          tr.appendChild(td);
+        });
+        return tr;
+      })
+    );
+  }
+
+  function cell(value, className) {
+    const el = document.createElement("span");
+    el.textContent = value;
+    el.className = className || "";
+    return el;
+  }
+
+  function profitCell(value, suffix) {
+    return cell((value > 0 ? "+" : "") + numberFormat.format(value) + (suffix || ""), value > 0 ? "up" : value < 0 ? "down" : "");
+  }
+
+  function text(value) {
+    return cell(value);
+  }
+
+  function badge(label, on) {
+    return cell(label, "badge " + (on ? "on" : "off"));
+  }
+
+  function colored(label, color) {
+    const el = cell(label);
+    el.style.color = color;
+    return el;
+  }
+
+  function formatTime(value) {
+    const date = new Date(value);
+    return isNaN(date) ? "" : date.toLocaleString("ko-KR", { hour12: false });
+  }
+
+  // ===== 시작 =====
+
+  document.getElementById("api-key").value = apiKey;
+  document.getElementById("key-form").addEventListener("submit", (e) => {
+    e.preventDefault();
+    apiKey = document.getElementById("api-key").value.trim();
+    localStorage.setItem(KEY_STORAGE, apiKey);
+    refresh();
+    subscribeEvents();
+  });
+
+  refresh();
+  if (apiKey) {
+    subscribeEvents();
+  }
+})();
@@ -0,0 +1,22 @@
+// Package dashboard
+package dashboard
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+// assets는 대시보드 정적 파일입니다. 외부 CDN 없이 바이너리에 포함되어 배포됩니다
+//
+//go:embed assets
+var assets embed.FS
+
+// FileSystem은 대시보드 정적 파일 시스템을 반환합니다
+func FileSystem() http.FileSystem {
+	sub, err := fs.Sub(assets, "assets")
+	if err != nil {
+		panic(err)
+	}
+	return http.FS(sub)
+}
@@ -0,0 +1,127 @@
+// Package event
+package event
+
+import (
+	"go-trading-bot/internal/logger"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type Type string
+
+const (
+	SIGNAL_GENERATED Type = "signal.generated" // 전략 신호 생성
+	STAGE_CHANGED    Type = "stage.changed"    // 사이클 단계 변경
+	ORDER_PLACED     Type = "order.placed"     // 주문 접수
+	ORDER_FILLED     Type = "order.filled"     // 주문 체결
+	ORDER_REJECTED   Type = "order.rejected"   // 리스크 규칙 등으로 주문 거부
+	RISK_EXIT        Type = "risk.exit"        // 손절/익절 청산
+	CYCLE_STARTED    Type = "cycle.started"    // 분석 사이클 시작
+	CYCLE_FINISHED   Type = "cycle.finished"   // 분석 사이클 종료
+	ERROR            Type = "error"            // 오류
+)
+
+// Types는 발행되는 모든 이벤트 타입입니다
+var Types = []Type{SIGNAL_GENERATED, STAGE_CHANGED, ORDER_PLACED, ORDER_FILLED, ORDER_REJECTED, RISK_EXIT, CYCLE_STARTED, CYCLE_FINISHED, ERROR}
+
+// Event는 버스로 전달되는 이벤트입니다
+type Event struct {
+	ID     uint64
+	Type   Type
+	Market string // 마켓과 무관한 이벤트는 빈 문자열
+	Time   time.Time
+	Data   any
+}
+
+// Filter는 구독할 이벤트 조건입니다. 비어 있는 조건은 모두 허용합니다
+type Filter struct {
+	Markets map[string]bool
+	Types   map[Type]bool
+}
+
+func (f Filter) matches(e Event) bool {
+	if len(f.Types) > 0 && !f.Types[e.Type] {
+		return false
+	}
+	// 마켓과 무관한 이벤트(사이클 등)는 마켓 필터와 관계없이 전달합니다
+	if len(f.Markets) > 0 && e.Market != "" && !f.Markets[e.Market] {
+		return false
+	}
+	return true
+}
+
+// Subscription은 이벤트 구독입니다. 처리가 늦어 버퍼가 가득 차면 이벤트가 버려집니다
+type Subscription struct {
+	C       <-chan Event
+	ch      chan Event
+	filter  Filter
+	dropped atomic.Int64
+}
+
+// Dropped는 버퍼가 가득 차 버려진 이벤트 수를 반환합니다
+func (s *Subscription) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// Bus는 이벤트 발행/구독을 관리합니다. nil Bus에 Publish하면 아무 일도 하지 않습니다
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers map[*Subscription]struct{}
+	seq         atomic.Uint64
+}
+
+func NewBus() *Bus {
+	return &Bus{subscribers: make(map[*Subscription]struct{})}
+}
+
+// Publish는 이벤트를 조건에 맞는 모든 구독자에게 전달합니다. 발행자는 구독자 때문에 막히지 않습니다
+func (b *Bus) Publish(eventType Type, market string, data any) {
+	if b == nil {
+		return
+	}
+
+	e := Event{
+		ID:     b.seq.Add(1),
+		Type:   eventType,
+		Market: market,
+		Time:   time.Now(),
+		Data:   data,
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for s := range b.subscribers {
+		if !s.filter.matches(e) {
+			continue
+		}
+		select {
+		case s.ch <- e:
+		default:
+			if s.dropped.Add(1) == 1 {
+				logger.Log.Warnf("이벤트 구독자의 처리가 늦어 이벤트를 버립니다. (%v) 🟠", e.Type)
+			}
+		}
+	}
+}
+
+// Subscribe는 조건에 맞는 이벤트를 받는 구독을 생성합니다
+func (b *Bus) Subscribe(filter Filter, buffer int) *Subscription {
+	ch := make(chan Event, buffer)
+	s := &Subscription{C: ch, ch: ch, filter: filter}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers[s] = struct{}{}
+	return s
+}
+
+// Unsubscribe는 구독을 해제하고 채널을 닫습니다
+func (b *Bus) Unsubscribe(s *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, exists := b.subscribers[s]; exists {
+		delete(b.subscribers, s)
+		close(s.ch)
+	}
+}
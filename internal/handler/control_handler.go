@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"errors"
+	"go-trading-bot/internal/model"
+	"go-trading-bot/internal/service"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ControlService는 제어 API가 사용하는 TradingBot 기능입니다
+type ControlService interface {
+	GetBotStatus() model.BotStatus
+	Pause(actor string)
+	Resume(actor string)
+	TriggerCycle(actor string) error
+	ClosePosition(actor, market string) (*model.Order, error)
+	CloseAllPositions(actor string) ([]model.Order, error)
+	PlaceManualOrder(actor, market string, side model.SignalType, orderAmount float64) (*model.Order, error)
+	AddMarket(actor, asset string) (string, error)
+	RemoveMarket(actor, market string) error
+}
+
+// ActorKey는 요청 실행자 정보를 gin.Context에 저장하는 키입니다
+const ActorKey = "actor"
+
+type ControlHandler struct {
+	Control ControlService
+}
+
+// NewControlHandler는 새로운 ControlHandler 인스턴스를 생성합니다
+func NewControlHandler(control ControlService) *ControlHandler {
+	return &ControlHandler{
+		Control: control,
+	}
+}
+
+type orderRequest struct {
+	Market string  `json:"market" binding:"required"`
+	Side   string  `json:"side" binding:"required"`
+	Amount float64 `json:"amount"` // 매수 금액, 0이면 order-amount 사용
+}
+
+type marketRequest struct {
+	Market string `json:"market" binding:"required"` // BTC 또는 KRW-BTC
+}
+
+// GetStatus는 일시정지 여부, 거래 시간대, 사이클 상태를 반환합니다
+func (h *ControlHandler) GetStatus(c *gin.Context) {
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    h.Control.GetBotStatus(),
+	})
+}
+
+// Pause는 신규 진입을 일시정지합니다
+func (h *ControlHandler) Pause(c *gin.Context) {
+	h.Control.Pause(actor(c))
+	h.GetStatus(c)
+}
+
+// Resume은 신규 진입을 재개합니다
+func (h *ControlHandler) Resume(c *gin.Context) {
+	h.Control.Resume(actor(c))
+	h.GetStatus(c)
+}
+
+// Run은 분석 사이클을 즉시 실행합니다
+func (h *ControlHandler) Run(c *gin.Context) {
+	if err := h.Control.TriggerCycle(actor(c)); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(202, gin.H{
+		"success": true,
+		"message": "cycle triggered",
+	})
+}
+
+// ClosePosition은 특정 마켓의 포지션을 청산합니다
+// POST /api/v1/control/positions/KRW-BTC/close
+func (h *ControlHandler) ClosePosition(c *gin.Context) {
+	order, err := h.Control.ClosePosition(actor(c), c.Param("market"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    order,
+	})
+}
+
+// CloseAllPositions는 모든 포지션을 청산합니다
+func (h *ControlHandler) CloseAllPositions(c *gin.Context) {
+	orders, err := h.Control.CloseAllPositions(actor(c))
+	if err != nil && len(orders) == 0 {
+		respondError(c, err)
+		return
+	}
+	if err != nil {
+		c.JSON(500, gin.H{
+			"success": false,
+			"message": err.Error(),
+			"data":    orders,
+		})
+		return
+	}
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    orders,
+		"count":   len(orders),
+	})
+}
+
+// PlaceOrder는 수동 매수/매도 주문을 실행합니다
+// POST /api/v1/control/orders {"market": "KRW-BTC", "side": "BUY", "amount": 100000}
+func (h *ControlHandler) PlaceOrder(c *gin.Context) {
+	var req orderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	var side model.SignalType
+	switch strings.ToUpper(req.Side) {
+	case model.BUY.String():
+		side = model.BUY
+	case model.SELL.String():
+		side = model.SELL
+	default:
+		badRequest(c, "side must be BUY or SELL")
+		return
+	}
+	if req.Amount < 0 {
+		badRequest(c, "amount must not be negative")
+		return
+	}
+
+	order, err := h.Control.PlaceManualOrder(actor(c), req.Market, side, req.Amount)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    order,
+	})
+}
+
+// AddMarket은 분석 대상 마켓을 추가합니다
+// POST /api/v1/control/markets {"market": "XRP"}
+func (h *ControlHandler) AddMarket(c *gin.Context) {
+	var req marketRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	market, err := h.Control.AddMarket(actor(c), req.Market)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    market,
+	})
+}
+
+// RemoveMarket은 분석 대상 마켓을 제거합니다
+// DELETE /api/v1/control/markets/KRW-XRP
+func (h *ControlHandler) RemoveMarket(c *gin.Context) {
+	if err := h.Control.RemoveMarket(actor(c), c.Param("market")); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(200, gin.H{
+		"success": true,
+	})
+}
+
+func actor(c *gin.Context) string {
+	name := c.GetString(ActorKey)
+	if name == "" {
+		name = "api"
+	}
+	return name + "@" + c.ClientIP()
+}
+
+// respondError는 서비스 오류를 HTTP 상태 코드로 변환해 응답합니다
+func respondError(c *gin.Context, err error) {
+	status := 500
+	switch {
+	case errors.Is(err, service.ErrMarketNotFound), errors.Is(err, service.ErrNoPosition):
+		status = 404
+	case errors.Is(err, service.ErrMarketExists), errors.Is(err, service.ErrPositionExists), errors.Is(err, service.ErrCycleRunning):
+		status = 409
+	case errors.Is(err, service.ErrEntriesPaused), errors.Is(err, service.ErrOutsideTradingWindow),
+		errors.Is(err, service.ErrMaxPositions), errors.Is(err, service.ErrOrderAmountExceeded),
+		errors.Is(err, service.ErrInvalidOrder):
+		status = 422
+	}
+
+	c.JSON(status, gin.H{
+		"success": false,
+		"message": err.Error(),
+	})
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"fmt"
+	"go-trading-bot/internal/event"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	eventBufferSize   = 64
+	heartbeatInterval = 30 * time.Second
+)
+
+type EventHandler struct {
+	Events *event.Bus
+}
+
+// NewEventHandler는 새로운 EventHandler 인스턴스를 생성합니다
+func NewEventHandler(events *event.Bus) *EventHandler {
+	return &EventHandler{
+		Events: events,
+	}
+}
+
+// Stream은 신호, 주문, 사이클 이벤트를 Server-Sent Events로 전송합니다.
+// 이벤트 이름은 이벤트 타입(order.filled 등)이며, 연결 유지를 위해 주기적으로 heartbeat 주석을 보냅니다
+// GET /api/v1/events?market=KRW-BTC,KRW-ETH&type=order.filled,risk.exit
+func (h *EventHandler) Stream(c *gin.Context) {
+	filter, ok := parseEventFilter(c)
+	if !ok {
+		return
+	}
+
+	subscription := h.Events.Subscribe(filter, eventBufferSize)
+	defer h.Events.Unsubscribe(subscription)
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // nginx 버퍼링 비활성화
+
+	// 연결 직후 헤더를 바로 전송해 클라이언트가 구독 성공을 알 수 있도록 합니다
+	_, _ = io.WriteString(c.Writer, ": connected\n\n")
+	c.Writer.Flush()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case e, open := <-subscription.C:
+			if !open {
+				return false
+			}
+			c.SSEvent(string(e.Type), e)
+			return true
+		case <-heartbeat.C:
+			_, _ = io.WriteString(w, ": heartbeat\n\n")
+			return true
+		}
+	})
+}
+
+// parseEventFilter는 market, type 쿼리 파라미터(쉼표로 구분)를 파싱합니다
+func parseEventFilter(c *gin.Context) (event.Filter, bool) {
+	filter := event.Filter{}
+
+	if markets := splitQuery(c.Query("market")); len(markets) > 0 {
+		filter.Markets = make(map[string]bool, len(markets))
+		for _, market := range markets {
+			filter.Markets[strings.ToUpper(market)] = true
+		}
+	}
+
+	if types := splitQuery(c.Query("type")); len(types) > 0 {
+		filter.Types = make(map[event.Type]bool, len(types))
+		for _, t := range types {
+			eventType := event.Type(t)
+			if !slices.Contains(event.Types, eventType) {
+				badRequest(c, fmt.Sprintf("unknown event type %q", t))
+				return event.Filter{}, false
+			}
+			filter.Types[eventType] = true
+		}
+	}
+
+	return filter, true
+}
+
+func splitQuery(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"context"
+	"go-trading-bot/internal/model"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HealthService는 헬스 체크 API가 사용하는 TradingBot 기능입니다
+type HealthService interface {
+	Liveness() model.HealthReport
+	Readiness(ctx context.Context) model.HealthReport
+}
+
+type HealthHandler struct {
+	Health HealthService
+}
+
+// NewHealthHandler는 새로운 HealthHandler 인스턴스를 생성합니다
+func NewHealthHandler(health HealthService) *HealthHandler {
+	return &HealthHandler{
+		Health: health,
+	}
+}
+
+// Live는 프로세스가 살아 있는지 반환합니다. 실패하면 503으로 응답합니다
+func (h *HealthHandler) Live(c *gin.Context) {
+	respondHealth(c, h.Health.Liveness())
+}
+
+// Ready는 봇이 거래할 준비가 되었는지 항목별로 반환합니다. fail 항목이 있으면 503으로 응답합니다
+func (h *HealthHandler) Ready(c *gin.Context) {
+	respondHealth(c, h.Health.Readiness(c.Request.Context()))
+}
+
+func respondHealth(c *gin.Context, report model.HealthReport) {
+	status := 200
+	if !report.Healthy() {
+		status = 503
+	}
+	c.JSON(status, gin.H{
+		"success": report.Healthy(),
+		"data":    report,
+	})
+}
@@ -3,18 +3,47 @@ package handler
 
 import (
 	"fmt"
-	"go-trading-bot/internal/service"
+	"go-trading-bot/internal/analytics"
+	"go-trading-bot/internal/model"
+	"go-trading-bot/internal/scheduler"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+
+	defaultChartCount = 120
+	maxChartCount     = 150
+)
+
+// TradingBotService는 핸들러가 사용하는 TradingBot 기능입니다
+type TradingBotService interface {
+	GetLatestSignal(market string) model.Signal
+	GetAllLatestSignals() []model.Signal
+	GetCycleStatus() model.CycleStatus
+	GetValidateMarkets() []string
+	GetPositionSummaries() []model.PositionSummary
+	GetOrders(filter model.OrderFilter) []model.Order
+	GetPnLSummary(filter model.OrderFilter) model.PnLSummary
+	GetPerformanceReport(filter model.OrderFilter, capital float64) *analytics.Report
+	GetStrategyInfo() model.StrategyInfo
+	GetChart(market string, count int) (model.Chart, error)
+	ReloadConfig() ([]string, error)
+}
+
 // Handler는 API 핸들러에 필요한 의존성을 관리합니다
 type TradingBotHandler struct {
-	TradingBot *service.TradingBot
+	TradingBot TradingBotService
 }
 
 // NewHandler는 새로운 Handler 인스턴스를 생성합니다
-func NewHandler(tradingBot *service.TradingBot) *TradingBotHandler {
+func NewHandler(tradingBot TradingBotService) *TradingBotHandler {
 	return &TradingBotHandler{
 		TradingBot: tradingBot,
 	}
@@ -51,3 +80,231 @@ func (h *TradingBotHandler) GetSignal(c *gin.Context) {
 		"data":    signal,
 	})
 }
+
+// GetCycleStatus는 분석 사이클의 실행 메타데이터를 반환합니다
+func (h *TradingBotHandler) GetCycleStatus(c *gin.Context) {
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    h.TradingBot.GetCycleStatus(),
+	})
+}
+
+// GetMarkets는 검증된 마켓 목록을 반환합니다
+func (h *TradingBotHandler) GetMarkets(c *gin.Context) {
+	markets := h.TradingBot.GetValidateMarkets()
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    markets,
+		"count":   len(markets),
+	})
+}
+
+// GetPositions는 보유 포지션을 평가손익과 함께 반환합니다
+// GET /api/v1/positions?page=1&size=20
+func (h *TradingBotHandler) GetPositions(c *gin.Context) {
+	page, size, ok := parsePage(c)
+	if !ok {
+		return
+	}
+
+	positions := h.TradingBot.GetPositionSummaries()
+	respondPage(c, positions, page, size)
+}
+
+// GetOrders는 주문 기록을 최신순으로 반환합니다
+// GET /api/v1/orders?market=KRW-BTC&side=BUY&from=2025-01-01&to=2025-02-01&page=1&size=20
+func (h *TradingBotHandler) GetOrders(c *gin.Context) {
+	page, size, ok := parsePage(c)
+	if !ok {
+		return
+	}
+	filter, ok := parseOrderFilter(c)
+	if !ok {
+		return
+	}
+
+	orders := h.TradingBot.GetOrders(filter)
+	respondPage(c, orders, page, size)
+}
+
+// GetPnL은 실현 손익을 마켓별, 일자별로 집계해 반환합니다
+// GET /api/v1/pnl?market=KRW-BTC&from=2025-01-01&to=2025-02-01
+func (h *TradingBotHandler) GetPnL(c *gin.Context) {
+	filter, ok := parseOrderFilter(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    h.TradingBot.GetPnLSummary(filter),
+	})
+}
+
+// GetAnalytics는 주문 기록으로 계산한 성과 지표와 마켓별/진입 단계별 집계를 반환합니다.
+// format이 markdown 또는 html이면 보고서 문서를 그대로 응답합니다
+// GET /api/v1/analytics?market=KRW-BTC&from=2025-01-01&to=2025-02-01&capital=1000000&format=json
+func (h *TradingBotHandler) GetAnalytics(c *gin.Context) {
+	filter, ok := parseOrderFilter(c)
+	if !ok {
+		return
+	}
+	capital, err := strconv.ParseFloat(c.DefaultQuery("capital", "0"), 64)
+	if err != nil || capital < 0 {
+		badRequest(c, "capital must be a non-negative number")
+		return
+	}
+	format := strings.ToLower(c.DefaultQuery("format", analytics.FORMAT_JSON))
+	if !slices.Contains(analytics.Formats(), format) {
+		badRequest(c, "format must be one of "+strings.Join(analytics.Formats(), ", "))
+		return
+	}
+
+	report := h.TradingBot.GetPerformanceReport(filter, capital)
+	switch format {
+	case analytics.FORMAT_MARKDOWN:
+		c.Header("Content-Type", "text/markdown; charset=utf-8")
+		_ = report.WriteMarkdown(c.Writer)
+	case analytics.FORMAT_HTML:
+		c.Header("Content-Type", "text/html; charset=utf-8")
+		_ = report.WriteHTML(c.Writer)
+	default:
+		c.JSON(200, gin.H{
+			"success": true,
+			"data":    report,
+		})
+	}
+}
+
+// GetStrategy는 현재 사용 중인 전략과 파라미터를 반환합니다
+func (h *TradingBotHandler) GetStrategy(c *gin.Context) {
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    h.TradingBot.GetStrategyInfo(),
+	})
+}
+
+// GetChart는 마켓의 최근 캔들과 전략 이동평균선을 반환합니다
+// GET /api/v1/chart?market=KRW-BTC&count=120
+func (h *TradingBotHandler) GetChart(c *gin.Context) {
+	market := strings.ToUpper(c.Query("market"))
+	if market == "" {
+		badRequest(c, "market is required")
+		return
+	}
+
+	count, err := strconv.Atoi(c.DefaultQuery("count", strconv.Itoa(defaultChartCount)))
+	if err != nil || count < 1 || count > maxChartCount {
+		badRequest(c, fmt.Sprintf("count must be between 1 and %d", maxChartCount))
+		return
+	}
+
+	chart, err := h.TradingBot.GetChart(market, count)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    chart,
+	})
+}
+
+// ReloadConfig는 application.json을 다시 읽어 적용하고 변경 내역을 반환합니다
+func (h *TradingBotHandler) ReloadConfig(c *gin.Context) {
+	changes, err := h.TradingBot.ReloadConfig()
+	if err != nil {
+		c.JSON(400, gin.H{
+			"success": false,
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    changes,
+		"count":   len(changes),
+	})
+}
+
+// parsePage는 page(1부터 시작), size 쿼리 파라미터를 파싱합니다
+func parsePage(c *gin.Context) (int, int, bool) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		badRequest(c, "page must be a positive integer")
+		return 0, 0, false
+	}
+
+	size, err := strconv.Atoi(c.DefaultQuery("size", strconv.Itoa(defaultPageSize)))
+	if err != nil || size < 1 || size > maxPageSize {
+		badRequest(c, fmt.Sprintf("size must be between 1 and %d", maxPageSize))
+		return 0, 0, false
+	}
+
+	return page, size, true
+}
+
+// respondPage는 items의 해당 페이지를 {success, data, count, page, size, total} 형식으로 응답합니다
+func respondPage[T any](c *gin.Context, items []T, page, size int) {
+	total := len(items)
+	start := min((page-1)*size, total)
+	end := min(start+size, total)
+	data := items[start:end]
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    data,
+		"count":   len(data),
+		"page":    page,
+		"size":    size,
+		"total":   total,
+	})
+}
+
+// parseOrderFilter는 market, side, from, to 쿼리 파라미터를 파싱합니다.
+// from/to는 2006-01-02(KST) 또는 RFC3339 형식이며, to 일자는 해당 일을 포함합니다
+func parseOrderFilter(c *gin.Context) (model.OrderFilter, bool) {
+	filter := model.OrderFilter{
+		Market: c.Query("market"),
+		Side:   strings.ToUpper(c.Query("side")),
+	}
+
+	if filter.Side != "" && filter.Side != model.BUY.String() && filter.Side != model.SELL.String() {
+		badRequest(c, "side must be BUY or SELL")
+		return filter, false
+	}
+
+	var err error
+	if filter.From, err = parseTime(c.Query("from"), false); err != nil {
+		badRequest(c, "invalid from: "+err.Error())
+		return filter, false
+	}
+	if filter.To, err = parseTime(c.Query("to"), true); err != nil {
+		badRequest(c, "invalid to: "+err.Error())
+		return filter, false
+	}
+
+	return filter, true
+}
+
+func parseTime(value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, scheduler.KST); err == nil {
+		if endOfDay {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+func badRequest(c *gin.Context, message string) {
+	c.JSON(400, gin.H{
+		"success": false,
+		"message": message,
+	})
+}
@@ -0,0 +1,330 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-trading-bot/internal/analytics"
+	"go-trading-bot/internal/model"
+	"go-trading-bot/internal/scheduler"
+	"go-trading-bot/internal/service"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakeTradingBot은 고정된 데이터를 돌려주고 마지막으로 받은 필터를 기록하는 TradingBotService입니다
+type fakeTradingBot struct {
+	positions  []model.PositionSummary
+	orders     []model.Order
+	pnl        model.PnLSummary
+	markets    []string
+	cycle      model.CycleStatus
+	strategy   model.StrategyInfo
+	chartErr   error
+	lastFilter model.OrderFilter
+}
+
+func (f *fakeTradingBot) GetLatestSignal(market string) model.Signal { return model.Signal{} }
+func (f *fakeTradingBot) GetAllLatestSignals() []model.Signal        { return nil }
+func (f *fakeTradingBot) GetCycleStatus() model.CycleStatus          { return f.cycle }
+func (f *fakeTradingBot) GetValidateMarkets() []string               { return f.markets }
+func (f *fakeTradingBot) GetPositionSummaries() []model.PositionSummary {
+	return f.positions
+}
+func (f *fakeTradingBot) GetOrders(filter model.OrderFilter) []model.Order {
+	f.lastFilter = filter
+	return f.orders
+}
+func (f *fakeTradingBot) GetPnLSummary(filter model.OrderFilter) model.PnLSummary {
+	f.lastFilter = filter
+	return f.pnl
+}
+func (f *fakeTradingBot) GetPerformanceReport(filter model.OrderFilter, capital float64) *analytics.Report {
+	f.lastFilter = filter
+	return analytics.Analyze("test", capital, nil, nil)
+}
+func (f *fakeTradingBot) GetStrategyInfo() model.StrategyInfo { return f.strategy }
+func (f *fakeTradingBot) GetChart(market string, count int) (model.Chart, error) {
+	return model.Chart{}, f.chartErr
+}
+func (f *fakeTradingBot) ReloadConfig() ([]string, error) { return nil, nil }
+
+type pageResponse struct {
+	Success bool              `json:"success"`
+	Message string            `json:"message"`
+	Data    []json.RawMessage `json:"data"`
+	Count   int               `json:"count"`
+	Page    int               `json:"page"`
+	Size    int               `json:"size"`
+	Total   int               `json:"total"`
+}
+
+func newTestRouter(bot TradingBotService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	h := NewHandler(bot)
+	router.GET("/positions", h.GetPositions)
+	router.GET("/orders", h.GetOrders)
+	router.GET("/pnl", h.GetPnL)
+	router.GET("/markets", h.GetMarkets)
+	router.GET("/strategy", h.GetStrategy)
+	router.GET("/cycle", h.GetCycleStatus)
+	router.GET("/chart", h.GetChart)
+	return router
+}
+
+func get(t *testing.T, router *gin.Engine, target string) (int, pageResponse) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+
+	var body pageResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("GET %s: invalid JSON %q: %v", target, w.Body.String(), err)
+	}
+	return w.Code, body
+}
+
+// getData는 target의 data를 v로 읽고 응답 코드와 count를 반환합니다
+func getData(t *testing.T, router *gin.Engine, target string, v any) (int, int) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+
+	var body struct {
+		Success bool            `json:"success"`
+		Data    json.RawMessage `json:"data"`
+		Count   int             `json:"count"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("GET %s: invalid JSON %q: %v", target, w.Body.String(), err)
+	}
+	if w.Code == 200 && (!body.Success || json.Unmarshal(body.Data, v) != nil) {
+		t.Fatalf("GET %s: unexpected body %s", target, w.Body.String())
+	}
+	return w.Code, body.Count
+}
+
+func positions(n int) []model.PositionSummary {
+	items := make([]model.PositionSummary, n)
+	for i := range items {
+		items[i].Market = fmt.Sprintf("KRW-%03d", i)
+	}
+	return items
+}
+
+func TestParsePageBounds(t *testing.T) {
+	router := newTestRouter(&fakeTradingBot{positions: positions(3)})
+
+	tests := []struct {
+		query string
+		code  int
+		page  int
+		size  int
+	}{
+		{"", 200, 1, defaultPageSize},
+		{"?page=2&size=1", 200, 2, 1},
+		{fmt.Sprintf("?size=%d", maxPageSize), 200, 1, maxPageSize},
+		{"?page=0", 400, 0, 0},
+		{"?page=-1", 400, 0, 0},
+		{"?page=abc", 400, 0, 0},
+		{"?size=0", 400, 0, 0},
+		{fmt.Sprintf("?size=%d", maxPageSize+1), 400, 0, 0},
+		{"?size=ten", 400, 0, 0},
+	}
+	for _, tt := range tests {
+		code, body := get(t, router, "/positions"+tt.query)
+		if code != tt.code {
+			t.Errorf("GET /positions%s = %d, want %d (%s)", tt.query, code, tt.code, body.Message)
+			continue
+		}
+		if code != 200 {
+			if body.Success || body.Message == "" {
+				t.Errorf("GET /positions%s: want failure envelope with message, got %+v", tt.query, body)
+			}
+			continue
+		}
+		if body.Page != tt.page || body.Size != tt.size {
+			t.Errorf("GET /positions%s: page %d size %d, want %d %d", tt.query, body.Page, body.Size, tt.page, tt.size)
+		}
+	}
+}
+
+func TestRespondPagePastLastPage(t *testing.T) {
+	router := newTestRouter(&fakeTradingBot{positions: positions(5)})
+
+	tests := []struct {
+		query string
+		count int
+	}{
+		{"?page=1&size=2", 2},
+		{"?page=3&size=2", 1},
+		{"?page=4&size=2", 0},
+		{"?page=1000&size=100", 0},
+	}
+	for _, tt := range tests {
+		code, body := get(t, router, "/positions"+tt.query)
+		if code != 200 || !body.Success {
+			t.Fatalf("GET /positions%s = %d %+v, want 200", tt.query, code, body)
+		}
+		if body.Count != tt.count || len(body.Data) != tt.count || body.Total != 5 {
+			t.Errorf("GET /positions%s: count %d, %d items, total %d, want %d items of 5", tt.query, body.Count, len(body.Data), body.Total, tt.count)
+		}
+		if body.Data == nil {
+			t.Errorf("GET /positions%s: data is null, want an empty array", tt.query)
+		}
+	}
+}
+
+func TestParseTimeEndOfDay(t *testing.T) {
+	bot := &fakeTradingBot{}
+	router := newTestRouter(bot)
+
+	code, body := get(t, router, "/orders?from=2025-01-01&to=2025-01-31")
+	if code != 200 {
+		t.Fatalf("GET /orders = %d (%s), want 200", code, body.Message)
+	}
+	wantFrom := time.Date(2025, 1, 1, 0, 0, 0, 0, scheduler.KST)
+	wantTo := time.Date(2025, 2, 1, 0, 0, 0, 0, scheduler.KST)
+	if !bot.lastFilter.From.Equal(wantFrom) {
+		t.Errorf("from = %v, want %v", bot.lastFilter.From, wantFrom)
+	}
+	if !bot.lastFilter.To.Equal(wantTo) {
+		t.Errorf("to = %v, want the start of the next day %v", bot.lastFilter.To, wantTo)
+	}
+
+	// RFC3339 시각은 그대로 사용합니다
+	code, _ = get(t, router, "/orders?to=2025-01-31T12:00:00Z")
+	if code != 200 {
+		t.Fatalf("GET /orders with RFC3339 = %d, want 200", code)
+	}
+	if want := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC); !bot.lastFilter.To.Equal(want) {
+		t.Errorf("to = %v, want %v", bot.lastFilter.To, want)
+	}
+
+	for _, query := range []string{"?from=2025-13-01", "?to=yesterday", "?side=HOLD"} {
+		if code, _ := get(t, router, "/orders"+query); code != 400 {
+			t.Errorf("GET /orders%s = %d, want 400", query, code)
+		}
+	}
+}
+
+func TestRespondErrorStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{service.ErrMarketNotFound, 404},
+		{service.ErrNoPosition, 404},
+		{service.ErrMarketExists, 409},
+		{service.ErrPositionExists, 409},
+		{service.ErrCycleRunning, 409},
+		{service.ErrEntriesPaused, 422},
+		{service.ErrOutsideTradingWindow, 422},
+		{service.ErrMaxPositions, 422},
+		{service.ErrOrderAmountExceeded, 422},
+		{service.ErrInvalidOrder, 422},
+		{fmt.Errorf("close KRW-BTC: %w", service.ErrNoPosition), 404},
+		{errors.New("upbit unavailable"), 500},
+	}
+	for _, tt := range tests {
+		router := newTestRouter(&fakeTradingBot{chartErr: tt.err})
+		code, body := get(t, router, "/chart?market=KRW-BTC")
+		if code != tt.code {
+			t.Errorf("%v: status %d, want %d", tt.err, code, tt.code)
+		}
+		if body.Success || body.Message != tt.err.Error() {
+			t.Errorf("%v: body %+v, want failure with the error message", tt.err, body)
+		}
+	}
+}
+
+func TestGetPnL(t *testing.T) {
+	summary := model.PnLSummary{
+		TotalProfit: -50000,
+		TradeCount:  3,
+		WinCount:    2,
+		ByMarket: []model.PnLEntry{
+			{Key: "KRW-BTC", Profit: 150000, TradeCount: 2, WinCount: 2},
+			{Key: "KRW-ETH", Profit: -200000, TradeCount: 1},
+		},
+		ByDay: []model.PnLEntry{
+			{Key: "2025-01-06", Profit: -100000, TradeCount: 2, WinCount: 1},
+			{Key: "2025-01-07", Profit: 50000, TradeCount: 1, WinCount: 1},
+		},
+	}
+	bot := &fakeTradingBot{pnl: summary}
+	router := newTestRouter(bot)
+
+	var got model.PnLSummary
+	if code, _ := getData(t, router, "/pnl?market=KRW-BTC&from=2025-01-06&to=2025-01-07", &got); code != 200 {
+		t.Fatalf("GET /pnl = %d, want 200", code)
+	}
+	if !reflect.DeepEqual(got, summary) {
+		t.Errorf("pnl = %+v, want %+v", got, summary)
+	}
+	want := model.OrderFilter{
+		Market: "KRW-BTC",
+		From:   time.Date(2025, 1, 6, 0, 0, 0, 0, scheduler.KST),
+		To:     time.Date(2025, 1, 8, 0, 0, 0, 0, scheduler.KST),
+	}
+	if bot.lastFilter.Market != want.Market || !bot.lastFilter.From.Equal(want.From) || !bot.lastFilter.To.Equal(want.To) {
+		t.Errorf("filter = %+v, want %+v", bot.lastFilter, want)
+	}
+
+	if code, _ := get(t, router, "/pnl?from=2025-01-32"); code != 400 {
+		t.Errorf("GET /pnl with an invalid date = %d, want 400", code)
+	}
+}
+
+func TestGetMarkets(t *testing.T) {
+	router := newTestRouter(&fakeTradingBot{markets: []string{"KRW-BTC", "KRW-ETH"}})
+
+	var got []string
+	code, count := getData(t, router, "/markets", &got)
+	if code != 200 || count != 2 || !reflect.DeepEqual(got, []string{"KRW-BTC", "KRW-ETH"}) {
+		t.Errorf("GET /markets = %d %v (count %d), want both markets", code, got, count)
+	}
+}
+
+func TestGetStrategy(t *testing.T) {
+	info := model.StrategyInfo{
+		Name:                "moving-average-cross",
+		RequiredCandleCount: 5,
+		Parameters:          map[string]any{"short-period": float64(2), "long-period": float64(4)},
+		CandleCategory:      "minutes",
+		CandleUnit:          240,
+		ClosedCandleOnly:    true,
+	}
+	router := newTestRouter(&fakeTradingBot{strategy: info})
+
+	var got model.StrategyInfo
+	if code, _ := getData(t, router, "/strategy", &got); code != 200 || !reflect.DeepEqual(got, info) {
+		t.Errorf("GET /strategy = %d %+v, want %+v", code, got, info)
+	}
+}
+
+func TestGetCycleStatus(t *testing.T) {
+	started := time.Date(2025, 1, 6, 1, 0, 0, 0, time.UTC)
+	status := model.CycleStatus{
+		CycleCount:   7,
+		StartedAt:    started,
+		EndedAt:      started.Add(3 * time.Second),
+		Duration:     3 * time.Second,
+		SkippedTicks: 1,
+		LastError:    "upbit unavailable",
+		LastErrorAt:  started.Add(3 * time.Second),
+		LastSuccess:  started.Add(-4 * time.Hour),
+	}
+	router := newTestRouter(&fakeTradingBot{cycle: status})
+
+	var got model.CycleStatus
+	if code, _ := getData(t, router, "/cycle", &got); code != 200 || !reflect.DeepEqual(got, status) {
+		t.Errorf("GET /cycle = %d %+v, want %+v", code, got, status)
+	}
+}
@@ -0,0 +1,116 @@
+package i18n
+
+import (
+	"go-trading-bot/internal/model"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// reasonText는 사유 코드의 언어별 문장입니다. 문장은 params 순서대로 인자를 받는 printf 형식입니다
+type reasonText struct {
+	params []string
+	ko     string
+	en     string
+}
+
+var reasons = map[string]reasonText{
+	model.REASON_GOLDEN_CROSS: {
+		params: []string{"ShortPeriod", "ShortMA", "LongPeriod", "LongMA"},
+		ko:     "▲ 골든 크로스 발생 -> MA%[1]d(%.2[2]f)이 MA%[3]d(%.2[4]f)를 상향 돌파",
+		en:     "▲ Golden cross -> MA%[1]d(%.2[2]f) crossed above MA%[3]d(%.2[4]f)",
+	},
+	model.REASON_DEAD_CROSS: {
+		params: []string{"ShortPeriod", "ShortMA", "LongPeriod", "LongMA"},
+		ko:     "▼ 데드 크로스 발생 -> MA%[1]d(%.2[2]f)이 MA%[3]d(%.2[4]f)를 하향 돌파",
+		en:     "▼ Dead cross -> MA%[1]d(%.2[2]f) crossed below MA%[3]d(%.2[4]f)",
+	},
+	model.REASON_NO_CROSS: {
+		ko: "이동평균선 교차 없음 - 관망",
+		en: "No moving average cross - hold",
+	},
+	model.REASON_STAGE_0: {
+		ko: "알 수 없는 단계",
+		en: "Unknown stage",
+	},
+	model.REASON_STAGE_1: {
+		ko: "안정 상승기, 단/중/장 배치",
+		en: "Stable uptrend, short/medium/long order",
+	},
+	model.REASON_STAGE_1_BUY: {
+		ko: "안정 상승기, 단/중/장 배치(매수 신호📈)",
+		en: "Stable uptrend, short/medium/long order (buy signal📈)",
+	},
+	model.REASON_STAGE_2: {
+		ko: "데드크로스, 중/단/장 배치",
+		en: "Dead cross, medium/short/long order",
+	},
+	model.REASON_STAGE_3: {
+		ko: "본격 하락기, 중/장/단 배치(매도 신호📉)",
+		en: "Full downtrend, medium/long/short order (sell signal📉)",
+	},
+	model.REASON_STAGE_4: {
+		ko: "안정 하락기, 장/중/단 배치",
+		en: "Stable downtrend, long/medium/short order",
+	},
+	model.REASON_STAGE_4_SHORT: {
+		ko: "안정 하락기, 장/중/단 배치(Short 진입)",
+		en: "Stable downtrend, long/medium/short order (short entry)",
+	},
+	model.REASON_STAGE_5: {
+		ko: "골든크로스, 장/단/중 배치",
+		en: "Golden cross, long/short/medium order",
+	},
+	model.REASON_STAGE_6: {
+		ko: "본격 상승기, 단/장/중 배치(Short 청산)",
+		en: "Full uptrend, short/long/medium order (short exit)",
+	},
+	model.REASON_NOT_ENOUGH_CANDLES: {
+		params: []string{"Count", "Required"},
+		ko:     "캔들 부족(%[1]d/%[2]d개) - 관망",
+		en:     "Not enough candles (%[1]d/%[2]d) - hold",
+	},
+	model.REASON_INVALID_CANDLE: {
+		params: []string{"Index", "Price"},
+		ko:     "올바르지 않은 캔들 가격(%[1]d번째: %[2]v) - 관망",
+		en:     "Invalid candle price (index %[1]d: %[2]v) - hold",
+	},
+}
+
+// labels는 템플릿의 t 함수와 신호 설명에 쓰는 문구입니다. 키는 영어 원문이며 한국어 번역만 등록합니다
+var labels = map[string]string{
+	"Buy signal":       "매수 신호",
+	"Sell signal":      "매도 신호",
+	"Hold signal":      "홀드 신호",
+	"Unknown signal":   "알 수 없는 신호",
+	"Current price":    "현재가",
+	"Cycle stage":      "사이클 단계",
+	"Normal progress":  "정상 진행",
+	"Reversal":         "역방향 전환",
+	"Stage maintained": "단계 유지",
+	"Details":          "상세",
+	"Strategy":         "전략",
+	"Time":             "시각",
+	"Position":         "포지션 정보",
+	"Status":           "상태",
+	"Holding":          "보유중",
+	"None":             "없음",
+	"Quantity":         "수량",
+	"Entry price":      "진입가",
+	"Profit":           "수익",
+
+	"📈 Buy signal - %s":  "📈 매수 신호 - %s",
+	"📉 Sell signal - %s": "📉 매도 신호 - %s",
+	"⏸️ Hold - %s":       "⏸️ 관망 - %s",
+}
+
+func init() {
+	for code, text := range reasons {
+		_ = message.SetString(language.Korean, code, text.ko)
+		_ = message.SetString(language.English, code, text.en)
+	}
+	for key, ko := range labels {
+		_ = message.SetString(language.Korean, key, ko)
+		_ = message.SetString(language.English, key, key)
+	}
+}
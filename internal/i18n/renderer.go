@@ -0,0 +1,163 @@
+// Package i18n는 알림 메시지를 언어별 카탈로그(golang.org/x/text/message)와 text/template 템플릿으로 만듭니다
+package i18n
+
+import (
+	"embed"
+	"fmt"
+	"go-trading-bot/internal/model"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"text/template"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// 지원하는 알림 언어
+const (
+	LANG_KO = "ko"
+	LANG_EN = "en"
+)
+
+// Languages는 ALERT_LANGUAGE에 사용할 수 있는 값입니다
+var Languages = []string{LANG_KO, LANG_EN}
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var defaultRenderer atomic.Pointer[Renderer]
+
+func init() {
+	renderer, err := NewRenderer(language.Korean, "")
+	if err != nil {
+		panic(err)
+	}
+	defaultRenderer.Store(renderer)
+}
+
+// Default는 기본 Renderer를 반환합니다. 전략의 신호 설명과 알림 메시지에 사용합니다
+func Default() *Renderer {
+	return defaultRenderer.Load()
+}
+
+// SetDefault는 기본 Renderer를 바꿉니다
+func SetDefault(renderer *Renderer) {
+	defaultRenderer.Store(renderer)
+}
+
+// ParseLanguage는 ALERT_LANGUAGE 값을 언어 태그로 변환합니다
+func ParseLanguage(lang string) (language.Tag, error) {
+	switch strings.ToLower(strings.TrimSpace(lang)) {
+	case "", LANG_KO:
+		return language.Korean, nil
+	case LANG_EN:
+		return language.English, nil
+	default:
+		return language.Und, fmt.Errorf("unsupported language %q (supported: %s)", lang, strings.Join(Languages, ", "))
+	}
+}
+
+// Renderer는 한 언어로 알림 메시지와 신호 설명을 만듭니다
+type Renderer struct {
+	printer   *message.Printer
+	templates *template.Template
+}
+
+// NewRenderer는 내장 템플릿을 읽고, dir에 같은 이름의 *.tmpl 파일이 있으면 그 파일로 덮어씁니다
+func NewRenderer(lang language.Tag, dir string) (*Renderer, error) {
+	r := &Renderer{printer: message.NewPrinter(lang)}
+
+	templates, err := template.New("").Funcs(r.funcs()).ParseFS(templateFS, "templates/*.tmpl")
+	if err != nil {
+		return nil, err
+	}
+	if dir != "" {
+		files, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+		if err != nil {
+			return nil, err
+		}
+		if len(files) == 0 {
+			if _, err := os.Stat(dir); err != nil {
+				return nil, fmt.Errorf("template dir: %w", err)
+			}
+		} else if templates, err = templates.ParseFiles(files...); err != nil {
+			return nil, err
+		}
+	}
+
+	r.templates = templates
+	return r, nil
+}
+
+// Render는 name.tmpl 템플릿으로 메시지를 만듭니다
+func (r *Renderer) Render(name string, data any) (string, error) {
+	var b strings.Builder
+	if err := r.templates.ExecuteTemplate(&b, name+".tmpl", data); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(b.String()), nil
+}
+
+// Printer는 언어별 숫자 형식과 카탈로그를 적용하는 Printer를 반환합니다
+func (r *Renderer) Printer() *message.Printer {
+	return r.printer
+}
+
+// Reason은 사유 코드와 파라미터로 문장을 만듭니다. 카탈로그에 없는 코드는 코드 그대로 반환합니다
+func (r *Renderer) Reason(reason model.Reason) string {
+	text, exists := reasons[reason.Code]
+	if !exists {
+		return reason.Code
+	}
+	args := make([]any, 0, len(text.params))
+	for _, name := range text.params {
+		args = append(args, reason.Params[name])
+	}
+	return r.printer.Sprintf(reason.Code, args...)
+}
+
+// Stage는 사이클 단계 설명을 만듭니다. 사유 코드가 없으면 전략이 만든 설명을 그대로 사용합니다
+func (r *Renderer) Stage(stage model.Stage) string {
+	if stage.Reason.Code == "" {
+		return stage.Description
+	}
+	return r.Reason(stage.Reason)
+}
+
+// Describe는 신호 설명을 만듭니다. 사이클 전략처럼 단계가 있는 신호는 신호 종류를 앞에 붙입니다
+func (r *Renderer) Describe(signal model.Signal) string {
+	if signal.Reason.Code == "" {
+		return signal.Description
+	}
+	description := r.Reason(signal.Reason)
+	if signal.Stage == nil {
+		return description
+	}
+
+	switch signal.Type {
+	case model.BUY:
+		return r.printer.Sprintf("📈 Buy signal - %s", description)
+	case model.SELL:
+		return r.printer.Sprintf("📉 Sell signal - %s", description)
+	default:
+		return r.printer.Sprintf("⏸️ Hold - %s", description)
+	}
+}
+
+func (r *Renderer) funcs() template.FuncMap {
+	return template.FuncMap{
+		"t": func(key string, args ...any) string {
+			return r.printer.Sprintf(key, args...)
+		},
+		"price": func(price float64) string {
+			return r.printer.Sprintf("%.0f", price)
+		},
+		"profit": func(action model.Action) float64 {
+			return (action.Signal.CurrentPrice - action.Position.EntryPrice) * action.Position.Quantity
+		},
+		"describe": r.Describe,
+		"stage":    r.Stage,
+	}
+}
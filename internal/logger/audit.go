@@ -0,0 +1,23 @@
+package logger
+
+import (
+	"github.com/sirupsen/logrus"
+)
+
+// AUDIT_FILE은 로그 파일과 같은 디렉터리에 남기는 감사 로그 파일 이름입니다
+const AUDIT_FILE = "audit.log"
+
+// AuditLog는 제어 명령 감사 기록을 JSON 라인으로 AUDIT_FILE에 남깁니다. Configure 전에는 기록하지 않습니다
+var AuditLog = newSideLogger()
+
+// Audit은 제어 명령 실행 기록을 감사 로그와 일반 로그에 남깁니다
+func Audit(actor, action string, fields logrus.Fields, err error) {
+	entry := AuditLog.WithFields(fields).WithField("actor", actor).WithField("action", action)
+	if err != nil {
+		entry.WithField("result", "failed").WithField("error", err.Error()).Warn("control action")
+		Log.Warnf("[AUDIT] %v -> %v %v 실패: %v 🟠", actor, action, fields, err)
+		return
+	}
+	entry.WithField("result", "ok").Info("control action")
+	Log.Infof("[AUDIT] %v -> %v %v", actor, action, fields)
+}
@@ -0,0 +1,59 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+// 로그 문맥 필드. 한 사이클의 로그는 cycle_id로 모아 볼 수 있습니다
+const (
+	FIELD_CYCLE_ID = "cycle_id"
+	FIELD_MARKET   = "market"
+	FIELD_STRATEGY = "strategy"
+	FIELD_ORDER_ID = "order_id"
+)
+
+type fieldsKey struct{}
+
+type loggerKey struct{}
+
+// WithLogger는 FromContext가 전역 Log 대신 l을 사용하도록 한 context를 반환합니다
+func WithLogger(ctx context.Context, l *logrus.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+// WithFields는 ctx의 로그 필드에 fields를 더한 context를 반환합니다
+func WithFields(ctx context.Context, fields logrus.Fields) context.Context {
+	merged := make(logrus.Fields, len(fields))
+	if parent, ok := ctx.Value(fieldsKey{}).(logrus.Fields); ok {
+		for k, v := range parent {
+			merged[k] = v
+		}
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, fieldsKey{}, merged)
+}
+
+// WithField는 ctx의 로그 필드에 key=value를 더한 context를 반환합니다
+func WithField(ctx context.Context, key string, value any) context.Context {
+	return WithFields(ctx, logrus.Fields{key: value})
+}
+
+// FromContext는 ctx의 로그 필드를 붙인 로그 엔트리를 반환합니다
+func FromContext(ctx context.Context) *logrus.Entry {
+	if ctx == nil {
+		return logrus.NewEntry(Log)
+	}
+
+	l := Log
+	if injected, ok := ctx.Value(loggerKey{}).(*logrus.Logger); ok {
+		l = injected
+	}
+	if fields, ok := ctx.Value(fieldsKey{}).(logrus.Fields); ok {
+		return l.WithFields(fields)
+	}
+	return logrus.NewEntry(l)
+}
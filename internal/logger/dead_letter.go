@@ -0,0 +1,17 @@
+package logger
+
+import (
+	"github.com/sirupsen/logrus"
+)
+
+// DEAD_LETTER_FILE은 로그 파일과 같은 디렉터리에 남기는 dead-letter 로그 파일 이름입니다
+const DEAD_LETTER_FILE = "dead-letter.log"
+
+// DeadLetterLog는 재시도 후에도 전송하지 못한 알림을 JSON 라인으로 DEAD_LETTER_FILE에 남깁니다. Configure 전에는 기록하지 않습니다
+var DeadLetterLog = newSideLogger()
+
+// DeadLetter는 전송에 실패한 알림을 dead-letter 로그와 일반 로그에 남깁니다
+func DeadLetter(sink string, fields logrus.Fields, err error) {
+	DeadLetterLog.WithFields(fields).WithField("sink", sink).WithField("error", err.Error()).Error("notification dropped")
+	Log.Errorf("[%v] 알림을 전송하지 못해 dead-letter 로그에 기록합니다: %v 🔴", sink, err)
+}
@@ -13,42 +13,156 @@ import (
 	"gopkg.in/natefinch/lumberjack.v2"
 )
 
-var Log = InitLogger("dev")
+// 로그 형식
+const (
+	FORMAT_TEXT = "text"
+	FORMAT_JSON = "json"
+)
+
+// Formats는 LOG_FORMAT에 사용할 수 있는 값입니다
+var Formats = []string{FORMAT_TEXT, FORMAT_JSON}
+
+// Options는 로그 출력 설정입니다
+type Options struct {
+	Env        string // production이면 텍스트 로그의 색상을 끕니다
+	Format     string // text | json
+	Level      string // trace, debug, info, warn, error
+	File       string // 로그 파일 경로, 비어 있으면 stdout에만 출력
+	MaxSize    int    // 파일 최대 크기(MB), 넘으면 새 파일로 교체
+	MaxBackups int    // 보관할 이전 파일 수
+	MaxAge     int    // 이전 파일 보관 기간(일)
+	Compress   bool   // 이전 파일 gzip 압축 여부
+}
+
+// DefaultOptions는 잘못된 로그 설정 대신 사용하는 기본 로그 설정입니다
+var DefaultOptions = Options{
+	Env:        "development",
+	Format:     FORMAT_TEXT,
+	Level:      "info",
+	File:       "logs/go-trading-bot.log",
+	MaxSize:    10,
+	MaxBackups: 5,
+	MaxAge:     7,
+	Compress:   true,
+}
+
+// bootstrapOptions는 Configure 전에 사용하는 설정입니다. 설정을 읽기 전에는 로그 파일을 만들지 않고 stdout에만 출력합니다
+var bootstrapOptions = Options{Env: DefaultOptions.Env, Format: DefaultOptions.Format, Level: DefaultOptions.Level}
+
+var Log = InitLogger(bootstrapOptions)
 
-func InitLogger(env string) *logrus.Logger {
+// InitLogger는 opts로 새 로거를 생성합니다. 잘못된 설정은 기본값을 사용합니다
+func InitLogger(opts Options) *logrus.Logger {
 	log := logrus.New()
-	log.SetOutput(io.MultiWriter(
-		os.Stdout,
-		&lumberjack.Logger{
-			Filename:   "logs/go-trading-bot.log",
-			MaxSize:    10, // MB
-			MaxBackups: 5,
-			MaxAge:     7, // days
-			Compress:   true,
-		}))
-
-	log.SetFormatter(&logrus.TextFormatter{
-		FullTimestamp:   true,
-		TimestampFormat: "2006-01-02 15:04:05",
-		ForceColors:     true,
-		ForceQuote:      true,
-		DisableColors:   false,
-		DisableQuote:    false,
-		CallerPrettyfier: func(f *runtime.Frame) (string, string) {
-			_, b, _, _ := runtime.Caller(0)
-			projectRoot := filepath.Dir(filepath.Dir(filepath.Dir(b))) // logger.go -> internal -> go-trading-bot
-			filename := strings.TrimPrefix(f.File, projectRoot+string(filepath.Separator))
-			funcName := f.Function
-
-			return funcName, fmt.Sprintf("%s:%d", filename, f.Line)
-		},
-	})
-	log.SetReportCaller(true)
-	log.SetLevel(logrus.InfoLevel)
+	if err := apply(log, opts); err != nil {
+		_ = apply(log, DefaultOptions)
+		log.Errorf("로그 설정이 올바르지 않아 기본값을 사용합니다. %v 🔴", err)
+	}
+	return log
+}
+
+// Configure는 Log의 출력 형식, 레벨, 파일 설정을 바꿉니다. 설정 파일을 읽은 뒤 호출합니다.
+// 감사 로그와 dead-letter 로그는 같은 디렉터리에 같은 교체 설정으로 남깁니다
+func Configure(opts Options) error {
+	if err := apply(Log, opts); err != nil {
+		return err
+	}
+	AuditLog.SetOutput(sideOutput(opts, AUDIT_FILE))
+	DeadLetterLog.SetOutput(sideOutput(opts, DEAD_LETTER_FILE))
+	return nil
+}
+
+// sideOutput은 로그 파일 옆에 name으로 남기는 JSON 라인 로그의 출력입니다.
+// 로그 파일을 쓰지 않으면 버립니다. 같은 내용이 요약되어 일반 로그에도 남습니다
+func sideOutput(opts Options, name string) io.Writer {
+	if opts.File == "" {
+		return io.Discard
+	}
+	return &lumberjack.Logger{
+		Filename:   filepath.Join(filepath.Dir(opts.File), name),
+		MaxSize:    opts.MaxSize,
+		MaxBackups: opts.MaxBackups,
+		MaxAge:     opts.MaxAge,
+		Compress:   opts.Compress,
+	}
+}
 
+// newSideLogger는 Configure 전까지 아무것도 남기지 않는 JSON 라인 로거를 생성합니다
+func newSideLogger() *logrus.Logger {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	log.SetFormatter(&logrus.JSONFormatter{TimestampFormat: "2006-01-02T15:04:05.000Z07:00"})
+	log.SetLevel(logrus.InfoLevel)
 	return log
 }
 
+// ParseLevel은 LOG_LEVEL 값을 logrus 레벨로 변환합니다. 비어 있으면 info입니다
+func ParseLevel(level string) (logrus.Level, error) {
+	if strings.TrimSpace(level) == "" {
+		return logrus.InfoLevel, nil
+	}
+	return logrus.ParseLevel(strings.TrimSpace(level))
+}
+
+func apply(log *logrus.Logger, opts Options) error {
+	level, err := ParseLevel(opts.Level)
+	if err != nil {
+		return err
+	}
+	formatter, err := newFormatter(opts)
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stdout
+	if opts.File != "" {
+		out = io.MultiWriter(os.Stdout, &lumberjack.Logger{
+			Filename:   opts.File,
+			MaxSize:    opts.MaxSize,
+			MaxBackups: opts.MaxBackups,
+			MaxAge:     opts.MaxAge,
+			Compress:   opts.Compress,
+		})
+	}
+
+	log.SetOutput(out)
+	log.SetFormatter(formatter)
+	log.SetReportCaller(true)
+	log.SetLevel(level)
+	return nil
+}
+
+func newFormatter(opts Options) (logrus.Formatter, error) {
+	switch strings.ToLower(opts.Format) {
+	case "", FORMAT_TEXT:
+		return &logrus.TextFormatter{
+			FullTimestamp:    true,
+			TimestampFormat:  "2006-01-02 15:04:05",
+			ForceColors:      opts.Env != "production",
+			DisableColors:    opts.Env == "production",
+			ForceQuote:       true,
+			DisableQuote:     false,
+			CallerPrettyfier: callerPrettyfier,
+		}, nil
+	case FORMAT_JSON:
+		return &logrus.JSONFormatter{
+			TimestampFormat:  "2006-01-02T15:04:05.000Z07:00",
+			CallerPrettyfier: callerPrettyfier,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported log format %q (supported: %s)", opts.Format, strings.Join(Formats, ", "))
+	}
+}
+
+func callerPrettyfier(f *runtime.Frame) (string, string) {
+	_, b, _, _ := runtime.Caller(0)
+	projectRoot := filepath.Dir(filepath.Dir(filepath.Dir(b))) // logger.go -> internal -> go-trading-bot
+	filename := strings.TrimPrefix(f.File, projectRoot+string(filepath.Separator))
+	funcName := f.Function
+
+	return funcName, fmt.Sprintf("%s:%d", filename, f.Line)
+}
+
 func Info(args ...interface{})          { Log.Info(args...) }
 func Warn(args ...interface{})          { Log.Warn(args...) }
 func Error(args ...interface{})         { Log.Error(args...) }
@@ -0,0 +1,40 @@
+package logger
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfigureDerivesSideLogPaths(t *testing.T) {
+	// 설정을 읽기 전에는 기본 경로에 로그 파일을 만들지 않습니다
+	Log.Info("before configure")
+	Audit("test", "bootstrap", nil, nil)
+	if _, err := os.Stat("logs"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("logs directory was created before Configure: %v", err)
+	}
+
+	dir := t.TempDir()
+	opts := Options{Format: FORMAT_JSON, Level: "info", File: filepath.Join(dir, "bot.log"), MaxSize: 1, MaxBackups: 1, MaxAge: 1}
+	if err := Configure(opts); err != nil {
+		t.Fatalf("Configure: %v", err)
+	}
+	t.Cleanup(func() { _ = Configure(bootstrapOptions) })
+
+	Audit("test", "pause", nil, nil)
+	DeadLetter("telegram", nil, errors.New("timeout"))
+
+	for name, want := range map[string]string{AUDIT_FILE: `"action":"pause"`, DEAD_LETTER_FILE: `"sink":"telegram"`, "bot.log": "[AUDIT]"} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if !strings.Contains(string(data), want) {
+			t.Errorf("%s = %q, want %s", name, data, want)
+		}
+	}
+}
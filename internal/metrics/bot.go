@@ -0,0 +1,28 @@
+package metrics
+
+// 봇 메트릭. 이름과 라벨은 알림 규칙에서 사용하므로 바꾸지 않습니다
+var (
+	// 분석 사이클 (status: success | failure, action: skip | queue)
+	CycleDuration = NewHistogramVec("tradingbot_cycle_duration_seconds", "Duration of analysis cycles.", DefaultBuckets, "status")
+	Cycles        = NewCounterVec("tradingbot_cycles_total", "Analysis cycles by result.", "status")
+	CycleOverlaps = NewCounterVec("tradingbot_cycle_overlaps_total", "Ticks that arrived while a cycle was running.", "action")
+
+	// 거래소 API (exchange: upbit | binance, code: HTTP 상태 코드 또는 error)
+	ExchangeRequestDuration    = NewHistogramVec("tradingbot_exchange_request_duration_seconds", "Latency of exchange API requests.", DefaultBuckets, "exchange", "endpoint")
+	ExchangeRequests           = NewCounterVec("tradingbot_exchange_requests_total", "Exchange API requests by status code.", "exchange", "endpoint", "code")
+	ExchangeRateLimitRemaining = NewGaugeVec("tradingbot_exchange_rate_limit_remaining", "Remaining requests in the current rate-limit window (Upbit Remaining-Req).", "exchange", "group", "window")
+	ExchangeRateLimitUsed      = NewGaugeVec("tradingbot_exchange_rate_limit_used_weight", "Request weight used in the current rate-limit window (Binance X-MBX-USED-WEIGHT).", "exchange", "window")
+
+	// 전략 (type: BUY | SELL | HOLD)
+	Signals = NewCounterVec("tradingbot_signals_total", "Signals emitted by strategies.", "market", "strategy", "type")
+	Stage   = NewGaugeVec("tradingbot_stage", "Current moving-average cycle stage per market (0 = unknown).", "market")
+
+	// 포지션과 주문 (outcome: filled | rejected)
+	OpenPositions = NewGaugeVec("tradingbot_open_positions", "Number of open positions.")
+	UnrealizedPnL = NewGaugeVec("tradingbot_unrealized_pnl_krw", "Unrealized profit of open positions at the latest price, in KRW.", "market")
+	Orders        = NewCounterVec("tradingbot_orders_total", "Orders by side and outcome.", "side", "outcome")
+
+	// 알림 (failures는 재시도를 포함한 전송 실패 횟수, dead_letters는 끝내 전송하지 못한 알림 수)
+	NotificationFailures    = NewCounterVec("tradingbot_notification_delivery_failures_total", "Failed notification delivery attempts.", "sink")
+	NotificationDeadLetters = NewCounterVec("tradingbot_notification_dead_letters_total", "Notifications that were never delivered.", "sink")
+)
@@ -0,0 +1,267 @@
+// Package metrics는 Prometheus 텍스트 형식(0.0.4)으로 노출하는 카운터, 게이지, 히스토그램을 제공합니다
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultBuckets는 지연 시간(초) 히스토그램의 기본 구간입니다
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+type collector interface {
+	write(w io.Writer)
+}
+
+var (
+	registryMu sync.Mutex
+	registry   []collector
+)
+
+func register(c collector) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry = append(registry, c)
+}
+
+// Handler는 등록된 모든 메트릭을 Prometheus 텍스트 형식으로 응답하는 핸들러를 반환합니다
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		registryMu.Lock()
+		collectors := slices.Clone(registry)
+		registryMu.Unlock()
+		for _, c := range collectors {
+			c.write(w)
+		}
+	})
+}
+
+// vec은 라벨 값 조합별 시계열을 보관합니다
+type vec[T any] struct {
+	name   string
+	help   string
+	kind   string
+	labels []string
+
+	mu     sync.Mutex
+	series map[string]*T
+	values map[string][]string
+	newT   func() *T
+}
+
+func newVec[T any](name, help, kind string, labels []string, newT func() *T) *vec[T] {
+	return &vec[T]{
+		name:   name,
+		help:   help,
+		kind:   kind,
+		labels: labels,
+		series: make(map[string]*T),
+		values: make(map[string][]string),
+		newT:   newT,
+	}
+}
+
+// get은 라벨 값에 해당하는 시계열을 반환합니다. 없으면 만듭니다. 호출자는 mu를 잡고 있어야 합니다
+func (v *vec[T]) get(labelValues []string) *T {
+	if len(labelValues) != len(v.labels) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", v.name, len(v.labels), len(labelValues)))
+	}
+	key := strings.Join(labelValues, "\xff")
+	s, exists := v.series[key]
+	if !exists {
+		s = v.newT()
+		v.series[key] = s
+		v.values[key] = slices.Clone(labelValues)
+	}
+	return s
+}
+
+func (v *vec[T]) delete(labelValues []string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	key := strings.Join(labelValues, "\xff")
+	delete(v.series, key)
+	delete(v.values, key)
+}
+
+func (v *vec[T]) reset() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.series = make(map[string]*T)
+	v.values = make(map[string][]string)
+}
+
+// each는 라벨 값 순서대로 시계열을 순회합니다. 출력 순서가 매번 같도록 정렬합니다
+func (v *vec[T]) each(w io.Writer, fn func(labels string, s *T)) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", v.name, v.help, v.name, v.kind)
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	keys := make([]string, 0, len(v.series))
+	for key := range v.series {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		fn(formatLabels(v.labels, v.values[key]), v.series[key])
+	}
+}
+
+// CounterVec는 증가만 하는 값입니다
+type CounterVec struct {
+	*vec[float64]
+}
+
+// NewCounterVec는 카운터를 만들어 등록합니다
+func NewCounterVec(name, help string, labels ...string) *CounterVec {
+	c := &CounterVec{newVec(name, help, "counter", labels, func() *float64 { return new(float64) })}
+	register(c)
+	return c
+}
+
+// Inc는 라벨 값에 해당하는 카운터를 1 증가시킵니다
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add는 라벨 값에 해당하는 카운터를 value만큼 증가시킵니다. 음수는 무시합니다
+func (c *CounterVec) Add(value float64, labelValues ...string) {
+	if value < 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	*c.get(labelValues) += value
+}
+
+func (c *CounterVec) write(w io.Writer) {
+	c.each(w, func(labels string, value *float64) {
+		fmt.Fprintf(w, "%s%s %s\n", c.name, labels, formatFloat(*value))
+	})
+}
+
+// GaugeVec는 오르내리는 현재 값입니다
+type GaugeVec struct {
+	*vec[float64]
+}
+
+// NewGaugeVec는 게이지를 만들어 등록합니다
+func NewGaugeVec(name, help string, labels ...string) *GaugeVec {
+	g := &GaugeVec{newVec(name, help, "gauge", labels, func() *float64 { return new(float64) })}
+	register(g)
+	return g
+}
+
+// Set은 라벨 값에 해당하는 게이지 값을 설정합니다
+func (g *GaugeVec) Set(value float64, labelValues ...string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	*g.get(labelValues) = value
+}
+
+// Delete는 라벨 값에 해당하는 게이지를 제거합니다
+func (g *GaugeVec) Delete(labelValues ...string) {
+	g.delete(labelValues)
+}
+
+// Reset은 모든 게이지를 제거합니다. 마켓 목록처럼 라벨 값이 바뀌는 게이지를 다시 채울 때 사용합니다
+func (g *GaugeVec) Reset() {
+	g.reset()
+}
+
+func (g *GaugeVec) write(w io.Writer) {
+	g.each(w, func(labels string, value *float64) {
+		fmt.Fprintf(w, "%s%s %s\n", g.name, labels, formatFloat(*value))
+	})
+}
+
+type histogram struct {
+	counts []uint64 // 구간별 누적이 아닌 개수, 마지막은 +Inf
+	sum    float64
+	count  uint64
+}
+
+// HistogramVec는 값의 분포를 구간별로 셉니다
+type HistogramVec struct {
+	*vec[histogram]
+	buckets []float64
+}
+
+// NewHistogramVec는 히스토그램을 만들어 등록합니다. buckets가 비어 있으면 DefaultBuckets를 사용합니다
+func NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	buckets = slices.Clone(buckets)
+	slices.Sort(buckets)
+	h := &HistogramVec{
+		vec: newVec(name, help, "histogram", labels, func() *histogram {
+			return &histogram{counts: make([]uint64, len(buckets)+1)}
+		}),
+		buckets: buckets,
+	}
+	register(h)
+	return h
+}
+
+// Observe는 라벨 값에 해당하는 히스토그램에 값을 기록합니다
+func (h *HistogramVec) Observe(value float64, labelValues ...string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s := h.get(labelValues)
+	i, _ := slices.BinarySearch(h.buckets, value)
+	s.counts[i]++
+	s.sum += value
+	s.count++
+}
+
+func (h *HistogramVec) write(w io.Writer) {
+	h.each(w, func(labels string, s *histogram) {
+		var cumulative uint64
+		for i, upper := range h.buckets {
+			cumulative += s.counts[i]
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, withLabel(labels, "le", formatFloat(upper)), cumulative)
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, withLabel(labels, "le", "+Inf"), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, labels, formatFloat(s.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, labels, s.count)
+	})
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatLabels(names, values []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = name + `="` + labelEscaper.Replace(values[i]) + `"`
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func withLabel(labels, name, value string) string {
+	pair := name + `="` + value + `"`
+	if labels == "" {
+		return "{" + pair + "}"
+	}
+	return labels[:len(labels)-1] + "," + pair + "}"
+}
+
+func formatFloat(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
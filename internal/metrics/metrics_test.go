@@ -0,0 +1,66 @@
+package metrics_test
+
+import (
+	"flag"
+	"go-trading-bot/internal/metrics"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// 이름이나 형식을 의도해서 바꿨다면 go test ./internal/metrics -update로 골든 파일을 다시 씁니다
+var update = flag.Bool("update", false, "rewrite the golden metrics output")
+
+// TestHandlerGolden은 봇 메트릭을 모두 채운 뒤 /metrics 응답을 골든 파일과 비교합니다.
+// 메트릭 이름과 라벨은 알림 규칙과 대시보드에서 사용하므로 바뀌면 실패해야 합니다
+func TestHandlerGolden(t *testing.T) {
+	metrics.CycleDuration.Observe(0.3, "success")
+	metrics.CycleDuration.Observe(75, "failure")
+	metrics.Cycles.Inc("success")
+	metrics.Cycles.Add(2, "failure")
+	metrics.Cycles.Add(-1, "failure") // 카운터는 줄지 않습니다
+	metrics.CycleOverlaps.Inc("skip")
+
+	metrics.ExchangeRequestDuration.Observe(0.004, "upbit", "/candles/minutes/240")
+	metrics.ExchangeRequests.Inc("upbit", "/candles/minutes/240", "200")
+	metrics.ExchangeRequests.Inc("binance", "/ticker/price", "error")
+	metrics.ExchangeRateLimitRemaining.Set(1800, "upbit", "default", "min")
+	metrics.ExchangeRateLimitRemaining.Set(29, "upbit", "default", "sec")
+	metrics.ExchangeRateLimitUsed.Set(12, "binance", "1m")
+
+	metrics.Signals.Inc("KRW-BTC", "moving-average-cross", "BUY")
+	metrics.Signals.Inc("KRW-ETH", `quote"back\slash`, "HOLD") // 라벨 값 이스케이프
+	metrics.Stage.Set(3, "KRW-BTC")
+	metrics.Stage.Set(1, "KRW-ETH")
+	metrics.Stage.Delete("KRW-ETH")
+
+	metrics.OpenPositions.Set(1)
+	metrics.UnrealizedPnL.Set(-1234.5, "KRW-BTC")
+	metrics.Orders.Inc("BUY", "filled")
+	metrics.Orders.Inc("SELL", "rejected")
+
+	metrics.NotificationFailures.Add(3, "slack")
+	metrics.NotificationDeadLetters.Inc("slack")
+
+	w := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if got := w.Header().Get("Content-Type"); got != "text/plain; version=0.0.4; charset=utf-8" {
+		t.Errorf("Content-Type = %q", got)
+	}
+
+	path := filepath.Join("testdata", "handler.golden")
+	if *update {
+		if err := os.WriteFile(path, w.Body.Bytes(), 0o644); err != nil {
+			t.Fatalf("write golden: %v", err)
+		}
+	}
+	golden, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden: %v", err)
+	}
+	if got := w.Body.String(); got != string(golden) {
+		t.Errorf("metrics output differs from %s:\n%s", path, got)
+	}
+}
@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Transport는 거래소 API 요청의 지연 시간, 상태 코드, 요청 한도 헤더를 기록하는 http.RoundTripper입니다
+type Transport struct {
+	Exchange string
+	Next     http.RoundTripper
+}
+
+// NewClient는 Transport를 사용하는 http.Client를 반환합니다
+func NewClient(exchange string) *http.Client {
+	return &http.Client{Transport: &Transport{Exchange: exchange}}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	endpoint := endpointLabel(req.URL.Path)
+	start := time.Now()
+	resp, err := next.RoundTrip(req)
+	ExchangeRequestDuration.Observe(time.Since(start).Seconds(), t.Exchange, endpoint)
+	if err != nil {
+		ExchangeRequests.Inc(t.Exchange, endpoint, "error")
+		return nil, err
+	}
+
+	ExchangeRequests.Inc(t.Exchange, endpoint, strconv.Itoa(resp.StatusCode))
+	t.recordRateLimit(resp.Header)
+	return resp, nil
+}
+
+// recordRateLimit은 거래소의 요청 한도 응답 헤더를 게이지로 기록합니다
+func (t *Transport) recordRateLimit(header http.Header) {
+	// 업비트: Remaining-Req: group=default; min=1800; sec=29
+	if remaining := header.Get("Remaining-Req"); remaining != "" {
+		group := ""
+		values := make(map[string]float64)
+		for _, field := range strings.Split(remaining, ";") {
+			key, value, found := strings.Cut(strings.TrimSpace(field), "=")
+			if !found {
+				continue
+			}
+			if key == "group" {
+				group = value
+			} else if v, err := strconv.ParseFloat(value, 64); err == nil {
+				values[key] = v
+			}
+		}
+		for window, v := range values {
+			ExchangeRateLimitRemaining.Set(v, t.Exchange, group, window)
+		}
+	}
+
+	// 바이낸스: X-MBX-USED-WEIGHT-1M: 12
+	for key, values := range header {
+		window, found := strings.CutPrefix(strings.ToUpper(key), "X-MBX-USED-WEIGHT-")
+		if !found || len(values) == 0 {
+			continue
+		}
+		if v, err := strconv.ParseFloat(values[0], 64); err == nil {
+			ExchangeRateLimitUsed.Set(v, t.Exchange, strings.ToLower(window))
+		}
+	}
+}
+
+// endpointLabel은 요청 경로를 라벨로 사용합니다. API 버전 접두사(/v1, /api/v3)는 뺍니다
+func endpointLabel(path string) string {
+	path = strings.TrimPrefix(path, "/api")
+	if rest, found := strings.CutPrefix(path, "/v"); found {
+		if i := strings.IndexByte(rest, '/'); i > 0 {
+			if _, err := strconv.Atoi(rest[:i]); err == nil {
+				path = rest[i:]
+			}
+		}
+	}
+	if path == "" {
+		return "/"
+	}
+	return path
+}
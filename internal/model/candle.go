@@ -1,11 +1,19 @@
 // Package model
 package model
 
+import "time"
+
 type Candle struct {
-	Market       string  `json:"market"`
-	OpeningPrice float64 `json:"opening_price"`
-	HighPrice    float64 `json:"high_price"`
-	LowPrice     float64 `json:"low_price"`
-	TradePrice   float64 `json:"trade_price"`
-	Timestamp    int64   `json:"timestamp"`
+	Market            string  `json:"market"`
+	CandleDateTimeUTC string  `json:"candle_date_time_utc"`
+	OpeningPrice      float64 `json:"opening_price"`
+	HighPrice         float64 `json:"high_price"`
+	LowPrice          float64 `json:"low_price"`
+	TradePrice        float64 `json:"trade_price"`
+	Timestamp         int64   `json:"timestamp"`
+}
+
+// StartTime은 캔들 시작 시각(candle_date_time_utc)을 반환합니다
+func (c Candle) StartTime() (time.Time, error) {
+	return time.ParseInLocation("2006-01-02T15:04:05", c.CandleDateTimeUTC, time.UTC)
 }
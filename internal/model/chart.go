@@ -0,0 +1,20 @@
+package model
+
+// Chart는 대시보드 차트용 캔들과 이동평균선 데이터입니다. 캔들은 오래된 순으로 정렬됩니다
+type Chart struct {
+	Market         string
+	Candles        []Candle
+	MovingAverages []MovingAverage
+}
+
+// MovingAverage는 기간별 이동평균선입니다. 계산할 수 없는 앞부분 캔들은 Points에서 제외됩니다
+type MovingAverage struct {
+	Period int
+	Points []ChartPoint
+}
+
+// ChartPoint는 캔들 시작 시각(candle_date_time_utc)의 값입니다
+type ChartPoint struct {
+	Time  string
+	Value float64
+}
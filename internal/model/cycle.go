@@ -0,0 +1,25 @@
+package model
+
+import "time"
+
+// CycleStatus는 분석 사이클의 실행 메타데이터입니다
+type CycleStatus struct {
+	Running      bool          // 현재 사이클 실행 여부
+	CycleCount   int64         // 완료된 사이클 수
+	StartedAt    time.Time     // 마지막 사이클 시작 시각
+	EndedAt      time.Time     // 마지막 사이클 종료 시각
+	Duration     time.Duration // 마지막 사이클 소요 시간
+	SkippedTicks int64         // 이전 사이클 실행 중이라 건너뛴 틱 수
+	QueuedTicks  int64         // 이전 사이클 종료 후 실행하도록 대기시킨 틱 수
+	LastError    string        // 마지막 사이클의 오류 메시지. 성공하면 비움
+	LastErrorAt  time.Time     // 마지막 사이클의 오류 발생 시각. 성공하면 비움
+	LastSuccess  time.Time     // 마지막으로 오류 없이 끝난 사이클의 종료 시각
+}
+
+// BotStatus는 봇의 제어 상태입니다
+type BotStatus struct {
+	Paused       bool     // 신규 진입 일시정지 여부
+	EntryAllowed bool     // 거래 시간대 내 여부
+	Markets      []string // 분석 대상 마켓
+	Cycle        CycleStatus
+}
@@ -0,0 +1,47 @@
+package model
+
+import "time"
+
+// 헬스 체크 결과. warn은 준비 상태에 영향을 주지 않고, fail이 하나라도 있으면 준비되지 않은 것으로 봅니다
+const (
+	HEALTH_OK   = "ok"
+	HEALTH_WARN = "warn"
+	HEALTH_FAIL = "fail"
+	HEALTH_SKIP = "skip" // 설정되지 않아 확인하지 않음
+)
+
+// HealthCheck는 항목 하나의 확인 결과입니다
+type HealthCheck struct {
+	Name    string
+	Status  string
+	Message string
+	Details map[string]any
+}
+
+// HealthReport는 헬스 체크 결과 모음입니다. Status는 항목 중 가장 나쁜 결과입니다
+type HealthReport struct {
+	Status    string
+	Checks    []HealthCheck
+	CheckedAt time.Time
+}
+
+// Healthy는 fail 항목이 없는지 반환합니다
+func (r HealthReport) Healthy() bool {
+	return r.Status != HEALTH_FAIL
+}
+
+// NewHealthReport는 checks로 HealthReport를 만듭니다
+func NewHealthReport(checks []HealthCheck, checkedAt time.Time) HealthReport {
+	status := HEALTH_OK
+	for _, check := range checks {
+		switch check.Status {
+		case HEALTH_FAIL:
+			status = HEALTH_FAIL
+		case HEALTH_WARN:
+			if status == HEALTH_OK {
+				status = HEALTH_WARN
+			}
+		}
+	}
+	return HealthReport{Status: status, Checks: checks, CheckedAt: checkedAt}
+}
@@ -0,0 +1,41 @@
+package model
+
+import "time"
+
+// Order는 실행된 주문 기록입니다
+type Order struct {
+	ID        string
+	Market    string
+	Side      string  // BUY | SELL
+	Price     float64 // 체결 가격
+	Quantity  float64 // 체결 수량
+	Amount    float64 // 주문 금액 (가격 * 수량)
+	Profit    float64 // 실현 손익 (SELL 주문)
+	Stage     string  // 진입 신호의 사이클 단계 (예: STAGE_1). SELL 주문은 청산한 포지션의 진입 단계이며, 단계가 없으면 빈 값
+	CreatedAt time.Time
+}
+
+// OrderFilter는 주문 기록 조회 조건입니다. 비어 있는 필드는 조건에서 제외됩니다
+type OrderFilter struct {
+	Market string
+	Side   string
+	From   time.Time
+	To     time.Time
+}
+
+// Matches는 주문이 조회 조건에 맞는지 반환합니다
+func (f OrderFilter) Matches(order Order) bool {
+	if f.Market != "" && f.Market != order.Market {
+		return false
+	}
+	if f.Side != "" && f.Side != order.Side {
+		return false
+	}
+	if !f.From.IsZero() && order.CreatedAt.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !order.CreatedAt.Before(f.To) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,36 @@
+package model
+
+// PositionSummary는 현재가 기준 평가손익을 포함한 포지션 정보입니다
+type PositionSummary struct {
+	Position
+	CurrentPrice         float64
+	UnrealizedProfit     float64
+	UnrealizedProfitRate float64 // 수익률(%)
+}
+
+// PnLEntry는 마켓 또는 일자별 실현 손익 집계입니다
+type PnLEntry struct {
+	Key        string // 마켓 또는 일자(2006-01-02, KST)
+	Profit     float64
+	TradeCount int
+	WinCount   int
+}
+
+// PnLSummary는 실현 손익 요약입니다
+type PnLSummary struct {
+	TotalProfit float64
+	TradeCount  int
+	WinCount    int
+	ByMarket    []PnLEntry
+	ByDay       []PnLEntry
+}
+
+// StrategyInfo는 현재 사용 중인 전략과 파라미터 정보입니다
+type StrategyInfo struct {
+	Name                string
+	RequiredCandleCount int
+	Parameters          map[string]any
+	CandleCategory      string
+	CandleUnit          int
+	ClosedCandleOnly    bool
+}
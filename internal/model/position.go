@@ -15,6 +15,7 @@ type Position struct {
 	Quantity   float64
 	EntryPrice float64
 	Profit     float64
+	Stage      string // 진입 신호의 사이클 단계. 단계가 없는 전략이나 수동 주문은 빈 값
 }
 
 func (p Position) String() string {
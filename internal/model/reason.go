@@ -0,0 +1,28 @@
+// Package model
+package model
+
+// 신호 사유 코드. 문장은 i18n 카탈로그에서 언어별로 만듭니다
+const (
+	REASON_GOLDEN_CROSS = "ma_cross.golden_cross" // 단기 MA가 장기 MA를 상향 돌파
+	REASON_DEAD_CROSS   = "ma_cross.dead_cross"   // 단기 MA가 장기 MA를 하향 돌파
+	REASON_NO_CROSS     = "ma_cross.no_cross"     // 교차 없음
+
+	REASON_STAGE_0       = "ma_cycle.stage_0"       // 알 수 없는 단계
+	REASON_STAGE_1       = "ma_cycle.stage_1"       // 안정 상승기
+	REASON_STAGE_1_BUY   = "ma_cycle.stage_1_buy"   // 안정 상승기, 모든 MA 우상향
+	REASON_STAGE_2       = "ma_cycle.stage_2"       // 데드크로스
+	REASON_STAGE_3       = "ma_cycle.stage_3"       // 본격 하락기
+	REASON_STAGE_4       = "ma_cycle.stage_4"       // 안정 하락기
+	REASON_STAGE_4_SHORT = "ma_cycle.stage_4_short" // 안정 하락기, 모든 MA 우하향
+	REASON_STAGE_5       = "ma_cycle.stage_5"       // 골든크로스
+	REASON_STAGE_6       = "ma_cycle.stage_6"       // 본격 상승기
+
+	REASON_NOT_ENOUGH_CANDLES = "candles.not_enough" // 분석에 필요한 캔들 부족
+	REASON_INVALID_CANDLE     = "candles.invalid"    // 가격이 NaN, 무한대 또는 0 이하인 캔들
+)
+
+// Reason은 신호가 발생한 이유를 언어와 무관한 코드와 파라미터로 표현합니다
+type Reason struct {
+	Code   string
+	Params map[string]any
+}
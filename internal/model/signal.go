@@ -20,9 +20,18 @@ type Signal struct {
 	CurrentPrice float64
 	Timestamp    string
 
-	Description  string
+	Description  string // Reason을 기본 언어로 만든 설명
+	Reason       Reason
 	StrategyName string
 
 	// Stage 정보 (사이클 전략에서 사용)
 	Stage *Stage // 포인터로 옵셔널하게 사용
 }
+
+// StageName은 신호의 사이클 단계 이름(예: STAGE_1)입니다. 단계가 없으면 빈 문자열입니다
+func (s Signal) StageName() string {
+	if s.Stage == nil {
+		return ""
+	}
+	return s.Stage.StageNumber.String()
+}
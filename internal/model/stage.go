@@ -29,5 +29,6 @@ const (
 type Stage struct {
 	StageNumber StageNumber
 	StageDir    StageDir
-	Description string
+	Description string // Reason을 기본 언어로 만든 설명
+	Reason      Reason
 }
@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+// BotState는 재시작 후 복원할 봇 상태입니다
+type BotState struct {
+	Positions []Position // 모의 주문 포지션
+	Orders    []Order    // 주문 기록
+	Paused    bool       // 신규 진입 일시정지 여부
+	SavedAt   time.Time
+}
@@ -0,0 +1,7 @@
+package model
+
+type Ticker struct {
+	Market     string  `json:"market"`
+	TradePrice float64 `json:"trade_price"`
+	Timestamp  int64   `json:"timestamp"`
+}
@@ -0,0 +1,104 @@
+package model
+
+// 업비트 주문 API의 side, ord_type, state 값
+const (
+	UPBIT_SIDE_BID = "bid" // 매수
+	UPBIT_SIDE_ASK = "ask" // 매도
+
+	UPBIT_ORD_TYPE_LIMIT  = "limit"  // 지정가 (price, volume 필요)
+	UPBIT_ORD_TYPE_PRICE  = "price"  // 시장가 매수 (price에 주문 총액)
+	UPBIT_ORD_TYPE_MARKET = "market" // 시장가 매도 (volume 필요)
+
+	UPBIT_ORDER_WAIT   = "wait"
+	UPBIT_ORDER_DONE   = "done"
+	UPBIT_ORDER_CANCEL = "cancel"
+)
+
+// UpbitOrderRequest는 업비트 주문 요청입니다. 숫자는 업비트 API와 같이 문자열로 보냅니다
+type UpbitOrderRequest struct {
+	Market     string `json:"market"`
+	Side       string `json:"side"`
+	Volume     string `json:"volume,omitempty"`
+	Price      string `json:"price,omitempty"`
+	OrdType    string `json:"ord_type"`
+	Identifier string `json:"identifier,omitempty"`
+}
+
+// Params는 query_hash 계산과 요청 본문에 사용할 파라미터를 반환합니다. 비어 있는 값은 제외합니다
+func (r UpbitOrderRequest) Params() map[string]string {
+	params := map[string]string{"market": r.Market, "side": r.Side, "ord_type": r.OrdType}
+	for key, value := range map[string]string{"volume": r.Volume, "price": r.Price, "identifier": r.Identifier} {
+		if value != "" {
+			params[key] = value
+		}
+	}
+	return params
+}
+
+// UpbitOrder는 업비트 주문 조회/생성/취소 응답입니다
+type UpbitOrder struct {
+	UUID            string       `json:"uuid"`
+	Side            string       `json:"side"`
+	OrdType         string       `json:"ord_type"`
+	Price           string       `json:"price,omitempty"`
+	State           string       `json:"state"`
+	Market          string       `json:"market"`
+	CreatedAt       string       `json:"created_at"`
+	Volume          string       `json:"volume,omitempty"`
+	RemainingVolume string       `json:"remaining_volume,omitempty"`
+	ReservedFee     string       `json:"reserved_fee"`
+	RemainingFee    string       `json:"remaining_fee"`
+	PaidFee         string       `json:"paid_fee"`
+	Locked          string       `json:"locked"`
+	ExecutedVolume  string       `json:"executed_volume"`
+	TradesCount     int          `json:"trades_count"`
+	Identifier      string       `json:"identifier,omitempty"`
+	Trades          []UpbitTrade `json:"trades,omitempty"`
+}
+
+// UpbitTrade는 주문의 체결 내역입니다
+type UpbitTrade struct {
+	Market    string `json:"market"`
+	UUID      string `json:"uuid"`
+	Price     string `json:"price"`
+	Volume    string `json:"volume"`
+	Funds     string `json:"funds"`
+	Side      string `json:"side"`
+	CreatedAt string `json:"created_at"`
+}
+
+// UpbitAccount는 업비트 계좌 잔고입니다
+type UpbitAccount struct {
+	Currency            string `json:"currency"`
+	Balance             string `json:"balance"`
+	Locked              string `json:"locked"`
+	AvgBuyPrice         string `json:"avg_buy_price"`
+	AvgBuyPriceModified bool   `json:"avg_buy_price_modified"`
+	UnitCurrency        string `json:"unit_currency"`
+}
+
+// UpbitOrderChance는 마켓별 주문 가능 정보(수수료, 최소 주문 금액, 잔고)입니다
+type UpbitOrderChance struct {
+	BidFee     string            `json:"bid_fee"`
+	AskFee     string            `json:"ask_fee"`
+	Market     UpbitChanceMarket `json:"market"`
+	BidAccount UpbitAccount      `json:"bid_account"`
+	AskAccount UpbitAccount      `json:"ask_account"`
+}
+
+type UpbitChanceMarket struct {
+	ID         string           `json:"id"`
+	Name       string           `json:"name"`
+	OrderSides []string         `json:"order_sides"`
+	BidTypes   []string         `json:"bid_types"`
+	AskTypes   []string         `json:"ask_types"`
+	Bid        UpbitChanceLimit `json:"bid"`
+	Ask        UpbitChanceLimit `json:"ask"`
+	MaxTotal   string           `json:"max_total"`
+	State      string           `json:"state"`
+}
+
+type UpbitChanceLimit struct {
+	Currency string `json:"currency"`
+	MinTotal string `json:"min_total"`
+}
@@ -0,0 +1,52 @@
+package notify
+
+import (
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EmailNotifier는 SMTP로 알림 메일을 전송합니다. Username이 있으면 PLAIN 인증을 사용합니다
+type EmailNotifier struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+func (e *EmailNotifier) Name() string { return "email" }
+
+func (e *EmailNotifier) Notify(n Notification) error {
+	subject := n.Title
+	if subject == "" {
+		subject = fmt.Sprintf("[%s] %s", n.Severity, n.Type)
+	}
+	subject = "[go-trading-bot] " + toPlainText(subject)
+
+	var msg strings.Builder
+	msg.WriteString("From: " + e.From + "\r\n")
+	msg.WriteString("To: " + strings.Join(e.To, ", ") + "\r\n")
+	msg.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", subject) + "\r\n")
+	msg.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.ReplaceAll(toPlainText(n.Message), "\n", "\r\n"))
+
+	var auth smtp.Auth
+	if e.Username != "" {
+		auth = smtp.PlainAuth("", e.Username, e.Password, e.Host)
+	}
+
+	addr := net.JoinHostPort(e.Host, strconv.Itoa(e.Port))
+	if err := smtp.SendMail(addr, auth, e.From, e.To, []byte(msg.String())); err != nil {
+		return fmt.Errorf("email: %w", err)
+	}
+	return nil
+}
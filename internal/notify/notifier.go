@@ -0,0 +1,97 @@
+// Package notify
+package notify
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Type은 알림 종류입니다. 라우팅 규칙(notification.routes[].types)에서 사용합니다
+type Type string
+
+const (
+	TYPE_SIGNAL  Type = "signal"  // 매매 신호
+	TYPE_STAGE   Type = "stage"   // 사이클 단계 변경
+	TYPE_HOLD    Type = "hold"    // 변화 없는 HOLD
+	TYPE_ORDER   Type = "order"   // 주문 체결
+	TYPE_RISK    Type = "risk"    // 손절/익절 등 리스크 청산
+	TYPE_CONTROL Type = "control" // 제어 API, 텔레그램 명령
+	TYPE_CONFIG  Type = "config"  // 설정 변경
+	TYPE_REPORT  Type = "report"  // 정기 리포트
+	TYPE_SYSTEM  Type = "system"  // 시작/종료, 오류
+	TYPE_DIGEST  Type = "digest"  // 요약 알림
+)
+
+// Types는 모든 알림 종류입니다
+var Types = []Type{TYPE_SIGNAL, TYPE_STAGE, TYPE_HOLD, TYPE_ORDER, TYPE_RISK, TYPE_CONTROL, TYPE_CONFIG, TYPE_REPORT, TYPE_SYSTEM, TYPE_DIGEST}
+
+// Severity는 알림 심각도입니다
+type Severity string
+
+const (
+	SEVERITY_INFO     Severity = "info"
+	SEVERITY_WARNING  Severity = "warning"
+	SEVERITY_CRITICAL Severity = "critical"
+)
+
+// Severities는 낮은 순으로 정렬된 심각도 목록입니다
+var Severities = []Severity{SEVERITY_INFO, SEVERITY_WARNING, SEVERITY_CRITICAL}
+
+// Rank는 심각도의 순위를 반환합니다. 알 수 없는 값은 info로 취급합니다
+func (s Severity) Rank() int {
+	for i, severity := range Severities {
+		if s == severity {
+			return i
+		}
+	}
+	return 0
+}
+
+// Notification은 알림 한 건입니다. Message는 텔레그램 HTML 형식(<b>, <i>)으로 작성하며,
+// 다른 채널은 각자의 형식으로 변환해 전송합니다
+type Notification struct {
+	Type     Type
+	Severity Severity
+	Title    string // 비어 있으면 제목 없이 본문만 전송
+	Message  string
+	Market   string
+	State    string // 반복 알림 억제에 사용하는 마켓 상태 (예: BUY, STAGE_3)
+	Summary  string // 요약 알림에 표시할 한 줄, 비어 있으면 Title 사용
+	Time     time.Time
+}
+
+// Notifier는 알림 채널입니다
+type Notifier interface {
+	Name() string
+	Notify(n Notification) error
+}
+
+// Nop은 아무것도 전송하지 않는 Notifier입니다
+type Nop struct{}
+
+func (Nop) Name() string { return "nop" }
+
+func (Nop) Notify(Notification) error { return nil }
+
+var tagPattern = regexp.MustCompile(`<[^>]+>`)
+
+// toMarkup은 텔레그램 HTML을 bold/italic 기호를 쓰는 마크다운 계열 형식으로 변환합니다
+func toMarkup(message, bold, italic string) string {
+	replacer := strings.NewReplacer("<b>", bold, "</b>", bold, "<i>", italic, "</i>", italic)
+	return html.UnescapeString(tagPattern.ReplaceAllString(replacer.Replace(message), ""))
+}
+
+// toPlainText는 텔레그램 HTML에서 태그를 제거합니다
+func toPlainText(message string) string {
+	return toMarkup(message, "", "")
+}
+
+// titled는 제목과 본문을 합친 텍스트를 반환합니다
+func titled(title, body string) string {
+	if title == "" {
+		return body
+	}
+	return title + "\n\n" + body
+}
@@ -0,0 +1,274 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-trading-bot/config"
+	"go-trading-bot/internal/clock"
+	"go-trading-bot/internal/logger"
+	"go-trading-bot/internal/scheduler"
+	"html"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 알림 규칙의 mode
+const (
+	MODE_IMMEDIATE = "immediate" // 즉시 전송
+	MODE_DIGEST    = "digest"    // 요약 알림에 모아서 전송
+	MODE_OFF       = "off"       // 전송하지 않음
+)
+
+// 조용한 시간에 즉시 알림을 처리하는 방법
+const (
+	QUIET_DIGEST = "digest" // 요약 알림으로 미룸
+	QUIET_DROP   = "drop"   // 버림
+	QUIET_SEND   = "send"   // 그대로 전송
+)
+
+// Modes, QuietActions는 규칙에 사용할 수 있는 값입니다
+var (
+	Modes        = []string{MODE_IMMEDIATE, MODE_DIGEST, MODE_OFF}
+	QuietActions = []string{QUIET_DIGEST, QUIET_DROP, QUIET_SEND}
+)
+
+// defaultRules는 설정에 규칙이 없는 타입에 적용됩니다. 변화 없는 HOLD는 요약 알림으로만 보냅니다
+var defaultRules = map[Type]config.AlertRule{
+	TYPE_HOLD: {Mode: MODE_DIGEST},
+}
+
+// Digester는 모아 둔 알림을 요약해 전송하는 Notifier입니다
+type Digester interface {
+	SendDigest() error
+}
+
+// digestEntry는 요약 알림에 들어갈 항목입니다. 같은 타입/마켓의 알림은 최신 것으로 교체하고 횟수만 셉니다
+type digestEntry struct {
+	notification Notification
+	count        int
+}
+
+// Policy는 알림 정책을 적용해 next로 전달하는 Notifier입니다.
+//   - 타입별 규칙(mode)에 따라 즉시 전송, 요약, 무시를 결정합니다
+//   - suppress-repeat 규칙은 마켓별 상태(State)가 마지막으로 전송한 알림(요약 포함)과 같으면 보내지 않습니다
+//   - 조용한 시간(quiet-hours)에는 즉시 알림을 요약으로 미루거나 버립니다. critical 알림은 항상 전송합니다
+//   - SendDigest는 모아 둔 알림과 변화 없는 마켓을 한 번에 요약해 보냅니다
+type Policy struct {
+	next   Notifier
+	policy func() config.AlertPolicy
+	now    func() time.Time
+
+	mu        sync.Mutex
+	lastState map[string]string // 즉시 전송했거나 요약으로 전송한 마지막 상태
+	digest    map[string]*digestEntry
+}
+
+// NewPolicy는 policy()의 규칙을 적용하는 Policy를 생성합니다. 규칙은 알림마다 다시 읽으므로 설정 리로드가 바로 반영됩니다
+func NewPolicy(next Notifier, policy func() config.AlertPolicy) *Policy {
+	return &Policy{
+		next:      next,
+		policy:    policy,
+		now:       time.Now,
+		lastState: make(map[string]string),
+		digest:    make(map[string]*digestEntry),
+	}
+}
+
+// NewPolicyFromConfig는 provider의 alert-policy를 적용하고 clk 기준으로 조용한 시간과 요약 시각을 판단하는 Policy를 생성합니다
+func NewPolicyFromConfig(next Notifier, provider config.Provider, clk clock.Clock) *Policy {
+	p := NewPolicy(next, func() config.AlertPolicy {
+		if tc := provider.TradingConfig(); tc != nil {
+			return tc.AlertPolicy
+		}
+		return config.AlertPolicy{}
+	})
+	if clk != nil {
+		p.now = clk.Now
+	}
+	return p
+}
+
+func (p *Policy) Name() string { return "policy" }
+
+// Rule은 타입에 적용되는 규칙을 기본값을 채워 반환합니다
+func Rule(policy config.AlertPolicy, t Type) config.AlertRule {
+	rule, exists := policy.Rules[string(t)]
+	if !exists {
+		rule = defaultRules[t]
+	}
+	if rule.Mode == "" {
+		rule.Mode = MODE_IMMEDIATE
+	}
+	if rule.QuietHours == "" {
+		rule.QuietHours = QUIET_DIGEST
+	}
+	return rule
+}
+
+func (p *Policy) Notify(n Notification) error {
+	if n.Time.IsZero() {
+		n.Time = p.now()
+	}
+	policy := p.policy()
+	rule := Rule(policy, n.Type)
+	key := string(n.Type) + "|" + n.Market
+
+	p.mu.Lock()
+	if rule.Mode == MODE_OFF {
+		p.mu.Unlock()
+		return nil
+	}
+	if rule.SuppressRepeat && n.State != "" && p.lastState[key] == n.State {
+		p.mu.Unlock()
+		logger.Log.Debugf("[%v] 직전과 같은 상태의 알림을 보내지 않습니다. (%v)", key, n.State)
+		return nil
+	}
+
+	deferred := rule.Mode == MODE_DIGEST
+	if !deferred && n.Severity != SEVERITY_CRITICAL && p.inQuietHours(policy.QuietHours, n.Time) {
+		switch rule.QuietHours {
+		case QUIET_DROP:
+			p.mu.Unlock()
+			return nil
+		case QUIET_DIGEST:
+			deferred = true
+		}
+	}
+
+	if deferred {
+		// 요약 알림을 보내기 전까지는 전송한 것이 아니므로 lastState를 바꾸지 않습니다
+		if entry, exists := p.digest[key]; exists {
+			if rule.SuppressRepeat && n.State != "" && entry.notification.State == n.State {
+				p.mu.Unlock()
+				return nil
+			}
+			entry.notification = n
+			entry.count++
+		} else {
+			p.digest[key] = &digestEntry{notification: n, count: 1}
+		}
+		p.mu.Unlock()
+		return nil
+	}
+	if n.State != "" {
+		p.lastState[key] = n.State
+	}
+	p.mu.Unlock()
+
+	return p.next.Notify(n)
+}
+
+// SendDigest는 모아 둔 알림을 요약해 전송합니다. 조용한 시간에는 전송을 미룹니다
+func (p *Policy) SendDigest() error {
+	policy := p.policy()
+	now := p.now()
+
+	p.mu.Lock()
+	if len(p.digest) == 0 || p.inQuietHours(policy.QuietHours, now) {
+		p.mu.Unlock()
+		return nil
+	}
+	entries := make([]*digestEntry, 0, len(p.digest))
+	for key, entry := range p.digest {
+		entries = append(entries, entry)
+		if entry.notification.State != "" {
+			p.lastState[key] = entry.notification.State
+		}
+	}
+	p.digest = make(map[string]*digestEntry)
+	p.mu.Unlock()
+
+	return p.next.Notify(formatDigest(entries, now))
+}
+
+// Flush는 다음 Notifier의 대기 중인 알림을 전송합니다. 요약 알림에 모아 둔 항목은 조용한 시간이 아니면 먼저 보냅니다
+func (p *Policy) Flush(ctx context.Context) error {
+	err := p.SendDigest()
+	if flusher, ok := p.next.(Flusher); ok {
+		err = errors.Join(err, flusher.Flush(ctx))
+	}
+	return err
+}
+
+// Status는 다음 Notifier의 채널별 전송 상태를 반환합니다
+func (p *Policy) Status() []SinkStatus {
+	if reporter, ok := p.next.(StatusReporter); ok {
+		return reporter.Status()
+	}
+	return nil
+}
+
+func (p *Policy) inQuietHours(quietHours config.QuietHours, t time.Time) bool {
+	window, err := scheduler.NewTradingWindow(config.TradingWindow{
+		Enabled:  quietHours.Enabled,
+		Timezone: quietHours.Timezone,
+		Hours:    quietHours.Hours,
+		Weekdays: quietHours.Weekdays,
+	})
+	if err != nil {
+		logger.Log.Errorf("조용한 시간 설정이 올바르지 않아 적용하지 않습니다. %v 🔴", err)
+		return false
+	}
+	return window != nil && window.Allows(t)
+}
+
+// formatDigest는 요약 알림을 만듭니다. 미뤄진 알림을 먼저, 변화 없는 마켓(HOLD)을 나중에 표시합니다
+func formatDigest(entries []*digestEntry, now time.Time) Notification {
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i].notification, entries[j].notification
+		if (a.Type == TYPE_HOLD) != (b.Type == TYPE_HOLD) {
+			return b.Type == TYPE_HOLD
+		}
+		if a.Market != b.Market {
+			return a.Market < b.Market
+		}
+		return a.Time.Before(b.Time)
+	})
+
+	var alerts, quiet []string
+	for _, entry := range entries {
+		n := entry.notification
+		line := "• " + digestSummary(n)
+		if entry.count > 1 {
+			line += fmt.Sprintf(" (%d회)", entry.count)
+		}
+		line += " <i>" + n.Time.In(scheduler.KST).Format("15:04") + "</i>"
+
+		if n.Type == TYPE_HOLD {
+			quiet = append(quiet, line)
+		} else {
+			alerts = append(alerts, line)
+		}
+	}
+
+	var message []string
+	if len(alerts) > 0 {
+		message = append(message, "<b>🔔 알림</b>\n"+strings.Join(alerts, "\n"))
+	}
+	if len(quiet) > 0 {
+		message = append(message, "<b>😴 변화 없는 마켓</b>\n"+strings.Join(quiet, "\n"))
+	}
+
+	return Notification{
+		Type:     TYPE_DIGEST,
+		Severity: SEVERITY_INFO,
+		Title:    fmt.Sprintf("🗒️ 알림 요약 (%s)", now.In(scheduler.KST).Format("01-02 15:04")),
+		Message:  strings.Join(message, "\n\n"),
+		Time:     now,
+	}
+}
+
+// digestSummary는 요약 알림에 표시할 한 줄을 반환합니다
+func digestSummary(n Notification) string {
+	switch {
+	case n.Summary != "":
+		return n.Summary
+	case n.Title != "":
+		return n.Title
+	}
+	line, _, _ := strings.Cut(n.Message, "\n")
+	return html.EscapeString(toPlainText(line))
+}
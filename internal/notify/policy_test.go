@@ -0,0 +1,216 @@
+package notify
+
+import (
+	"go-trading-bot/config"
+	"go-trading-bot/internal/clock"
+	"go-trading-bot/internal/scheduler"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recorder는 받은 알림을 기록하는 Notifier입니다
+type recorder struct {
+	mu   sync.Mutex
+	sent []Notification
+}
+
+func (r *recorder) Name() string { return "recorder" }
+
+func (r *recorder) Notify(n Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sent = append(r.sent, n)
+	return nil
+}
+
+// take는 기록한 알림의 제목을 반환하고 비웁니다
+func (r *recorder) take() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	titles := make([]string, len(r.sent))
+	for i, n := range r.sent {
+		titles[i] = n.Title
+	}
+	r.sent = nil
+	return titles
+}
+
+// kst는 2025-01-06(월) KST 기준 시각입니다
+func kst(hour, minute int) time.Time {
+	return time.Date(2025, 1, 6, hour, minute, 0, 0, scheduler.KST)
+}
+
+// newTestPolicy는 조용한 시간 23:00-07:00(KST)과 rules로 설정한 Policy를 now에 멈춘 시계로 생성합니다
+func newTestPolicy(now time.Time, rules map[string]config.AlertRule) (*Policy, *recorder, *clock.Fake) {
+	next := &recorder{}
+	fake := clock.NewFake(now)
+	provider := config.NewStatic(&config.Config{}, &config.TradingConfig{AlertPolicy: config.AlertPolicy{
+		QuietHours: config.QuietHours{Enabled: true, Timezone: "Asia/Seoul", Hours: []string{"23:00-07:00"}},
+		DigestCron: "0 9 * * *",
+		Rules:      rules,
+	}})
+	return NewPolicyFromConfig(next, provider, fake), next, fake
+}
+
+func signal(title, market, state string) Notification {
+	return Notification{Type: TYPE_SIGNAL, Severity: SEVERITY_INFO, Title: title, Market: market, State: state}
+}
+
+func expectSent(t *testing.T, next *recorder, want ...string) {
+	t.Helper()
+	if got := next.take(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("sent %q, want %q", got, want)
+	}
+}
+
+func TestPolicySuppressRepeat(t *testing.T) {
+	p, next, _ := newTestPolicy(kst(12, 0), map[string]config.AlertRule{
+		string(TYPE_SIGNAL): {SuppressRepeat: true},
+	})
+
+	for _, n := range []Notification{
+		signal("btc buy", "KRW-BTC", "BUY"),
+		signal("btc buy again", "KRW-BTC", "BUY"), // 같은 마켓, 같은 상태
+		signal("eth buy", "KRW-ETH", "BUY"),       // 마켓마다 따로 봅니다
+		signal("btc sell", "KRW-BTC", "SELL"),
+		signal("btc buy later", "KRW-BTC", "BUY"),
+		signal("no state", "KRW-BTC", ""), // 상태가 없으면 억제하지 않습니다
+		signal("no state again", "KRW-BTC", ""),
+	} {
+		_ = p.Notify(n)
+	}
+	expectSent(t, next, "btc buy", "eth buy", "btc sell", "btc buy later", "no state", "no state again")
+
+	// 규칙이 없는 타입은 같은 상태도 보냅니다
+	_ = p.Notify(Notification{Type: TYPE_STAGE, Title: "stage", Market: "KRW-BTC", State: "STAGE_1"})
+	_ = p.Notify(Notification{Type: TYPE_STAGE, Title: "stage", Market: "KRW-BTC", State: "STAGE_1"})
+	expectSent(t, next, "stage", "stage")
+}
+
+func TestPolicyQuietHours(t *testing.T) {
+	tests := []struct {
+		action string
+		sent   []string // 03:00에 바로 전송된 알림
+		digest bool     // 09:00 요약에 포함되는지
+	}{
+		{QUIET_DIGEST, nil, true},
+		{QUIET_DROP, nil, false},
+		{QUIET_SEND, []string{"btc buy"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.action, func(t *testing.T) {
+			p, next, fake := newTestPolicy(kst(3, 0), map[string]config.AlertRule{
+				string(TYPE_SIGNAL): {QuietHours: tt.action},
+			})
+			_ = p.Notify(signal("btc buy", "KRW-BTC", "BUY"))
+			expectSent(t, next, tt.sent...)
+
+			fake.Set(kst(9, 0))
+			if err := p.SendDigest(); err != nil {
+				t.Fatalf("SendDigest: %v", err)
+			}
+			next.mu.Lock()
+			defer next.mu.Unlock()
+			if got := len(next.sent) == 1 && strings.Contains(next.sent[0].Message, "btc buy"); got != tt.digest {
+				t.Errorf("digest %+v, want btc buy included: %v", next.sent, tt.digest)
+			}
+		})
+	}
+}
+
+func TestPolicyQuietHoursBoundaries(t *testing.T) {
+	p, next, fake := newTestPolicy(kst(22, 59), map[string]config.AlertRule{
+		string(TYPE_SIGNAL): {QuietHours: QUIET_DROP},
+	})
+	for _, tt := range []struct {
+		at   time.Time
+		sent bool
+	}{
+		{kst(22, 59), true},
+		{kst(23, 0), false},
+		{kst(6, 59).AddDate(0, 0, 1), false},
+		{kst(7, 0).AddDate(0, 0, 1), true},
+	} {
+		fake.Set(tt.at)
+		_ = p.Notify(signal("btc buy", "KRW-BTC", ""))
+		if got := len(next.take()) == 1; got != tt.sent {
+			t.Errorf("%v: sent %v, want %v", tt.at, got, tt.sent)
+		}
+	}
+}
+
+func TestPolicyCriticalBypassesQuietHours(t *testing.T) {
+	p, next, _ := newTestPolicy(kst(3, 0), map[string]config.AlertRule{
+		string(TYPE_SYSTEM): {QuietHours: QUIET_DROP},
+		string(TYPE_RISK):   {Mode: MODE_OFF},
+	})
+
+	_ = p.Notify(Notification{Type: TYPE_SYSTEM, Severity: SEVERITY_WARNING, Title: "warning"})
+	_ = p.Notify(Notification{Type: TYPE_SYSTEM, Severity: SEVERITY_CRITICAL, Title: "critical"})
+	// off는 critical도 보내지 않습니다
+	_ = p.Notify(Notification{Type: TYPE_RISK, Severity: SEVERITY_CRITICAL, Title: "risk"})
+	expectSent(t, next, "critical")
+}
+
+func TestPolicyDigestDeferredDuringQuietHours(t *testing.T) {
+	p, next, fake := newTestPolicy(kst(20, 0), nil)
+
+	// HOLD는 기본 규칙으로 요약에 모읍니다. 같은 마켓은 최신 알림으로 바꾸고 횟수만 셉니다
+	_ = p.Notify(Notification{Type: TYPE_HOLD, Title: "btc hold 1", Market: "KRW-BTC"})
+	fake.Set(kst(21, 0))
+	_ = p.Notify(Notification{Type: TYPE_HOLD, Title: "btc hold 2", Market: "KRW-BTC"})
+	_ = p.Notify(Notification{Type: TYPE_HOLD, Title: "eth hold", Market: "KRW-ETH"})
+	expectSent(t, next)
+
+	// 조용한 시간에는 요약도 미룹니다
+	fake.Set(kst(23, 30))
+	if err := p.SendDigest(); err != nil {
+		t.Fatalf("SendDigest: %v", err)
+	}
+	expectSent(t, next)
+
+	fake.Set(kst(9, 0).AddDate(0, 0, 1))
+	_ = p.SendDigest()
+	next.mu.Lock()
+	sent := append([]Notification(nil), next.sent...)
+	next.mu.Unlock()
+	if len(sent) != 1 || sent[0].Type != TYPE_DIGEST {
+		t.Fatalf("sent %+v, want one digest", sent)
+	}
+	if want := "• btc hold 2 (2회) <i>21:00</i>\n• eth hold <i>21:00</i>"; !strings.Contains(sent[0].Message, want) {
+		t.Errorf("digest = %q, want %q", sent[0].Message, want)
+	}
+	next.take()
+
+	// 보낸 항목은 비웁니다
+	_ = p.SendDigest()
+	expectSent(t, next)
+}
+
+func TestPolicySuppressRepeatAfterDeferredState(t *testing.T) {
+	p, next, fake := newTestPolicy(kst(3, 0), map[string]config.AlertRule{
+		string(TYPE_SIGNAL): {SuppressRepeat: true},
+	})
+
+	// 조용한 시간에 요약으로 미룬 상태는 아직 보낸 것이 아니므로, 조용한 시간이 끝나면 바로 보냅니다
+	_ = p.Notify(signal("btc buy", "KRW-BTC", "BUY"))
+	_ = p.Notify(signal("btc buy again", "KRW-BTC", "BUY"))
+	expectSent(t, next)
+	fake.Set(kst(7, 30))
+	_ = p.Notify(signal("btc buy after quiet", "KRW-BTC", "BUY"))
+	_ = p.Notify(signal("btc buy repeat", "KRW-BTC", "BUY"))
+	expectSent(t, next, "btc buy after quiet")
+
+	// 요약으로 보낸 상태는 다시 보내지 않습니다
+	fake.Set(kst(3, 0).AddDate(0, 0, 1))
+	_ = p.Notify(signal("eth sell", "KRW-ETH", "SELL"))
+	fake.Set(kst(9, 0).AddDate(0, 0, 1))
+	_ = p.SendDigest()
+	if sent := next.take(); len(sent) != 1 {
+		t.Fatalf("sent %q, want one digest", sent)
+	}
+	_ = p.Notify(signal("eth sell repeat", "KRW-ETH", "SELL"))
+	expectSent(t, next)
+}
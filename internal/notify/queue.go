@@ -0,0 +1,238 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-trading-bot/internal/clock"
+	"go-trading-bot/internal/logger"
+	"go-trading-bot/internal/metrics"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrQueueFull   = errors.New("notification queue is full")
+	ErrQueueClosed = errors.New("notification queue is closed")
+)
+
+// DeliveryError는 알림 채널이 오류 상태 코드로 응답한 경우입니다
+type DeliveryError struct {
+	Sink       string
+	StatusCode int
+	RetryAfter time.Duration // 429 응답의 재시도 대기 시간 (텔레그램 retry_after, Retry-After 헤더)
+	Message    string
+}
+
+func (e *DeliveryError) Error() string {
+	return fmt.Sprintf("%s: status code %d: %s", e.Sink, e.StatusCode, e.Message)
+}
+
+// Temporary는 재시도로 해결될 수 있는 오류(429, 5xx)인지 반환합니다
+func (e *DeliveryError) Temporary() bool {
+	return e.StatusCode == 429 || e.StatusCode >= 500
+}
+
+// Splitter는 채널의 메시지 길이 제한에 맞춰 알림을 나누는 Notifier입니다
+type Splitter interface {
+	Split(n Notification) []Notification
+}
+
+// Flusher는 대기 중인 알림을 모두 전송하는 Notifier입니다. 종료 시 호출합니다
+type Flusher interface {
+	Flush(ctx context.Context) error
+}
+
+// QueueOptions는 전송 큐 설정입니다
+type QueueOptions struct {
+	Size           int           // 대기 가능한 알림 수, 가득 차면 dead-letter로 보냄
+	MaxAttempts    int           // 최대 전송 시도 횟수
+	InitialBackoff time.Duration // 첫 재시도 대기 시간, 이후 두 배씩 증가
+	MaxBackoff     time.Duration
+	Clock          clock.Clock // 기본값: clock.Real (재시도 대기, 전송 시각 기록에 사용)
+}
+
+// DefaultQueueOptions는 기본 전송 큐 설정입니다
+var DefaultQueueOptions = QueueOptions{
+	Size:           256,
+	MaxAttempts:    5,
+	InitialBackoff: time.Second,
+	MaxBackoff:     time.Minute,
+}
+
+// Queue는 채널 앞에 두는 전송 큐입니다. Notify는 알림을 큐에 넣고 바로 반환하며,
+// 별도 고루틴이 순서대로 전송합니다. 실패하면 backoff로 재시도하고(429는 retry_after만큼 대기),
+// 끝내 전송하지 못한 알림은 dead-letter 로그에 남깁니다
+type Queue struct {
+	sink Notifier
+	opts QueueOptions
+
+	mu     sync.RWMutex
+	closed bool
+	items  chan Notification
+
+	abortOnce sync.Once
+	abort     chan struct{}
+	done      chan struct{}
+
+	statusMu sync.Mutex
+	status   SinkStatus
+}
+
+// SinkStatus는 채널의 전송 상태입니다
+type SinkStatus struct {
+	Name        string
+	Pending     int // 전송 대기 중인 알림 수
+	DeadLetters int64
+	LastSuccess time.Time
+	LastError   string
+	LastErrorAt time.Time
+}
+
+// StatusReporter는 채널별 전송 상태를 반환하는 Notifier입니다
+type StatusReporter interface {
+	Status() []SinkStatus
+}
+
+// NewQueue는 sink로 전송하는 큐를 생성하고 전송 고루틴을 시작합니다
+func NewQueue(sink Notifier, opts QueueOptions) *Queue {
+	if opts.Clock == nil {
+		opts.Clock = clock.Real
+	}
+	q := &Queue{
+		sink:  sink,
+		opts:  opts,
+		items: make(chan Notification, opts.Size),
+		abort: make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	go q.run()
+	return q
+}
+
+func (q *Queue) Name() string { return q.sink.Name() }
+
+// Status는 큐의 전송 상태를 반환합니다
+func (q *Queue) Status() []SinkStatus {
+	q.statusMu.Lock()
+	status := q.status
+	q.statusMu.Unlock()
+
+	status.Name = q.sink.Name()
+	status.Pending = len(q.items)
+	return []SinkStatus{status}
+}
+
+// Notify는 알림을 큐에 넣습니다. 채널이 Splitter면 길이 제한에 맞게 나눠 넣습니다
+func (q *Queue) Notify(n Notification) error {
+	parts := []Notification{n}
+	if splitter, ok := q.sink.(Splitter); ok {
+		parts = splitter.Split(n)
+	}
+
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.closed {
+		q.deadLetter(n, 0, ErrQueueClosed)
+		return ErrQueueClosed
+	}
+
+	for _, part := range parts {
+		select {
+		case q.items <- part:
+		default:
+			q.deadLetter(part, 0, ErrQueueFull)
+		}
+	}
+	return nil
+}
+
+// Flush는 새 알림을 받지 않고 대기 중인 알림을 모두 전송할 때까지 기다립니다.
+// ctx가 끝나면 남은 알림은 전송하지 않고 dead-letter 로그에 남깁니다
+func (q *Queue) Flush(ctx context.Context) error {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.items)
+	}
+	q.mu.Unlock()
+
+	select {
+	case <-q.done:
+		return nil
+	case <-ctx.Done():
+		q.abortOnce.Do(func() { close(q.abort) })
+		<-q.done
+		return fmt.Errorf("%s: %w", q.sink.Name(), ctx.Err())
+	}
+}
+
+func (q *Queue) run() {
+	defer close(q.done)
+	for n := range q.items {
+		select {
+		case <-q.abort:
+			q.deadLetter(n, 0, ErrQueueClosed)
+		default:
+			q.deliver(n)
+		}
+	}
+}
+
+func (q *Queue) deliver(n Notification) {
+	backoff := q.opts.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		err := q.sink.Notify(n)
+		q.statusMu.Lock()
+		if err == nil {
+			q.status.LastSuccess = q.opts.Clock.Now()
+		} else {
+			q.status.LastError = err.Error()
+			q.status.LastErrorAt = q.opts.Clock.Now()
+		}
+		q.statusMu.Unlock()
+		if err == nil {
+			return
+		}
+		metrics.NotificationFailures.Inc(q.sink.Name())
+
+		var deliveryErr *DeliveryError
+		permanent := errors.As(err, &deliveryErr) && !deliveryErr.Temporary()
+		if permanent || attempt >= q.opts.MaxAttempts {
+			q.deadLetter(n, attempt, err)
+			return
+		}
+
+		wait := backoff
+		if deliveryErr != nil && deliveryErr.RetryAfter > 0 {
+			wait = deliveryErr.RetryAfter
+		}
+		backoff = min(backoff*2, q.opts.MaxBackoff)
+		logger.Log.Warnf("[%v] 알림 전송 실패, %v 후 재시도합니다. (%d/%d) %v 🟠", q.sink.Name(), wait, attempt, q.opts.MaxAttempts, err)
+
+		select {
+		case <-q.opts.Clock.After(wait):
+		case <-q.abort:
+			q.deadLetter(n, attempt, err)
+			return
+		}
+	}
+}
+
+func (q *Queue) deadLetter(n Notification, attempts int, err error) {
+	metrics.NotificationDeadLetters.Inc(q.sink.Name())
+	q.statusMu.Lock()
+	q.status.DeadLetters++
+	q.statusMu.Unlock()
+	logger.DeadLetter(q.sink.Name(), logrus.Fields{
+		"type":        n.Type,
+		"severity":    n.Severity,
+		"market":      n.Market,
+		"title":       n.Title,
+		"message":     n.Message,
+		"notified_at": n.Time,
+		"attempts":    attempts,
+	}, err)
+}
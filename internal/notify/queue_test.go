@@ -0,0 +1,277 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"go-trading-bot/internal/clock"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+var queueStart = time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+
+var errUnavailable = errors.New("sink unavailable")
+
+// scriptedSink는 전송마다 errs의 결과를 순서대로 돌려주는 Notifier입니다. errs를 다 쓰면 성공합니다
+type scriptedSink struct {
+	mu     sync.Mutex
+	errs   []error
+	always error         // 있으면 errs 대신 항상 이 오류를 돌려줍니다
+	block  chan struct{} // 있으면 닫힐 때까지 전송을 멈춥니다
+	calls  chan Notification
+}
+
+func newScriptedSink(errs ...error) *scriptedSink {
+	return &scriptedSink{errs: errs, calls: make(chan Notification, 16)}
+}
+
+func (s *scriptedSink) Name() string { return "scripted" }
+
+func (s *scriptedSink) Notify(n Notification) error {
+	s.calls <- n
+	if s.block != nil {
+		<-s.block
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.always != nil {
+		return s.always
+	}
+	if len(s.errs) == 0 {
+		return nil
+	}
+	err := s.errs[0]
+	s.errs = s.errs[1:]
+	return err
+}
+
+// waitCall은 다음 전송 시도를 기다립니다
+func (s *scriptedSink) waitCall(t *testing.T) Notification {
+	t.Helper()
+	select {
+	case n := <-s.calls:
+		return n
+	case <-time.After(time.Second):
+		t.Fatal("no delivery attempt")
+		return Notification{}
+	}
+}
+
+// expectNoCall은 전송 시도가 없었는지 확인합니다
+func (s *scriptedSink) expectNoCall(t *testing.T) {
+	t.Helper()
+	select {
+	case n := <-s.calls:
+		t.Fatalf("unexpected delivery attempt: %+v", n)
+	default:
+	}
+}
+
+func testQueueOptions(fake *clock.Fake) QueueOptions {
+	return QueueOptions{Size: 8, MaxAttempts: 5, InitialBackoff: time.Second, MaxBackoff: 3 * time.Second, Clock: fake}
+}
+
+// waitRetry는 재시도 대기가 시작되면 wait 직전까지 시각을 옮겨 재시도하지 않는지 확인하고, wait가 지나면 재시도를 기다립니다
+func waitRetry(t *testing.T, fake *clock.Fake, sink *scriptedSink, wait time.Duration) {
+	t.Helper()
+	fake.BlockUntilTimers(1)
+	fake.Advance(wait - time.Millisecond)
+	sink.expectNoCall(t)
+	fake.Advance(time.Millisecond)
+	sink.waitCall(t)
+}
+
+func flush(t *testing.T, q *Queue) {
+	t.Helper()
+	if err := q.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+}
+
+func TestQueueRetriesWithBackoff(t *testing.T) {
+	fake := clock.NewFake(queueStart)
+	sink := newScriptedSink(errUnavailable, errUnavailable, errUnavailable, errUnavailable)
+	q := NewQueue(sink, testQueueOptions(fake))
+
+	if err := q.Notify(Notification{Title: "buy"}); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	sink.waitCall(t)
+	// 1초부터 두 배씩, MaxBackoff 3초에서 멈춥니다
+	for _, wait := range []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second} {
+		waitRetry(t, fake, sink, wait)
+	}
+	flush(t, q)
+
+	status := q.Status()[0]
+	if !status.LastSuccess.Equal(queueStart.Add(9*time.Second)) || status.DeadLetters != 0 {
+		t.Errorf("status = %+v, want delivered at +9s without dead letters", status)
+	}
+	if status.LastError != errUnavailable.Error() || !status.LastErrorAt.Equal(queueStart.Add(6*time.Second)) {
+		t.Errorf("last error = %q at %v, want the fourth failure at +6s", status.LastError, status.LastErrorAt)
+	}
+}
+
+func TestQueueDeadLettersAfterMaxAttempts(t *testing.T) {
+	fake := clock.NewFake(queueStart)
+	sink := newScriptedSink()
+	sink.always = errUnavailable
+	opts := testQueueOptions(fake)
+	opts.MaxAttempts = 3
+	q := NewQueue(sink, opts)
+
+	_ = q.Notify(Notification{Title: "buy"})
+	sink.waitCall(t)
+	waitRetry(t, fake, sink, time.Second)
+	waitRetry(t, fake, sink, 2*time.Second)
+	flush(t, q)
+
+	sink.expectNoCall(t)
+	if status := q.Status()[0]; status.DeadLetters != 1 || !status.LastSuccess.IsZero() {
+		t.Errorf("status = %+v, want one dead letter after 3 attempts", status)
+	}
+}
+
+func TestQueueDoesNotRetryPermanentErrors(t *testing.T) {
+	fake := clock.NewFake(queueStart)
+	sink := newScriptedSink(&DeliveryError{Sink: "scripted", StatusCode: http.StatusBadRequest, Message: "chat not found"})
+	q := NewQueue(sink, testQueueOptions(fake))
+
+	_ = q.Notify(Notification{Title: "buy"})
+	sink.waitCall(t)
+	flush(t, q)
+
+	sink.expectNoCall(t)
+	if status := q.Status()[0]; status.DeadLetters != 1 {
+		t.Errorf("dead letters = %d, want 1 without retrying a 400", status.DeadLetters)
+	}
+}
+
+func TestQueueHonorsRetryAfterHeader(t *testing.T) {
+	sinks := map[string]func(url string, client *http.Client) Notifier{
+		"slack": func(url string, client *http.Client) Notifier {
+			return &SlackNotifier{WebhookURL: url, Client: client}
+		},
+		"discord": func(url string, client *http.Client) Notifier {
+			return &DiscordNotifier{WebhookURL: url, Client: client}
+		},
+		"webhook": func(url string, client *http.Client) Notifier {
+			return &WebhookNotifier{URL: url, Client: client}
+		},
+	}
+	for name, newSink := range sinks {
+		t.Run(name, func(t *testing.T) {
+			requests := make(chan struct{}, 4)
+			var mu sync.Mutex
+			limited := true
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				if limited {
+					limited = false
+					w.Header().Set("Retry-After", "7")
+					w.WriteHeader(http.StatusTooManyRequests)
+				}
+				requests <- struct{}{}
+			}))
+			defer server.Close()
+
+			fake := clock.NewFake(queueStart)
+			q := NewQueue(newSink(server.URL, server.Client()), testQueueOptions(fake))
+			_ = q.Notify(Notification{Title: "buy"})
+			<-requests
+
+			// InitialBackoff(1초)가 아니라 Retry-After(7초)만큼 기다립니다
+			fake.BlockUntilTimers(1)
+			fake.Advance(6 * time.Second)
+			select {
+			case <-requests:
+				t.Fatal("retried before Retry-After")
+			case <-time.After(10 * time.Millisecond):
+			}
+			fake.Advance(time.Second)
+			select {
+			case <-requests:
+			case <-time.After(time.Second):
+				t.Fatal("no retry after Retry-After")
+			}
+			flush(t, q)
+
+			if status := q.Status()[0]; status.DeadLetters != 0 || !status.LastSuccess.Equal(queueStart.Add(7*time.Second)) {
+				t.Errorf("status = %+v, want delivered at +7s", status)
+			}
+		})
+	}
+}
+
+func TestQueueFullDeadLetters(t *testing.T) {
+	fake := clock.NewFake(queueStart)
+	sink := newScriptedSink()
+	sink.block = make(chan struct{})
+	opts := testQueueOptions(fake)
+	opts.Size = 1
+	q := NewQueue(sink, opts)
+
+	// 첫 알림은 전송 중, 두 번째는 대기, 세 번째는 큐가 가득 차 dead-letter로 보냅니다
+	_ = q.Notify(Notification{Title: "first"})
+	sink.waitCall(t)
+	_ = q.Notify(Notification{Title: "second"})
+	_ = q.Notify(Notification{Title: "third"})
+	if status := q.Status()[0]; status.Pending != 1 || status.DeadLetters != 1 {
+		t.Errorf("status = %+v, want 1 pending and 1 dead letter", status)
+	}
+
+	close(sink.block)
+	if n := sink.waitCall(t); n.Title != "second" {
+		t.Errorf("delivered %q, want second", n.Title)
+	}
+	flush(t, q)
+	sink.expectNoCall(t)
+}
+
+func TestQueueFlushDeliversPending(t *testing.T) {
+	sink := newScriptedSink()
+	q := NewQueue(sink, testQueueOptions(clock.NewFake(queueStart)))
+	for _, title := range []string{"a", "b", "c"} {
+		_ = q.Notify(Notification{Title: title})
+	}
+	flush(t, q)
+
+	for _, want := range []string{"a", "b", "c"} {
+		if n := sink.waitCall(t); n.Title != want {
+			t.Errorf("delivered %q, want %q", n.Title, want)
+		}
+	}
+	if err := q.Notify(Notification{Title: "late"}); !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("Notify after Flush = %v, want ErrQueueClosed", err)
+	}
+	if status := q.Status()[0]; status.DeadLetters != 1 {
+		t.Errorf("dead letters = %d, want the late notification", status.DeadLetters)
+	}
+}
+
+func TestQueueFlushCancelled(t *testing.T) {
+	fake := clock.NewFake(queueStart)
+	sink := newScriptedSink()
+	sink.always = errUnavailable
+	q := NewQueue(sink, testQueueOptions(fake))
+
+	_ = q.Notify(Notification{Title: "a"})
+	_ = q.Notify(Notification{Title: "b"})
+	sink.waitCall(t)
+	fake.BlockUntilTimers(1)
+
+	// 재시도 대기 중에 ctx가 끝나면 남은 알림을 전송하지 않고 dead-letter로 보냅니다
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := q.Flush(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Flush = %v, want context.Canceled", err)
+	}
+	sink.expectNoCall(t)
+	if status := q.Status()[0]; status.DeadLetters != 2 || status.Pending != 0 {
+		t.Errorf("status = %+v, want both notifications dead-lettered", status)
+	}
+}
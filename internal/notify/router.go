@@ -0,0 +1,187 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"go-trading-bot/config"
+	"go-trading-bot/internal/logger"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Sink 이름 (notification.routes[].sink)
+const (
+	SINK_TELEGRAM = "telegram"
+	SINK_SLACK    = "slack"
+	SINK_DISCORD  = "discord"
+	SINK_EMAIL    = "email"
+	SINK_WEBHOOK  = "webhook"
+)
+
+// Sinks는 지원하는 모든 알림 채널 이름입니다
+var Sinks = []string{SINK_TELEGRAM, SINK_SLACK, SINK_DISCORD, SINK_EMAIL, SINK_WEBHOOK}
+
+// Router는 라우팅 규칙에 따라 알림을 채널로 전달하는 Notifier입니다.
+// 규칙은 전송할 때마다 routes()로 읽으므로 설정 리로드가 바로 반영됩니다
+type Router struct {
+	sinks  map[string]Notifier
+	routes func() []config.NotificationRoute
+}
+
+// NewRouter는 채널 목록과 라우팅 규칙으로 Router를 생성합니다
+func NewRouter(sinks []Notifier, routes func() []config.NotificationRoute) *Router {
+	r := &Router{sinks: make(map[string]Notifier, len(sinks)), routes: routes}
+	for _, sink := range sinks {
+		r.sinks[sink.Name()] = sink
+	}
+	return r
+}
+
+// NewRouterFromConfig는 provider의 환경 변수로 설정된 채널과 application.json의 notification.routes로 Router를 생성합니다.
+// 각 채널은 재시도와 메시지 분할을 처리하는 전송 큐(Queue)를 거칩니다
+func NewRouterFromConfig(provider config.Provider) *Router {
+	var sinks []Notifier
+	for _, sink := range SinksFromConfig(provider.Config()) {
+		sinks = append(sinks, NewQueue(sink, DefaultQueueOptions))
+	}
+
+	router := NewRouter(sinks, func() []config.NotificationRoute {
+		if tc := provider.TradingConfig(); tc != nil {
+			return tc.Notification.Routes
+		}
+		return nil
+	})
+
+	names := router.SinkNames()
+	if len(names) == 0 {
+		logger.Log.Warn("설정된 알림 채널이 없습니다. 알림이 전송되지 않습니다. 🟠")
+	} else {
+		logger.Log.Infof("알림 채널: %v 🟢", strings.Join(names, ", "))
+	}
+	return router
+}
+
+// SinksFromConfig는 환경 변수가 설정된 알림 채널을 생성합니다
+func SinksFromConfig(c *config.Config) []Notifier {
+	var sinks []Notifier
+	if c.TelegramSend == "OK" && c.TelegramBotToken != "" && c.TelegramChatID != "" {
+		sinks = append(sinks, &TelegramNotifier{BaseURL: c.TelegramAPIUrl, Token: c.TelegramBotToken, ChatID: c.TelegramChatID})
+	}
+	if c.SlackWebhookURL != "" {
+		sinks = append(sinks, &SlackNotifier{WebhookURL: c.SlackWebhookURL})
+	}
+	if c.DiscordWebhookURL != "" {
+		sinks = append(sinks, &DiscordNotifier{WebhookURL: c.DiscordWebhookURL})
+	}
+	if c.SMTPHost != "" && c.SMTPFrom != "" && c.SMTPTo != "" {
+		var to []string
+		for _, addr := range strings.Split(c.SMTPTo, ",") {
+			if addr = strings.TrimSpace(addr); addr != "" {
+				to = append(to, addr)
+			}
+		}
+		sinks = append(sinks, &EmailNotifier{Host: c.SMTPHost, Port: c.SMTPPort, Username: c.SMTPUsername, Password: c.SMTPPassword, From: c.SMTPFrom, To: to})
+	}
+	if c.NotifyWebhookURL != "" {
+		sinks = append(sinks, &WebhookNotifier{URL: c.NotifyWebhookURL, Secret: c.NotifyWebhookSecret})
+	}
+	return sinks
+}
+
+func (r *Router) Name() string { return "router" }
+
+// SinkNames는 설정된 채널 이름을 정렬해 반환합니다
+func (r *Router) SinkNames() []string {
+	names := make([]string, 0, len(r.sinks))
+	for name := range r.sinks {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Notify는 규칙에 맞는 모든 채널로 알림을 전송합니다. 규칙이 없으면 모든 채널로 전송합니다.
+// 여러 규칙이 같은 채널을 가리켜도 한 번만 전송합니다
+func (r *Router) Notify(n Notification) error {
+	if n.Severity == "" {
+		n.Severity = SEVERITY_INFO
+	}
+	if n.Time.IsZero() {
+		n.Time = time.Now()
+	}
+
+	var errs []error
+	for _, name := range r.match(n) {
+		sink, exists := r.sinks[name]
+		if !exists {
+			continue
+		}
+		if err := sink.Notify(n); err != nil {
+			logger.Log.Errorf("[%v] 알림 전송 실패: %v 🔴", name, err)
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Status는 채널별 전송 상태를 반환합니다. 전송 큐를 거치지 않는 채널은 이름만 채웁니다
+func (r *Router) Status() []SinkStatus {
+	statuses := make([]SinkStatus, 0, len(r.sinks))
+	for _, sink := range r.sinks {
+		if reporter, ok := sink.(StatusReporter); ok {
+			statuses = append(statuses, reporter.Status()...)
+		} else {
+			statuses = append(statuses, SinkStatus{Name: sink.Name()})
+		}
+	}
+	return statuses
+}
+
+// Flush는 모든 채널의 대기 중인 알림을 전송할 때까지 기다립니다
+func (r *Router) Flush(ctx context.Context) error {
+	var wg sync.WaitGroup
+	errs := make([]error, 0, len(r.sinks))
+	var mu sync.Mutex
+	for _, sink := range r.sinks {
+		flusher, ok := sink.(Flusher)
+		if !ok {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := flusher.Flush(ctx); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// match는 알림을 전송할 채널 이름을 반환합니다
+func (r *Router) match(n Notification) []string {
+	routes := r.routes()
+	if len(routes) == 0 {
+		return r.SinkNames()
+	}
+
+	var names []string
+	for _, route := range routes {
+		if slices.Contains(names, route.Sink) {
+			continue
+		}
+		if len(route.Types) > 0 && !slices.Contains(route.Types, string(n.Type)) {
+			continue
+		}
+		if n.Severity.Rank() < Severity(route.MinSeverity).Rank() {
+			continue
+		}
+		names = append(names, route.Sink)
+	}
+	return names
+}
@@ -0,0 +1,143 @@
+package notify
+
+import (
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// splitReserve는 조각 끝에 닫는 태그, 다음 조각 앞에 다시 여는 태그를 붙일 여유 공간입니다
+const splitReserve = 100
+
+// SplitHTML은 텔레그램 HTML 메시지를 limit(UTF-16 코드 단위) 이하의 조각으로 나눕니다.
+// 빈 줄 > 줄바꿈 > 공백 순으로 자를 위치를 고르며, 태그나 엔티티(&lt; 등) 중간에서는 자르지 않습니다.
+// 조각 경계에서 열려 있는 태그는 닫고, 다음 조각에서 다시 엽니다
+func SplitHTML(message string, limit int) []string {
+	if utf16Len(message) <= limit {
+		return []string{message}
+	}
+
+	budget := max(limit-splitReserve, limit/2)
+	tokens := tokenizeHTML(message)
+
+	var parts []string
+	var prefix string
+	for len(tokens) > 0 {
+		cut := splitPoint(tokens, budget-utf16Len(prefix))
+		body := strings.TrimRight(prefix+strings.Join(tokens[:cut], ""), " \n")
+
+		stack := openTags(body)
+		var closing, reopen strings.Builder
+		for i := len(stack) - 1; i >= 0; i-- {
+			closing.WriteString("</" + stack[i].name + ">")
+		}
+		for _, tag := range stack {
+			reopen.WriteString(tag.raw)
+		}
+
+		parts = append(parts, body+closing.String())
+		prefix = reopen.String()
+
+		tokens = tokens[cut:]
+		for len(tokens) > 0 && (tokens[0] == "\n" || tokens[0] == " ") {
+			tokens = tokens[1:]
+		}
+	}
+	return parts
+}
+
+// splitPoint는 budget 안에서 자를 토큰 위치를 반환합니다. 뒤쪽 절반 안에 있는 가장 좋은 경계를 우선합니다
+func splitPoint(tokens []string, budget int) int {
+	var lastBreak [4]int // 순위별 마지막 경계 위치 (1: 공백, 2: 줄바꿈, 3: 빈 줄)
+	size := 0
+	for i, token := range tokens {
+		size += utf16Len(token)
+		if size > budget {
+			if i == 0 {
+				return 1
+			}
+			for rank := 3; rank >= 1; rank-- {
+				if lastBreak[rank] >= i/2 && lastBreak[rank] > 0 {
+					return lastBreak[rank]
+				}
+			}
+			for rank := 3; rank >= 1; rank-- {
+				if lastBreak[rank] > 0 {
+					return lastBreak[rank]
+				}
+			}
+			return i
+		}
+
+		switch {
+		case token == "\n" && i > 0 && tokens[i-1] == "\n":
+			lastBreak[3] = i + 1
+		case token == "\n":
+			lastBreak[2] = i + 1
+		case token == " ":
+			lastBreak[1] = i + 1
+		}
+	}
+	return len(tokens)
+}
+
+// tokenizeHTML은 메시지를 태그, 엔티티, 문자 단위 토큰으로 나눕니다
+func tokenizeHTML(message string) []string {
+	tokens := make([]string, 0, len(message))
+	for i := 0; i < len(message); {
+		switch message[i] {
+		case '<':
+			if end := strings.IndexByte(message[i:], '>'); end > 0 {
+				tokens = append(tokens, message[i:i+end+1])
+				i += end + 1
+				continue
+			}
+		case '&':
+			if end := strings.IndexByte(message[i:], ';'); end > 0 && end <= 10 {
+				tokens = append(tokens, message[i:i+end+1])
+				i += end + 1
+				continue
+			}
+		}
+		_, size := utf8.DecodeRuneInString(message[i:])
+		tokens = append(tokens, message[i:i+size])
+		i += size
+	}
+	return tokens
+}
+
+type htmlTag struct {
+	name string
+	raw  string
+}
+
+// openTags는 message 끝에서 닫히지 않은 태그를 여는 순서대로 반환합니다
+func openTags(message string) []htmlTag {
+	var stack []htmlTag
+	for _, token := range tokenizeHTML(message) {
+		if len(token) < 3 || token[0] != '<' {
+			continue
+		}
+		if token[1] == '/' {
+			name := strings.TrimSpace(token[2 : len(token)-1])
+			for i := len(stack) - 1; i >= 0; i-- {
+				if stack[i].name == name {
+					stack = stack[:i]
+					break
+				}
+			}
+			continue
+		}
+		name, _, _ := strings.Cut(token[1:len(token)-1], " ")
+		stack = append(stack, htmlTag{name: name, raw: token})
+	}
+	return stack
+}
+
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		n += utf16.RuneLen(r)
+	}
+	return n
+}
@@ -0,0 +1,85 @@
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// telegramMaxLength는 텔레그램 메시지 최대 길이입니다
+const telegramMaxLength = 4096
+
+// TelegramNotifier는 텔레그램 Bot API sendMessage로 알림을 전송합니다
+type TelegramNotifier struct {
+	BaseURL string // 예: https://api.telegram.org
+	Token   string
+	ChatID  string
+	Client  *http.Client
+}
+
+func (t *TelegramNotifier) Name() string { return "telegram" }
+
+// Split은 4096자를 넘는 메시지를 태그가 깨지지 않게 나눕니다. 제목은 첫 조각에만 붙습니다
+func (t *TelegramNotifier) Split(n Notification) []Notification {
+	return splitNotification(n, telegramMaxLength)
+}
+
+func (t *TelegramNotifier) Notify(n Notification) error {
+	text := n.Message
+	if n.Title != "" {
+		text = titled("<b>"+n.Title+"</b>", n.Message)
+	}
+
+	data := url.Values{}
+	data.Set("chat_id", t.ChatID)
+	data.Set("text", text)
+	data.Set("parse_mode", "HTML")
+
+	resp, err := httpClient(t.Client).PostForm(fmt.Sprintf("%s/bot%s/sendMessage", t.BaseURL, t.Token), data)
+	if err != nil {
+		// 오류 메시지에 토큰이 포함된 URL이 노출되지 않도록 합니다
+		return fmt.Errorf("telegram: request failed")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	var response struct {
+		Description string `json:"description"`
+		Parameters  struct {
+			RetryAfter int `json:"retry_after"`
+		} `json:"parameters"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&response)
+	return &DeliveryError{
+		Sink:       t.Name(),
+		StatusCode: resp.StatusCode,
+		RetryAfter: time.Duration(response.Parameters.RetryAfter) * time.Second,
+		Message:    response.Description,
+	}
+}
+
+// splitNotification은 제목을 포함한 메시지를 limit 이하의 알림 여러 개로 나눕니다
+func splitNotification(n Notification, limit int) []Notification {
+	text := n.Message
+	if n.Title != "" {
+		text = titled("<b>"+n.Title+"</b>", n.Message)
+	}
+	chunks := SplitHTML(text, limit)
+	if len(chunks) == 1 {
+		return []Notification{n}
+	}
+
+	parts := make([]Notification, 0, len(chunks))
+	for _, chunk := range chunks {
+		part := n
+		part.Title = ""
+		part.Message = chunk
+		parts = append(parts, part)
+	}
+	return parts
+}
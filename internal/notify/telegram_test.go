@@ -0,0 +1,154 @@
+package notify
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// telegramServer는 sendMessage로 받은 text를 기록하는 Bot API 대체 서버입니다
+type telegramServer struct {
+	*httptest.Server
+	mu    sync.Mutex
+	texts []string
+}
+
+func newTelegramServer(t *testing.T, handle func(w http.ResponseWriter, r *http.Request)) *telegramServer {
+	t.Helper()
+	s := &telegramServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/bottest-token/sendMessage" {
+			http.NotFound(w, r)
+			return
+		}
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("parse_mode") != "HTML" || r.PostForm.Get("chat_id") != "42" {
+			t.Errorf("unexpected sendMessage form %v (%v)", r.PostForm, err)
+		}
+		s.mu.Lock()
+		s.texts = append(s.texts, r.PostForm.Get("text"))
+		s.mu.Unlock()
+		handle(w, r)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *telegramServer) notifier() *TelegramNotifier {
+	return &TelegramNotifier{BaseURL: s.URL, Token: "test-token", ChatID: "42", Client: s.Client()}
+}
+
+func ok(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(`{"ok":true,"result":{}}`))
+}
+
+// send는 Split으로 나눈 조각을 모두 전송하고 서버가 받은 text를 반환합니다
+func send(t *testing.T, n Notification) []string {
+	t.Helper()
+	server := newTelegramServer(t, ok)
+	notifier := server.notifier()
+	for _, part := range notifier.Split(n) {
+		if err := notifier.Notify(part); err != nil {
+			t.Fatalf("Notify: %v", err)
+		}
+	}
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	return server.texts
+}
+
+func TestTelegramSplitBoundary(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		parts   int
+	}{
+		{"exactly the limit", strings.Repeat("a", telegramMaxLength), 1},
+		{"one over the limit", strings.Repeat("a", telegramMaxLength+1), 2},
+		// 이모지는 UTF-16 코드 단위 2개입니다
+		{"surrogate pairs at the limit", strings.Repeat("📈", telegramMaxLength/2), 1},
+		{"surrogate pairs over the limit", strings.Repeat("📈", telegramMaxLength/2+1), 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			texts := send(t, Notification{Message: tt.message})
+			if len(texts) != tt.parts {
+				t.Fatalf("sent %d messages, want %d", len(texts), tt.parts)
+			}
+			if joined := strings.Join(texts, ""); joined != tt.message {
+				t.Errorf("split lost or changed text: %d runes sent, want %d", len([]rune(joined)), len([]rune(tt.message)))
+			}
+			for i, text := range texts {
+				if n := utf16Len(text); n > telegramMaxLength {
+					t.Errorf("part %d is %d UTF-16 units, over %d", i, n, telegramMaxLength)
+				}
+			}
+		})
+	}
+}
+
+func TestTelegramSplitKeepsHTMLIntact(t *testing.T) {
+	var b strings.Builder
+	for i := 0; b.Len() < 3*telegramMaxLength; i++ {
+		b.WriteString("<b>[KRW-BTC]</b> 가격 &lt;1,000&gt; <i>A&amp;B</i>\n")
+		if i%10 == 9 {
+			b.WriteString("\n")
+		}
+	}
+	message := "<pre>" + b.String() + "</pre>"
+
+	texts := send(t, Notification{Title: "일일 리포트", Message: message})
+	if len(texts) < 3 {
+		t.Fatalf("sent %d messages, want at least 3", len(texts))
+	}
+	if !strings.HasPrefix(texts[0], "<b>일일 리포트</b>\n\n") {
+		t.Errorf("title missing from the first part: %q", texts[0][:40])
+	}
+	for i, text := range texts {
+		if n := utf16Len(text); n > telegramMaxLength {
+			t.Errorf("part %d is %d UTF-16 units, over %d", i, n, telegramMaxLength)
+		}
+		if i > 0 && strings.Contains(text, "리포트") {
+			t.Errorf("part %d repeats the title", i)
+		}
+		if !strings.HasPrefix(text, "<pre>") && i > 0 {
+			t.Errorf("part %d does not reopen <pre>: %q", i, text[:20])
+		}
+		if !strings.HasSuffix(text, "</pre>") {
+			t.Errorf("part %d does not close <pre>: %q", i, text[len(text)-20:])
+		}
+		if stack := openTags(text); len(stack) != 0 {
+			t.Errorf("part %d leaves tags open: %v", i, stack)
+		}
+		// 엔티티가 잘리면 &lt 처럼 ; 없이 끝나는 조각이 생깁니다
+		for _, entity := range []string{"&lt", "&gt", "&amp"} {
+			if strings.Count(text, entity) != strings.Count(text, entity+";") {
+				t.Errorf("part %d has a broken %s entity", i, entity)
+			}
+		}
+	}
+}
+
+func TestTelegramNotifyRetryAfter(t *testing.T) {
+	server := newTelegramServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"ok":false,"error_code":429,"description":"Too Many Requests: retry after 7","parameters":{"retry_after":7}}`))
+	})
+
+	err := server.notifier().Notify(Notification{Message: "hello"})
+	var delivery *DeliveryError
+	if !errors.As(err, &delivery) {
+		t.Fatalf("Notify = %v, want *DeliveryError", err)
+	}
+	if delivery.StatusCode != http.StatusTooManyRequests || delivery.RetryAfter != 7*time.Second || !delivery.Temporary() {
+		t.Errorf("delivery error = %+v, want temporary 429 with 7s retry", delivery)
+	}
+	if strings.Contains(err.Error(), "test-token") {
+		t.Errorf("error exposes the bot token: %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+package notify
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// SlackNotifier는 Slack Incoming Webhook으로 알림을 전송합니다
+type SlackNotifier struct {
+	WebhookURL string
+	Client     *http.Client
+}
+
+func (s *SlackNotifier) Name() string { return "slack" }
+
+func (s *SlackNotifier) Notify(n Notification) error {
+	title := n.Title
+	if title != "" {
+		title = "*" + title + "*"
+	}
+	// Slack은 &, <, >를 이스케이프해야 합니다
+	escape := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	return postJSON(httpClient(s.Client), "slack", s.WebhookURL, map[string]string{
+		"text": escape.Replace(titled(title, toMarkup(n.Message, "*", "_"))),
+	}, nil)
+}
+
+// discordMaxLength는 Discord 메시지 최대 길이입니다
+const discordMaxLength = 2000
+
+// DiscordNotifier는 Discord Webhook으로 알림을 전송합니다
+type DiscordNotifier struct {
+	WebhookURL string
+	Client     *http.Client
+}
+
+func (d *DiscordNotifier) Name() string { return "discord" }
+
+// Split은 2000자를 넘는 메시지를 나눕니다. 마크다운으로 바꾸면 길이가 줄어들므로 HTML 기준으로 나눕니다
+func (d *DiscordNotifier) Split(n Notification) []Notification {
+	return splitNotification(n, discordMaxLength)
+}
+
+func (d *DiscordNotifier) Notify(n Notification) error {
+	title := n.Title
+	if title != "" {
+		title = "**" + title + "**"
+	}
+	content := []rune(titled(title, toMarkup(n.Message, "**", "*")))
+	if len(content) > discordMaxLength {
+		content = append(content[:discordMaxLength-1], '…')
+	}
+	return postJSON(httpClient(d.Client), "discord", d.WebhookURL, map[string]string{
+		"content": string(content),
+	}, nil)
+}
+
+// WebhookNotifier는 알림을 JSON으로 임의의 URL에 POST합니다.
+// Secret이 있으면 X-Signature 헤더에 hex(HMAC-SHA256(secret, body))를 추가합니다
+type WebhookNotifier struct {
+	URL    string
+	Secret string
+	Client *http.Client
+}
+
+func (w *WebhookNotifier) Name() string { return "webhook" }
+
+func (w *WebhookNotifier) Notify(n Notification) error {
+	payload := struct {
+		Type     Type
+		Severity Severity
+		Title    string
+		Message  string // 태그를 제거한 본문
+		Market   string
+		Time     time.Time
+	}{n.Type, n.Severity, n.Title, toPlainText(n.Message), n.Market, n.Time}
+
+	var sign func([]byte) map[string]string
+	if w.Secret != "" {
+		sign = func(body []byte) map[string]string {
+			mac := hmac.New(sha256.New, []byte(w.Secret))
+			mac.Write(body)
+			return map[string]string{"X-Signature": hex.EncodeToString(mac.Sum(nil))}
+		}
+	}
+	return postJSON(httpClient(w.Client), "webhook", w.URL, payload, sign)
+}
+
+// postJSON은 payload를 JSON으로 전송합니다. headers가 있으면 요청 본문으로 추가 헤더를 만듭니다
+func postJSON(client *http.Client, name, url string, payload any, headers func(body []byte) map[string]string) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if headers != nil {
+		for key, value := range headers(body) {
+			req.Header.Set(key, value)
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		// 웹훅 URL에는 토큰이 포함되므로 오류 메시지에 남기지 않습니다
+		return fmt.Errorf("%s: request failed", name)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		retryAfter, _ := strconv.Atoi(resp.Header.Get("Retry-After"))
+		return &DeliveryError{
+			Sink:       name,
+			StatusCode: resp.StatusCode,
+			RetryAfter: time.Duration(retryAfter) * time.Second,
+			Message:    string(msg),
+		}
+	}
+	return nil
+}
+
+func httpClient(client *http.Client) *http.Client {
+	if client != nil {
+		return client
+	}
+	return &http.Client{Timeout: defaultTimeout}
+}
@@ -0,0 +1,94 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+// 2025-01-06은 월요일입니다
+var monday = time.Date(2025, 1, 6, 10, 7, 30, 0, KST)
+
+func TestParseCronInvalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * 32 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"*/x * * * *",
+		"a * * * *",
+		"1-2-3 * * * *",
+		"* * * FOO *",
+		",5 * * * *",
+	} {
+		if _, err := ParseCron(expr, KST); err == nil {
+			t.Errorf("ParseCron(%q) = nil error, want an error", expr)
+		}
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		now  time.Time
+		want time.Time
+	}{
+		{"step", "*/15 * * * *", monday, time.Date(2025, 1, 6, 10, 15, 0, 0, KST)},
+		{"strictly after a match", "*/15 * * * *", time.Date(2025, 1, 6, 10, 15, 0, 0, KST), time.Date(2025, 1, 6, 10, 30, 0, 0, KST)},
+		{"single value with step runs to the max", "5/20 * * * *", monday, time.Date(2025, 1, 6, 10, 25, 0, 0, KST)},
+		{"range with step", "0 9-18/3 * * *", monday, time.Date(2025, 1, 6, 12, 0, 0, 0, KST)},
+		{"range with step past the last value", "0 9-18/3 * * *", time.Date(2025, 1, 6, 18, 0, 0, 0, KST), time.Date(2025, 1, 7, 9, 0, 0, 0, KST)},
+		{"weekday range", "0 9 * * MON-FRI", monday, time.Date(2025, 1, 7, 9, 0, 0, 0, KST)},
+		{"weekday range skips the weekend", "0 9 * * MON-FRI", time.Date(2025, 1, 10, 9, 0, 0, 0, KST), time.Date(2025, 1, 13, 9, 0, 0, 0, KST)},
+		{"day list", "30 8 1,15 * *", monday, time.Date(2025, 1, 15, 8, 30, 0, 0, KST)},
+		{"month names", "0 0 1 JUL,jan *", monday, time.Date(2025, 7, 1, 0, 0, 0, 0, KST)},
+		{"sunday as 7", "0 0 * * 7", monday, time.Date(2025, 1, 12, 0, 0, 0, 0, KST)},
+		{"sunday as 0", "0 0 * * 0", monday, time.Date(2025, 1, 12, 0, 0, 0, 0, KST)},
+		// 일과 요일이 모두 지정되면 둘 중 하나만 맞아도 실행합니다
+		{"day or weekday", "0 0 13 * FRI", monday, time.Date(2025, 1, 10, 0, 0, 0, 0, KST)},
+		{"day or weekday, day first", "0 0 7 * FRI", monday, time.Date(2025, 1, 7, 0, 0, 0, 0, KST)},
+		{"day with weekday star", "0 0 13 * *", monday, time.Date(2025, 1, 13, 0, 0, 0, 0, KST)},
+		{"weekday with day star", "0 0 */1 * FRI", monday, time.Date(2025, 1, 10, 0, 0, 0, 0, KST)},
+		{"month rollover", "0 0 1 * *", time.Date(2025, 1, 31, 23, 59, 30, 0, KST), time.Date(2025, 2, 1, 0, 0, 0, 0, KST)},
+		{"year rollover", "0 9 * * *", time.Date(2025, 12, 31, 9, 0, 0, 0, KST), time.Date(2026, 1, 1, 9, 0, 0, 0, KST)},
+		{"leap day", "0 0 29 2 *", monday, time.Date(2028, 2, 29, 0, 0, 0, 0, KST)},
+		{"never", "0 0 31 2 *", monday, time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ParseCron(tt.expr, KST)
+			if err != nil {
+				t.Fatalf("ParseCron(%q): %v", tt.expr, err)
+			}
+			if got := s.Next(tt.now); !got.Equal(tt.want) {
+				t.Errorf("Next(%v) = %v, want %v", tt.now, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronLocation(t *testing.T) {
+	s, err := ParseCron("0 9 * * *", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// KST 10:07은 UTC 01:07이므로 같은 날 UTC 09:00(KST 18:00)에 실행합니다
+	if got, want := s.Next(monday), time.Date(2025, 1, 6, 18, 0, 0, 0, KST); !got.Equal(want) {
+		t.Errorf("Next = %v, want %v", got, want)
+	}
+
+	s, err = ParseCron("0 9 * * *", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := s.Next(monday), time.Date(2025, 1, 7, 9, 0, 0, 0, KST); !got.Equal(want) {
+		t.Errorf("nil location: Next = %v, want KST %v", got, want)
+	}
+}
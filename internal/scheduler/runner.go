@@ -0,0 +1,103 @@
+package scheduler
+
+import (
+	"go-trading-bot/internal/clock"
+	"go-trading-bot/internal/logger"
+	"sync"
+	"time"
+)
+
+// Job은 스케줄에 따라 실행되는 작업입니다
+type Job struct {
+	Name     string
+	Schedule Schedule
+	Run      func()
+
+	next time.Time
+}
+
+// Runner는 여러 Job을 각자의 스케줄에 맞춰 실행합니다. 실행 시각과 대기 타이머는 clock 기준입니다
+type Runner struct {
+	jobs    []*Job
+	clock   clock.Clock
+	running sync.WaitGroup
+}
+
+// NewRunner는 clk 기준으로 Job을 실행하는 Runner를 생성합니다. nil이면 clock.Real을 사용합니다
+func NewRunner(clk clock.Clock) *Runner {
+	if clk == nil {
+		clk = clock.Real
+	}
+	return &Runner{clock: clk}
+}
+
+// Add는 Job을 등록합니다
+func (r *Runner) Add(name string, schedule Schedule, run func()) {
+	r.jobs = append(r.jobs, &Job{Name: name, Schedule: schedule, Run: run})
+}
+
+// Run은 stopChan이 닫힐 때까지 등록된 Job을 실행합니다. 각 Job은 별도 고루틴에서 실행되며
+// Run이 반환된 뒤에도 끝나지 않은 Job은 Wait로 기다립니다
+func (r *Runner) Run(stopChan <-chan struct{}) {
+	now := r.clock.Now()
+	for _, job := range r.jobs {
+		r.schedule(job, now)
+	}
+
+	timer := r.clock.NewTimer(r.untilNext(now))
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C():
+			now := r.clock.Now()
+			for _, job := range r.jobs {
+				if job.next.IsZero() || job.next.After(now) {
+					continue
+				}
+				r.running.Add(1)
+				go func(run func()) {
+					defer r.running.Done()
+					run()
+				}(job.Run)
+				r.schedule(job, now)
+			}
+			timer.Reset(r.untilNext(r.clock.Now()))
+		case <-stopChan:
+			logger.Log.Infof("스케줄러 종료 요청")
+			return
+		}
+	}
+}
+
+// Wait는 실행 중인 Job이 모두 끝날 때까지 기다립니다. Run이 반환된 뒤에 호출합니다
+func (r *Runner) Wait() {
+	r.running.Wait()
+}
+
+func (r *Runner) schedule(job *Job, now time.Time) {
+	job.next = job.Schedule.Next(now)
+	if job.next.IsZero() {
+		logger.Log.Warnf("[%v] 다음 실행 시각이 없습니다. 작업을 중지합니다. 🟠", job.Name)
+		return
+	}
+	logger.Log.Infof("[%v] 다음 실행 예정 시각: %v", job.Name, job.next.In(KST).Format("2006-01-02 15:04:05"))
+}
+
+func (r *Runner) untilNext(now time.Time) time.Duration {
+	var earliest time.Time
+	for _, job := range r.jobs {
+		if job.next.IsZero() {
+			continue
+		}
+		if earliest.IsZero() || job.next.Before(earliest) {
+			earliest = job.next
+		}
+	}
+
+	if earliest.IsZero() {
+		// 실행할 작업이 없으면 종료 요청만 기다립니다
+		return time.Duration(1<<63 - 1)
+	}
+	return earliest.Sub(now)
+}
@@ -0,0 +1,64 @@
+package scheduler
+
+import (
+	"go-trading-bot/internal/clock"
+	"testing"
+	"time"
+)
+
+func TestRunnerFollowsClock(t *testing.T) {
+	start := time.Date(2025, 1, 6, 10, 0, 0, 0, KST)
+	fake := clock.NewFake(start)
+	runner := NewRunner(fake)
+	ran := make(chan time.Time, 10)
+	release := make(chan struct{})
+	runner.Add("every-5m", IntervalSchedule{Interval: 5 * time.Minute}, func() {
+		ran <- fake.Now()
+		<-release
+	})
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		runner.Run(stop)
+		close(done)
+	}()
+
+	fake.BlockUntilTimers(1)
+	fake.Advance(4 * time.Minute)
+	select {
+	case at := <-ran:
+		t.Fatalf("job ran at %v, before its schedule", at)
+	default:
+	}
+
+	for i, advance := range []time.Duration{time.Minute, 5 * time.Minute} {
+		fake.Advance(advance)
+		select {
+		case at := <-ran:
+			if want := start.Add(time.Duration(5*(i+1)) * time.Minute); !at.Equal(want) {
+				t.Errorf("run %d at %v, want %v", i+1, at, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("job did not run after advancing to %v", fake.Now())
+		}
+		fake.BlockUntilTimers(1)
+	}
+
+	close(stop)
+	<-done
+
+	// Run이 반환된 뒤에도 Wait는 실행 중인 Job을 기다립니다
+	waited := make(chan struct{})
+	go func() {
+		runner.Wait()
+		close(waited)
+	}()
+	select {
+	case <-waited:
+		t.Fatal("Wait returned while jobs were still running")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+	<-waited
+}
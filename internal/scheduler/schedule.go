@@ -0,0 +1,119 @@
+// Package scheduler
+package scheduler
+
+import (
+	"go-trading-bot/config"
+	"go-trading-bot/internal/clock"
+	"go-trading-bot/internal/logger"
+	"time"
+)
+
+// KST는 업비트 캔들 경계 계산에 사용하는 한국 표준시입니다
+var KST = time.FixedZone("KST", 9*60*60)
+
+const (
+	TYPE_INTERVAL     = "interval"     // analysis-interval(분) 간격으로 실행
+	TYPE_CANDLE_CLOSE = "candle-close" // 캔들 마감 직후 실행
+	TYPE_CRON         = "cron"         // cron 표현식에 따라 실행
+)
+
+// Schedule은 주어진 시각 이후의 다음 실행 시각을 계산합니다
+type Schedule interface {
+	Next(now time.Time) time.Time
+}
+
+// IntervalSchedule은 고정 간격으로 실행되는 스케줄입니다
+type IntervalSchedule struct {
+	Interval time.Duration
+}
+
+func (s IntervalSchedule) Next(now time.Time) time.Time {
+	return now.Add(s.Interval)
+}
+
+// CandleCloseSchedule은 캔들 경계 이후 Delay만큼 지난 시점에 실행되는 스케줄입니다
+type CandleCloseSchedule struct {
+	Category string
+	Unit     int
+	Delay    time.Duration
+}
+
+func (s CandleCloseSchedule) Next(now time.Time) time.Time {
+	return NextCandleStart(s.Category, s.Unit, now.Add(-s.Delay)).Add(s.Delay)
+}
+
+// NewAnalysisSchedule은 설정에 맞는 분석 스케줄을 생성합니다. 캔들 설정은 clk의 현재 시각으로 검증합니다
+func NewAnalysisSchedule(tradingConfig *config.TradingConfig, clk clock.Clock) Schedule {
+	interval := IntervalSchedule{Interval: time.Duration(tradingConfig.AnalysisInterval) * time.Minute}
+
+	switch tradingConfig.Scheduler.Type {
+	case TYPE_CANDLE_CLOSE:
+		candle := tradingConfig.Candle
+		if CandleStart(candle.Category, candle.Unit, clk.Now()).IsZero() {
+			logger.Log.Errorf("캔들 마감 스케줄을 만들 수 없습니다. 고정 간격으로 실행합니다. %+v 🟠", candle)
+			return interval
+		}
+		delay := time.Duration(tradingConfig.Scheduler.CandleCloseDelay) * time.Second
+		return CandleCloseSchedule{Category: candle.Category, Unit: candle.Unit, Delay: delay}
+	case TYPE_CRON:
+		cron, err := ParseCron(tradingConfig.Scheduler.Cron, LoadLocation(tradingConfig.Scheduler.Timezone))
+		if err != nil {
+			logger.Log.Errorf("분석 cron 표현식이 올바르지 않습니다. 고정 간격으로 실행합니다. %v 🟠", err)
+			return interval
+		}
+		return cron
+	default:
+		return interval
+	}
+}
+
+// CandleStart는 t 시점에 진행 중인 캔들의 시작 시각을 반환합니다.
+// 분봉은 UTC 기준으로 정렬되고, 일/주/월봉은 KST 09:00(UTC 00:00)에 시작합니다.
+// 지원하지 않는 캔들이면 zero time을 반환합니다.
+func CandleStart(category string, unit int, t time.Time) time.Time {
+	k := t.In(KST)
+	switch category {
+	case "minutes":
+		if unit <= 0 {
+			return time.Time{}
+		}
+		return t.Truncate(time.Duration(unit) * time.Minute).In(KST)
+	case "days":
+		day := time.Date(k.Year(), k.Month(), k.Day(), 9, 0, 0, 0, KST)
+		if k.Before(day) {
+			day = day.AddDate(0, 0, -1)
+		}
+		return day
+	case "weeks":
+		day := CandleStart("days", unit, t)
+		offset := (int(day.Weekday()) + 6) % 7 // 월요일 시작
+		return day.AddDate(0, 0, -offset)
+	case "months":
+		month := time.Date(k.Year(), k.Month(), 1, 9, 0, 0, 0, KST)
+		if k.Before(month) {
+			month = month.AddDate(0, -1, 0)
+		}
+		return month
+	default:
+		return time.Time{}
+	}
+}
+
+// NextCandleStart는 t 시점 이후 처음 시작되는 캔들의 시작 시각을 반환합니다
+func NextCandleStart(category string, unit int, t time.Time) time.Time {
+	start := CandleStart(category, unit, t)
+	if start.IsZero() {
+		return start
+	}
+
+	switch category {
+	case "minutes":
+		return start.Add(time.Duration(unit) * time.Minute)
+	case "days":
+		return start.AddDate(0, 0, 1)
+	case "weeks":
+		return start.AddDate(0, 0, 7)
+	default:
+		return start.AddDate(0, 1, 0)
+	}
+}
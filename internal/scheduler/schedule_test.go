@@ -0,0 +1,99 @@
+package scheduler
+
+import (
+	"go-trading-bot/config"
+	"go-trading-bot/internal/clock"
+	"testing"
+	"time"
+)
+
+func kst(year int, month time.Month, day, hour, minute, sec int) time.Time {
+	return time.Date(year, month, day, hour, minute, sec, 0, KST)
+}
+
+func TestCandleStart(t *testing.T) {
+	tests := []struct {
+		name     string
+		category string
+		unit     int
+		at       time.Time
+		start    time.Time
+		next     time.Time
+	}{
+		{"1 minute", "minutes", 1, kst(2025, 1, 6, 10, 15, 30), kst(2025, 1, 6, 10, 15, 0), kst(2025, 1, 6, 10, 16, 0)},
+		{"60 minutes on the boundary", "minutes", 60, kst(2025, 1, 6, 11, 0, 0), kst(2025, 1, 6, 11, 0, 0), kst(2025, 1, 6, 12, 0, 0)},
+		// 240분봉은 UTC 00/04/08/12/16/20시, 즉 KST 09/13/17/21/01/05시에 시작합니다
+		{"240 minutes after 09 KST", "minutes", 240, kst(2025, 1, 6, 10, 15, 0), kst(2025, 1, 6, 9, 0, 0), kst(2025, 1, 6, 13, 0, 0)},
+		{"240 minutes before 09 KST", "minutes", 240, kst(2025, 1, 6, 8, 59, 59), kst(2025, 1, 6, 5, 0, 0), kst(2025, 1, 6, 9, 0, 0)},
+		{"240 minutes across midnight", "minutes", 240, kst(2025, 1, 7, 0, 30, 0), kst(2025, 1, 6, 21, 0, 0), kst(2025, 1, 7, 1, 0, 0)},
+		{"day before 09 KST", "days", 1, kst(2025, 1, 6, 8, 59, 59), kst(2025, 1, 5, 9, 0, 0), kst(2025, 1, 6, 9, 0, 0)},
+		{"day at 09 KST", "days", 1, kst(2025, 1, 6, 9, 0, 0), kst(2025, 1, 6, 9, 0, 0), kst(2025, 1, 7, 9, 0, 0)},
+		{"day at month end", "days", 1, kst(2025, 1, 31, 23, 0, 0), kst(2025, 1, 31, 9, 0, 0), kst(2025, 2, 1, 9, 0, 0)},
+		// 주봉은 월요일 09:00 KST에 시작합니다 (2025-01-06은 월요일)
+		{"week on monday morning", "weeks", 1, kst(2025, 1, 6, 8, 59, 59), kst(2024, 12, 30, 9, 0, 0), kst(2025, 1, 6, 9, 0, 0)},
+		{"week on monday 09 KST", "weeks", 1, kst(2025, 1, 6, 9, 0, 0), kst(2025, 1, 6, 9, 0, 0), kst(2025, 1, 13, 9, 0, 0)},
+		{"week on sunday night", "weeks", 1, kst(2025, 1, 12, 23, 0, 0), kst(2025, 1, 6, 9, 0, 0), kst(2025, 1, 13, 9, 0, 0)},
+		{"month before 09 KST on the 1st", "months", 1, kst(2025, 2, 1, 8, 59, 59), kst(2025, 1, 1, 9, 0, 0), kst(2025, 2, 1, 9, 0, 0)},
+		{"month rollover to the next year", "months", 1, kst(2024, 12, 31, 23, 0, 0), kst(2024, 12, 1, 9, 0, 0), kst(2025, 1, 1, 9, 0, 0)},
+		{"month on new year's morning", "months", 1, kst(2025, 1, 1, 8, 0, 0), kst(2024, 12, 1, 9, 0, 0), kst(2025, 1, 1, 9, 0, 0)},
+		{"february", "months", 1, kst(2024, 2, 29, 12, 0, 0), kst(2024, 2, 1, 9, 0, 0), kst(2024, 3, 1, 9, 0, 0)},
+		{"unsupported category", "seconds", 1, kst(2025, 1, 6, 10, 0, 0), time.Time{}, time.Time{}},
+		{"zero minute unit", "minutes", 0, kst(2025, 1, 6, 10, 0, 0), time.Time{}, time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CandleStart(tt.category, tt.unit, tt.at); !got.Equal(tt.start) {
+				t.Errorf("CandleStart(%v) = %v, want %v", tt.at, got, tt.start)
+			}
+			if got := NextCandleStart(tt.category, tt.unit, tt.at); !got.Equal(tt.next) {
+				t.Errorf("NextCandleStart(%v) = %v, want %v", tt.at, got, tt.next)
+			}
+			// UTC로 주어져도 같은 경계를 반환합니다
+			if got := CandleStart(tt.category, tt.unit, tt.at.UTC()); !got.Equal(tt.start) {
+				t.Errorf("CandleStart(%v UTC) = %v, want %v", tt.at.UTC(), got, tt.start)
+			}
+		})
+	}
+}
+
+func TestCandleCloseScheduleNext(t *testing.T) {
+	s := CandleCloseSchedule{Category: "minutes", Unit: 240, Delay: 10 * time.Second}
+	tests := []struct {
+		now  time.Time
+		want time.Time
+	}{
+		// 마감 직후 지연 시간 안이면 방금 마감된 캔들을 기다립니다
+		{kst(2025, 1, 6, 9, 0, 5), kst(2025, 1, 6, 9, 0, 10)},
+		{kst(2025, 1, 6, 9, 0, 10), kst(2025, 1, 6, 13, 0, 10)},
+		{kst(2025, 1, 6, 12, 59, 59), kst(2025, 1, 6, 13, 0, 10)},
+	}
+	for _, tt := range tests {
+		if got := s.Next(tt.now); !got.Equal(tt.want) {
+			t.Errorf("Next(%v) = %v, want %v", tt.now, got, tt.want)
+		}
+	}
+}
+
+func TestNewAnalysisSchedule(t *testing.T) {
+	fake := clock.NewFake(kst(2025, 1, 6, 10, 7, 0))
+	tests := []struct {
+		name      string
+		scheduler config.Scheduler
+		candle    config.Candle
+		want      time.Time
+	}{
+		{"interval", config.Scheduler{}, config.Candle{Category: "minutes", Unit: 240}, kst(2025, 1, 6, 10, 22, 0)},
+		{"candle close", config.Scheduler{Type: TYPE_CANDLE_CLOSE, CandleCloseDelay: 5}, config.Candle{Category: "days", Unit: 1}, kst(2025, 1, 7, 9, 0, 5)},
+		{"candle close with a bad candle falls back to the interval", config.Scheduler{Type: TYPE_CANDLE_CLOSE}, config.Candle{Category: "seconds", Unit: 1}, kst(2025, 1, 6, 10, 22, 0)},
+		{"cron", config.Scheduler{Type: TYPE_CRON, Cron: "0 * * * *"}, config.Candle{}, kst(2025, 1, 6, 11, 0, 0)},
+		{"bad cron falls back to the interval", config.Scheduler{Type: TYPE_CRON, Cron: "every hour"}, config.Candle{}, kst(2025, 1, 6, 10, 22, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := &config.TradingConfig{AnalysisInterval: 15, Scheduler: tt.scheduler, Candle: tt.candle}
+			if got := NewAnalysisSchedule(tc, fake).Next(fake.Now()); !got.Equal(tt.want) {
+				t.Errorf("Next = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
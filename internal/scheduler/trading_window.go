@@ -0,0 +1,131 @@
+package scheduler
+
+import (
+	"fmt"
+	"go-trading-bot/config"
+	"strings"
+	"time"
+)
+
+// TradingWindow는 신규 진입이 허용되는 시간대를 정의합니다.
+// 청산(매도)은 거래 시간과 관계없이 항상 허용됩니다.
+type TradingWindow struct {
+	location *time.Location
+	hours    [][2]int // 자정 기준 분 단위 [시작, 종료)
+	weekdays map[time.Weekday]bool
+	blackout map[string]bool
+}
+
+// NewTradingWindow는 설정으로 TradingWindow를 생성합니다. 비활성화된 경우 nil을 반환합니다
+func NewTradingWindow(cfg config.TradingWindow) (*TradingWindow, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	w := &TradingWindow{
+		location: LoadLocation(cfg.Timezone),
+		blackout: make(map[string]bool),
+	}
+
+	for _, h := range cfg.Hours {
+		r, err := ParseHourRange(h)
+		if err != nil {
+			return nil, err
+		}
+		w.hours = append(w.hours, r)
+	}
+
+	if len(cfg.Weekdays) > 0 {
+		w.weekdays = make(map[time.Weekday]bool)
+		for _, d := range cfg.Weekdays {
+			v, err := ParseWeekday(d)
+			if err != nil {
+				return nil, err
+			}
+			w.weekdays[v] = true
+		}
+	}
+
+	for _, d := range cfg.BlackoutDates {
+		if _, err := time.Parse("2006-01-02", d); err != nil {
+			return nil, fmt.Errorf("invalid blackout date: %q", d)
+		}
+		w.blackout[d] = true
+	}
+
+	return w, nil
+}
+
+// Allows는 t 시점에 신규 진입이 허용되는지 반환합니다. nil이면 항상 허용합니다
+func (w *TradingWindow) Allows(t time.Time) bool {
+	if w == nil {
+		return true
+	}
+
+	local := t.In(w.location)
+	if w.blackout[local.Format("2006-01-02")] {
+		return false
+	}
+	if w.weekdays != nil && !w.weekdays[local.Weekday()] {
+		return false
+	}
+	if len(w.hours) == 0 {
+		return true
+	}
+
+	minute := local.Hour()*60 + local.Minute()
+	for _, r := range w.hours {
+		if r[0] <= r[1] {
+			if minute >= r[0] && minute < r[1] {
+				return true
+			}
+		} else if minute >= r[0] || minute < r[1] {
+			// 자정을 넘는 구간 (예: 22:00-02:00)
+			return true
+		}
+	}
+	return false
+}
+
+// ParseWeekday는 MON, TUE, ... SUN 형식의 요일을 파싱합니다
+func ParseWeekday(s string) (time.Weekday, error) {
+	v, ok := weekdayNames[strings.ToUpper(s)]
+	if !ok {
+		return 0, fmt.Errorf("invalid weekday: %q", s)
+	}
+	return time.Weekday(v), nil
+}
+
+// ParseHourRange는 "09:00-23:00" 형식의 시간 구간을 파싱합니다
+func ParseHourRange(s string) ([2]int, error) {
+	parts := strings.SplitN(s, "-", 2)
+	if len(parts) != 2 {
+		return [2]int{}, fmt.Errorf("invalid hour range: %q (expected HH:MM-HH:MM)", s)
+	}
+
+	var r [2]int
+	for i, p := range parts {
+		t, err := time.Parse("15:04", strings.TrimSpace(p))
+		if err != nil {
+			if strings.TrimSpace(p) != "24:00" {
+				return [2]int{}, fmt.Errorf("invalid hour range: %q (expected HH:MM-HH:MM)", s)
+			}
+			r[i] = 24 * 60
+			continue
+		}
+		r[i] = t.Hour()*60 + t.Minute()
+	}
+	return r, nil
+}
+
+// LoadLocation은 타임존을 로드합니다. 비어 있거나 찾을 수 없으면 KST를 사용합니다
+func LoadLocation(name string) *time.Location {
+	if name == "" {
+		return KST
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return KST
+	}
+	return location
+}
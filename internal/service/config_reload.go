@@ -0,0 +1,140 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"go-trading-bot/config"
+	"go-trading-bot/internal/logger"
+	"go-trading-bot/internal/notify"
+	"go-trading-bot/internal/scheduler"
+	"go-trading-bot/internal/strategy"
+	"go-trading-bot/internal/validator"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// reloadResult는 마지막 설정 리로드 결과입니다. 준비 상태 확인에 사용합니다
+type reloadResult struct {
+	at  time.Time
+	err error
+}
+
+// ReloadConfig는 application.json을 다시 읽어 검증한 뒤 현재 설정을 교체합니다.
+// 파라미터가 바뀐 전략만 다시 생성하고, 나머지 상태(신호, 포지션, 단계 정보)는 유지합니다.
+func (t *TradingBot) ReloadConfig() (changes []string, err error) {
+	t.reloadMu.Lock()
+	defer t.reloadMu.Unlock()
+	defer func() {
+		t.mu.Lock()
+		t.lastReload = reloadResult{at: t.clock.Now(), err: err}
+		t.mu.Unlock()
+	}()
+
+	logger.Log.Info("설정 리로드 시작 🔘")
+
+	newConfig, issues := validator.ValidateFile(config.TradingConfigPath, t.config.Config())
+	if issues.HasFatal() {
+		logger.Log.Errorf("설정 리로드 실패: 설정 오류가 있어 기존 설정을 유지합니다.\n%v 🔴", issues.Error())
+		return nil, issues
+	}
+	for _, issue := range issues {
+		logger.Log.Warn(issue.String())
+	}
+
+	oldConfig := t.config.TradingConfig()
+	changes = config.DiffTradingConfig(oldConfig, newConfig)
+	if len(changes) == 0 {
+		logger.Log.Info("변경된 설정이 없습니다.")
+		return nil, nil
+	}
+
+	newStrategy := t.currentStrategy()
+	if strategyChanged(oldConfig, newConfig) {
+		newStrategy = strategy.CreateStrategy(newConfig, t.clock)
+		if newStrategy == nil {
+			err := fmt.Errorf("unknown strategy: %q", newConfig.Strategy)
+			logger.Log.Errorf("설정 리로드 실패: %v 🔴", err)
+			return nil, err
+		}
+		logger.Log.Infof("전략 파라미터가 변경되어 전략을 다시 생성합니다. (%v)", newConfig.Strategy)
+	}
+
+	tradingWindow, err := scheduler.NewTradingWindow(newConfig.TradingWindow)
+	if err != nil {
+		logger.Log.Errorf("설정 리로드 실패: %v 🔴", err)
+		return nil, err
+	}
+
+	validMarkets := t.GetValidateMarkets()
+	if !reflect.DeepEqual(oldConfig.Markets, newConfig.Markets) {
+		validMarkets = t.marketHandler.validateAndFilterMarkets(t.ctx, t.runtimeAssets(newConfig.Markets))
+		if len(validMarkets) == 0 {
+			err := errors.New("no valid markets in new config")
+			logger.Log.Errorf("설정 리로드 실패: %v 🔴", err)
+			return nil, err
+		}
+	}
+
+	valid := make(map[string]bool, len(validMarkets))
+	for _, m := range validMarkets {
+		valid[m] = true
+	}
+
+	t.mu.Lock()
+	validMarkets = t.withoutRemovedMarkets(validMarkets)
+	t.config.SetTradingConfig(newConfig)
+	t.strategy = newStrategy
+	t.validateMarkets = validMarkets
+	for market := range t.latestSignal {
+		if !valid[market] {
+			delete(t.latestSignal, market)
+		}
+	}
+	t.mu.Unlock()
+
+	t.riskManager.SetTradingWindow(tradingWindow)
+	t.cycleCoordinator.SetPolicy(OverlapPolicy(newConfig.CycleOverlapPolicy))
+	if scheduleChanged(oldConfig, newConfig) {
+		select {
+		case t.scheduleChanged <- struct{}{}:
+		default:
+		}
+	}
+
+	logger.Log.Infof("설정 리로드 완료 🟢\n%v", strings.Join(changes, "\n"))
+	t.sendNotification(notify.Notification{Type: notify.TYPE_CONFIG, Title: "⚙️ 설정 변경", Message: strings.Join(changes, "\n")})
+
+	return changes, nil
+}
+
+func (t *TradingBot) currentStrategy() strategy.TradingStrategy {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.strategy
+}
+
+// strategyChanged는 전략 또는 전략이 사용하는 파라미터/캔들이 바뀌었는지 확인합니다
+func strategyChanged(oldConfig, newConfig *config.TradingConfig) bool {
+	if oldConfig.Strategy != newConfig.Strategy || oldConfig.Candle != newConfig.Candle {
+		return true
+	}
+
+	switch newConfig.Strategy {
+	case strategy.MOVING_AVERAGE_CROSS:
+		return oldConfig.MovingAverageCross != newConfig.MovingAverageCross
+	case strategy.MOVING_AVERAGE_CYCLE:
+		return oldConfig.MovingAverageCycle != newConfig.MovingAverageCycle
+	default:
+		return true
+	}
+}
+
+// scheduleChanged는 스케줄러를 다시 만들어야 하는 설정이 바뀌었는지 확인합니다
+func scheduleChanged(oldConfig, newConfig *config.TradingConfig) bool {
+	return oldConfig.Scheduler != newConfig.Scheduler ||
+		oldConfig.AnalysisInterval != newConfig.AnalysisInterval ||
+		oldConfig.Candle.Category != newConfig.Candle.Category ||
+		oldConfig.Candle.Unit != newConfig.Candle.Unit ||
+		oldConfig.AlertPolicy.DigestCron != newConfig.AlertPolicy.DigestCron
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-trading-bot/internal/logger"
+	"go-trading-bot/internal/model"
+	"go-trading-bot/internal/notify"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrMarketNotFound = errors.New("market not found")
+	ErrMarketExists   = errors.New("market already exists")
+	ErrCycleRunning   = errors.New("cycle is already running")
+	ErrShuttingDown   = errors.New("bot is shutting down")
+)
+
+// 제어 명령은 모두 감사 로그에 기록되고 텔레그램으로 알립니다.
+// actor는 명령을 실행한 주체(API 키, 텔레그램 사용자 등)입니다.
+
+// GetBotStatus는 봇의 제어 상태와 사이클 상태를 반환합니다
+func (t *TradingBot) GetBotStatus() model.BotStatus {
+	return model.BotStatus{
+		Paused:       t.riskManager.IsPaused(),
+		EntryAllowed: t.riskManager.EntryAllowed(t.clock.Now()),
+		Markets:      t.GetValidateMarkets(),
+		Cycle:        t.GetCycleStatus(),
+	}
+}
+
+// Pause는 신규 진입을 일시정지합니다. 청산과 분석은 계속 실행됩니다
+func (t *TradingBot) Pause(actor string) {
+	t.riskManager.Pause()
+	t.announceControl(actor, "pause", nil, nil, "⏸️ 신규 진입 일시정지")
+}
+
+// Resume은 신규 진입을 재개합니다
+func (t *TradingBot) Resume(actor string) {
+	t.riskManager.Resume()
+	t.announceControl(actor, "resume", nil, nil, "▶️ 신규 진입 재개")
+}
+
+// TriggerCycle은 분석 사이클을 즉시 실행합니다
+func (t *TradingBot) TriggerCycle(actor string) error {
+	var err error
+	if t.cycleCoordinator.Closed() {
+		err = ErrShuttingDown
+	} else if !t.cycleCoordinator.Trigger() {
+		err = ErrCycleRunning
+	}
+	t.announceControl(actor, "run", nil, err, "🔁 분석 사이클 즉시 실행")
+	return err
+}
+
+// ClosePosition은 마켓의 포지션을 현재가로 청산합니다. 신호 주문과 같은 주문 경로를 사용하므로 실거래 모드에서는 업비트로 매도합니다
+func (t *TradingBot) ClosePosition(actor, market string) (*model.Order, error) {
+	order, err := t.closePosition(market)
+	t.announceControl(actor, "close", logrus.Fields{"market": market}, err, fmt.Sprintf("🧹 [%s] 포지션 강제 청산%s", market, formatOrder(order)))
+	return order, err
+}
+
+// CloseAllPositions는 모든 포지션을 현재가로 청산합니다
+func (t *TradingBot) CloseAllPositions(actor string) ([]model.Order, error) {
+	var orders []model.Order
+	var errs []error
+	for _, position := range t.orderService.GetPositions() {
+		order, err := t.closePosition(position.Market)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", position.Market, err))
+			continue
+		}
+		orders = append(orders, *order)
+	}
+
+	err := errors.Join(errs...)
+	t.announceControl(actor, "close-all", logrus.Fields{"closed": len(orders)}, err, fmt.Sprintf("🧹 전체 포지션 강제 청산 (%d건)", len(orders)))
+	return orders, err
+}
+
+func (t *TradingBot) closePosition(market string) (*model.Order, error) {
+	if t.orderService.GetPosition(market) == nil {
+		return nil, ErrNoPosition
+	}
+	ctx := logger.WithField(t.ctx, logger.FIELD_MARKET, market)
+	price, err := t.currentPrice(ctx, market)
+	if err != nil {
+		return nil, err
+	}
+	return t.orderService.PlaceOrder(ctx, market, model.SELL, price, 0)
+}
+
+// PlaceManualOrder는 수동 주문을 실행합니다. 신호 주문과 동일하게 OrderService의 리스크 검사를 거칩니다
+func (t *TradingBot) PlaceManualOrder(actor, market string, side model.SignalType, orderAmount float64) (*model.Order, error) {
+	fields := logrus.Fields{"market": market, "side": side.String(), "amount": orderAmount}
+
+	var order *model.Order
+	err := t.requireMarket(market)
+	if err == nil {
+		ctx := logger.WithField(t.ctx, logger.FIELD_MARKET, market)
+		var price float64
+		if price, err = t.currentPrice(ctx, market); err == nil {
+			order, err = t.orderService.PlaceOrder(ctx, market, side, price, orderAmount)
+		}
+	}
+
+	t.announceControl(actor, "order", fields, err, fmt.Sprintf("✋ [%s] 수동 %s 주문%s", market, side, formatOrder(order)))
+	return order, err
+}
+
+// AddMarket은 런타임에 마켓을 추가합니다. 업비트 지원 여부를 검증하며, application.json에는 저장되지 않습니다.
+// 추가한 마켓은 재시작 전까지 마켓 점검과 설정 리로드 후에도 유지됩니다
+func (t *TradingBot) AddMarket(actor, asset string) (string, error) {
+	asset = strings.TrimPrefix(strings.ToUpper(asset), "KRW-")
+	market := "KRW-" + asset
+
+	var err error
+	if slices.Contains(t.GetValidateMarkets(), market) {
+		err = ErrMarketExists
+	} else if valid := t.marketHandler.validateAndFilterMarkets(t.ctx, []string{asset}); len(valid) == 0 {
+		err = fmt.Errorf("%w: %s is not supported by upbit", ErrMarketNotFound, market)
+	} else {
+		t.mu.Lock()
+		t.validateMarkets = append(t.validateMarkets, market)
+		t.removedMarkets = slices.DeleteFunc(t.removedMarkets, func(m string) bool { return m == market })
+		if !slices.Contains(t.addedMarkets, market) {
+			t.addedMarkets = append(t.addedMarkets, market)
+		}
+		t.mu.Unlock()
+	}
+
+	t.announceControl(actor, "add-market", logrus.Fields{"market": market}, err, fmt.Sprintf("➕ [%s] 마켓 추가", market))
+	return market, err
+}
+
+// RemoveMarket은 런타임에 마켓을 제거합니다. 보유 포지션은 청산하지 않습니다.
+// 제거한 마켓은 재시작 전까지 설정에 남아 있어도 다시 추가되지 않습니다
+func (t *TradingBot) RemoveMarket(actor, market string) error {
+	t.mu.Lock()
+	index := slices.Index(t.validateMarkets, market)
+	if index >= 0 {
+		t.validateMarkets = slices.Delete(t.validateMarkets, index, index+1)
+		delete(t.latestSignal, market)
+		t.addedMarkets = slices.DeleteFunc(t.addedMarkets, func(m string) bool { return m == market })
+		if !slices.Contains(t.removedMarkets, market) {
+			t.removedMarkets = append(t.removedMarkets, market)
+		}
+	}
+	t.mu.Unlock()
+
+	var err error
+	if index < 0 {
+		err = ErrMarketNotFound
+	}
+	t.announceControl(actor, "remove-market", logrus.Fields{"market": market}, err, fmt.Sprintf("➖ [%s] 마켓 제거", market))
+	return err
+}
+
+// runtimeAssets는 설정의 자산 목록에 AddMarket으로 추가한 자산을 더합니다
+func (t *TradingBot) runtimeAssets(assets []string) []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	assets = slices.Clone(assets)
+	for _, market := range t.addedMarkets {
+		if asset := strings.TrimPrefix(market, "KRW-"); !slices.Contains(assets, asset) {
+			assets = append(assets, asset)
+		}
+	}
+	return assets
+}
+
+// withoutRemovedMarkets는 RemoveMarket으로 제거한 마켓을 뺍니다. 호출자는 mu를 잡고 있어야 합니다
+func (t *TradingBot) withoutRemovedMarkets(markets []string) []string {
+	return slices.DeleteFunc(markets, func(m string) bool { return slices.Contains(t.removedMarkets, m) })
+}
+
+func (t *TradingBot) requireMarket(market string) error {
+	if !slices.Contains(t.GetValidateMarkets(), market) {
+		return fmt.Errorf("%w: %s", ErrMarketNotFound, market)
+	}
+	return nil
+}
+
+// currentPrice는 마켓의 현재가를 조회합니다. 실패하면 마지막 신호의 가격을 사용합니다
+func (t *TradingBot) currentPrice(ctx context.Context, market string) (float64, error) {
+	if price, exists := t.marketHandler.GetCurrentPrices(ctx, []string{market})[market]; exists {
+		return price, nil
+	}
+	if price := t.GetLatestSignal(market).CurrentPrice; price > 0 {
+		return price, nil
+	}
+	return 0, fmt.Errorf("failed to get current price: %s", market)
+}
+
+func (t *TradingBot) announceControl(actor, action string, fields logrus.Fields, err error, message string) {
+	logger.Audit(actor, action, fields, err)
+
+	n := notify.Notification{
+		Type:     notify.TYPE_CONTROL,
+		Severity: notify.SEVERITY_INFO,
+		Title:    message,
+		Message:  fmt.Sprintf("실행자: %s", actor),
+	}
+	if err != nil {
+		n.Severity = notify.SEVERITY_WARNING
+		n.Message += fmt.Sprintf("\n결과: 실패 (%v)", err)
+	}
+	t.sendNotification(n)
+}
+
+func formatOrder(order *model.Order) string {
+	if order == nil {
+		return ""
+	}
+	return fmt.Sprintf("\n수량: %f, 가격: %.0f, 금액: %.0f, 손익: %.0f", order.Quantity, order.Price, order.Amount, order.Profit)
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"go-trading-bot/internal/clock"
+	"go-trading-bot/internal/event"
+	"go-trading-bot/internal/logger"
+	"go-trading-bot/internal/metrics"
+	"go-trading-bot/internal/model"
+	"sync"
+	"time"
+)
+
+type OverlapPolicy string
+
+const (
+	OVERLAP_SKIP  OverlapPolicy = "skip"  // 실행 중이면 틱을 건너뜀
+	OVERLAP_QUEUE OverlapPolicy = "queue" // 실행 중이면 종료 후 한 번 더 실행
+)
+
+// CycleCoordinator는 분석 사이클이 겹쳐 실행되지 않도록 조율합니다
+type CycleCoordinator struct {
+	mu      sync.Mutex
+	wg      sync.WaitGroup
+	policy  OverlapPolicy
+	task    func() error
+	events  *event.Bus
+	clock   clock.Clock
+	running bool
+	pending bool
+	closed  bool
+	status  model.CycleStatus
+	waiters []chan error // 실행 중인 사이클의 결과를 기다리는 요청
+	queued  []chan error // 대기열에 들어간 사이클의 결과를 기다리는 요청
+}
+
+// NewCycleCoordinator는 주어진 정책으로 task를 실행하는 CycleCoordinator를 생성합니다.
+// 사이클 시작/종료/오류는 events로 발행되고, 시작/종료 시각은 clk로 기록됩니다
+func NewCycleCoordinator(policy OverlapPolicy, task func() error, events *event.Bus, clk clock.Clock) *CycleCoordinator {
+	if policy != OVERLAP_QUEUE {
+		policy = OVERLAP_SKIP
+	}
+	return &CycleCoordinator{policy: policy, task: task, events: events, clock: clk}
+}
+
+// SetPolicy는 사이클 중복 실행 정책을 변경합니다
+func (c *CycleCoordinator) SetPolicy(policy OverlapPolicy) {
+	if policy != OVERLAP_QUEUE {
+		policy = OVERLAP_SKIP
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.policy = policy
+}
+
+// Trigger는 사이클 실행을 요청합니다. 새 사이클이 시작되거나 대기열에 들어가면 true를 반환합니다
+func (c *CycleCoordinator) Trigger() bool {
+	return c.trigger(nil)
+}
+
+// TriggerWait는 Trigger와 같이 사이클 실행을 요청하고, 요청이 받아들여지면 그 사이클의 결과를 받을 채널을 반환합니다.
+// 대기열의 사이클이 Stop으로 버려지면 ErrShuttingDown을 받습니다
+func (c *CycleCoordinator) TriggerWait() (<-chan error, bool) {
+	result := make(chan error, 1)
+	if !c.trigger(result) {
+		return nil, false
+	}
+	return result, true
+}
+
+func (c *CycleCoordinator) trigger(result chan error) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		logger.Log.Warnf("종료 중이라 사이클을 실행하지 않습니다. 🟠")
+		return false
+	}
+	if c.running {
+		if c.policy == OVERLAP_QUEUE && !c.pending {
+			c.pending = true
+			c.status.QueuedTicks++
+			if result != nil {
+				c.queued = append(c.queued, result)
+			}
+			metrics.CycleOverlaps.Inc("queue")
+			logger.Log.Infof("이전 사이클이 실행 중입니다. 종료 후 다시 실행합니다. 🟠")
+			return true
+		}
+		c.status.SkippedTicks++
+		metrics.CycleOverlaps.Inc("skip")
+		logger.Log.Warnf("이전 사이클이 실행 중입니다. 이번 틱을 건너뜁니다. (누적 %v회) 🟠", c.status.SkippedTicks)
+		return false
+	}
+
+	c.running = true
+	c.status.Running = true
+	c.status.StartedAt = c.clock.Now()
+	if result != nil {
+		c.waiters = append(c.waiters, result)
+	}
+	c.wg.Add(1)
+	go c.run()
+	return true
+}
+
+// Wait는 실행 중인 사이클이 모두 종료될 때까지 대기합니다
+func (c *CycleCoordinator) Wait() {
+	c.wg.Wait()
+}
+
+// Stop은 새 사이클 실행을 막고 실행 중인 사이클이 끝날 때까지 대기합니다. 대기 중인 틱은 버립니다.
+// ctx가 먼저 만료되면 ctx.Err()를 반환하며, 이후에도 사이클은 새로 시작되지 않습니다
+func (c *CycleCoordinator) Stop(ctx context.Context) error {
+	c.mu.Lock()
+	c.closed = true
+	c.pending = false
+	for _, result := range c.queued {
+		result <- ErrShuttingDown
+	}
+	c.queued = nil
+	c.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		c.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Closed는 Stop이 호출되었는지 반환합니다
+func (c *CycleCoordinator) Closed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+// Status는 사이클 메타데이터의 복사본을 반환합니다
+func (c *CycleCoordinator) Status() model.CycleStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.status
+}
+
+func (c *CycleCoordinator) run() {
+	defer c.wg.Done()
+
+	for {
+		c.events.Publish(event.CYCLE_STARTED, "", c.Status())
+		err := c.runSafely()
+
+		c.mu.Lock()
+		now := c.clock.Now()
+		c.status.EndedAt = now
+		c.status.Duration = now.Sub(c.status.StartedAt)
+		c.status.CycleCount++
+		result := "success"
+		if err == nil {
+			c.status.LastSuccess = now
+			c.status.LastError = ""
+			c.status.LastErrorAt = time.Time{}
+		} else {
+			result = "failure"
+			c.status.LastError = err.Error()
+			c.status.LastErrorAt = now
+			logger.Log.Errorf("사이클 실행 실패: %v 🔴", err)
+		}
+		metrics.Cycles.Inc(result)
+		metrics.CycleDuration.Observe(c.status.Duration.Seconds(), result)
+		status := c.status
+		status.Running = c.pending
+		for _, result := range c.waiters {
+			result <- err
+		}
+		c.waiters, c.queued = c.queued, nil
+
+		if c.pending {
+			c.pending = false
+			c.status.StartedAt = c.clock.Now()
+			c.mu.Unlock()
+			c.publishFinished(status, err)
+			continue
+		}
+
+		c.running = false
+		c.status.Running = false
+		c.mu.Unlock()
+		c.publishFinished(status, err)
+		return
+	}
+}
+
+func (c *CycleCoordinator) publishFinished(status model.CycleStatus, err error) {
+	if err != nil {
+		c.events.Publish(event.ERROR, "", map[string]string{"Source": "cycle", "Message": err.Error()})
+	}
+	c.events.Publish(event.CYCLE_FINISHED, "", status)
+}
+
+func (c *CycleCoordinator) runSafely() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic during cycle: %v", r)
+		}
+	}()
+	return c.task()
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"go-trading-bot/internal/clock"
+	"go-trading-bot/internal/event"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// blockingTask는 release에서 값을 받을 때까지 멈춰 있는 사이클 작업입니다. 받은 값이 작업 결과가 됩니다
+type blockingTask struct {
+	started chan struct{}
+	release chan error
+	calls   atomic.Int64
+}
+
+func newBlockingTask() *blockingTask {
+	return &blockingTask{started: make(chan struct{}, 10), release: make(chan error)}
+}
+
+func (b *blockingTask) run() error {
+	b.calls.Add(1)
+	b.started <- struct{}{}
+	return <-b.release
+}
+
+func waitStarted(t *testing.T, task *blockingTask) {
+	t.Helper()
+	select {
+	case <-task.started:
+	case <-time.After(time.Second):
+		t.Fatal("cycle did not start")
+	}
+}
+
+func receive(t *testing.T, result <-chan error) error {
+	t.Helper()
+	select {
+	case err := <-result:
+		return err
+	case <-time.After(time.Second):
+		t.Fatal("cycle result was not delivered")
+		return nil
+	}
+}
+
+func TestCycleCoordinatorSkipsOverlappingTick(t *testing.T) {
+	task := newBlockingTask()
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	clk := clock.NewFake(start)
+	c := NewCycleCoordinator(OVERLAP_SKIP, task.run, event.NewBus(), clk)
+
+	if !c.Trigger() {
+		t.Fatal("first trigger was rejected")
+	}
+	waitStarted(t, task)
+	clk.Advance(3 * time.Second)
+	if c.Trigger() {
+		t.Fatal("overlapping trigger was accepted with skip policy")
+	}
+	if _, ok := c.TriggerWait(); ok {
+		t.Fatal("overlapping TriggerWait was accepted with skip policy")
+	}
+
+	task.release <- nil
+	c.Wait()
+
+	status := c.Status()
+	if got := task.calls.Load(); got != 1 {
+		t.Errorf("task calls = %d, want 1", got)
+	}
+	if status.CycleCount != 1 || status.SkippedTicks != 2 || status.QueuedTicks != 0 {
+		t.Errorf("status = %+v, want 1 cycle, 2 skipped, 0 queued", status)
+	}
+	if status.Running {
+		t.Error("status still running after Wait")
+	}
+	if !status.StartedAt.Equal(start) || !status.LastSuccess.Equal(start.Add(3*time.Second)) || status.Duration != 3*time.Second {
+		t.Errorf("status times = %v..%v (%v), want the injected clock", status.StartedAt, status.LastSuccess, status.Duration)
+	}
+}
+
+func TestCycleCoordinatorQueuesOneTick(t *testing.T) {
+	task := newBlockingTask()
+	c := NewCycleCoordinator(OVERLAP_QUEUE, task.run, event.NewBus(), clock.Real)
+
+	first, ok := c.TriggerWait()
+	if !ok {
+		t.Fatal("first trigger was rejected")
+	}
+	waitStarted(t, task)
+	queued, ok := c.TriggerWait()
+	if !ok {
+		t.Fatal("overlapping trigger was not queued")
+	}
+	if c.Trigger() {
+		t.Fatal("second overlapping trigger was queued; only one tick may wait")
+	}
+
+	failure := errors.New("exchange unavailable")
+	task.release <- failure
+	if err := receive(t, first); !errors.Is(err, failure) {
+		t.Errorf("first cycle result = %v, want %v", err, failure)
+	}
+	waitStarted(t, task)
+	task.release <- nil
+	if err := receive(t, queued); err != nil {
+		t.Errorf("queued cycle result = %v, want nil", err)
+	}
+	c.Wait()
+
+	status := c.Status()
+	if got := task.calls.Load(); got != 2 {
+		t.Errorf("task calls = %d, want 2", got)
+	}
+	if status.CycleCount != 2 || status.QueuedTicks != 1 || status.SkippedTicks != 1 {
+		t.Errorf("status = %+v, want 2 cycles, 1 queued, 1 skipped", status)
+	}
+	if status.LastError != "" || !status.LastErrorAt.IsZero() {
+		t.Errorf("last error not cleared after success: %q at %v", status.LastError, status.LastErrorAt)
+	}
+}
+
+func TestCycleCoordinatorRecoversPanic(t *testing.T) {
+	panicking := true
+	c := NewCycleCoordinator(OVERLAP_SKIP, func() error {
+		if panicking {
+			panic("nil map")
+		}
+		return nil
+	}, event.NewBus(), clock.Real)
+
+	result, ok := c.TriggerWait()
+	if !ok {
+		t.Fatal("trigger was rejected")
+	}
+	err := receive(t, result)
+	if err == nil || !strings.Contains(err.Error(), "panic during cycle: nil map") {
+		t.Fatalf("cycle result = %v, want recovered panic", err)
+	}
+	c.Wait()
+
+	status := c.Status()
+	if status.Running || status.CycleCount != 1 {
+		t.Errorf("status = %+v, want 1 finished cycle", status)
+	}
+	if status.LastError != err.Error() || status.LastErrorAt.IsZero() {
+		t.Errorf("last error = %q at %v, want %q", status.LastError, status.LastErrorAt, err)
+	}
+
+	panicking = false
+	result, ok = c.TriggerWait()
+	if !ok {
+		t.Fatal("trigger after panic was rejected")
+	}
+	if err := receive(t, result); err != nil {
+		t.Fatalf("cycle result after panic = %v, want nil", err)
+	}
+	if status := c.Status(); status.LastError != "" || !status.LastErrorAt.IsZero() {
+		t.Errorf("last error not cleared after success: %q at %v", status.LastError, status.LastErrorAt)
+	}
+}
+
+func TestCycleCoordinatorStopExpires(t *testing.T) {
+	task := newBlockingTask()
+	c := NewCycleCoordinator(OVERLAP_QUEUE, task.run, event.NewBus(), clock.Real)
+
+	if !c.Trigger() {
+		t.Fatal("first trigger was rejected")
+	}
+	waitStarted(t, task)
+	queued, ok := c.TriggerWait()
+	if !ok {
+		t.Fatal("overlapping trigger was not queued")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := c.Stop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Stop = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if err := receive(t, queued); !errors.Is(err, ErrShuttingDown) {
+		t.Errorf("queued cycle result = %v, want %v", err, ErrShuttingDown)
+	}
+	if !c.Closed() {
+		t.Error("coordinator not closed after Stop")
+	}
+	if c.Trigger() {
+		t.Error("trigger accepted after Stop")
+	}
+	if !c.Status().Running {
+		t.Error("cycle reported finished while the task is still blocked")
+	}
+
+	task.release <- nil
+	if err := c.Stop(context.Background()); err != nil {
+		t.Fatalf("second Stop = %v, want nil", err)
+	}
+	if got := task.calls.Load(); got != 1 {
+		t.Errorf("task calls = %d, want 1; the queued tick must be dropped", got)
+	}
+	if status := c.Status(); status.Running || status.CycleCount != 1 {
+		t.Errorf("status = %+v, want 1 finished cycle", status)
+	}
+}
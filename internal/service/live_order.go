@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-trading-bot/internal/logger"
+	"go-trading-bot/internal/model"
+	"math"
+	"strconv"
+	"time"
+)
+
+const (
+	ORDER_POLL_INTERVAL = 500 * time.Millisecond // 실거래 주문 체결 확인 간격
+	ORDER_FILL_TIMEOUT  = 10 * time.Second       // 이 시간 안에 체결되지 않은 실거래 주문은 취소합니다
+)
+
+var (
+	ErrNoCredentials  = errors.New("live-trading requires ACCESS_KEY and SECRET_KEY")
+	ErrOrderNotFilled = errors.New("order was not filled")
+)
+
+// fill은 실거래 주문의 체결 결과입니다
+type fill struct {
+	volume float64 // 체결 수량
+	funds  float64 // 체결 금액 (수수료 제외)
+	fee    float64 // 지불한 수수료
+}
+
+// price는 평균 체결 가격입니다
+func (f fill) price() float64 {
+	return f.funds / f.volume
+}
+
+// isLive는 업비트로 실제 주문을 보내는지 반환합니다
+func (o *OrderService) isLive() bool {
+	tc := o.config.TradingConfig()
+	return tc != nil && tc.LiveTrading
+}
+
+// executeBuy는 orderAmount만큼 시장가 매수 주문을 보내고 체결 결과를 반환합니다
+func (o *OrderService) executeBuy(ctx context.Context, id, market string, orderAmount float64) (fill, error) {
+	return o.execute(ctx, model.UpbitOrderRequest{
+		Market:     market,
+		Side:       model.UPBIT_SIDE_BID,
+		OrdType:    model.UPBIT_ORD_TYPE_PRICE,
+		Price:      strconv.FormatFloat(math.Floor(orderAmount), 'f', -1, 64),
+		Identifier: id,
+	})
+}
+
+// executeSell은 quantity만큼 시장가 매도 주문을 보내고 체결 결과를 반환합니다
+func (o *OrderService) executeSell(ctx context.Context, id, market string, quantity float64) (fill, error) {
+	return o.execute(ctx, model.UpbitOrderRequest{
+		Market:     market,
+		Side:       model.UPBIT_SIDE_ASK,
+		OrdType:    model.UPBIT_ORD_TYPE_MARKET,
+		Volume:     strconv.FormatFloat(math.Floor(quantity*1e8)/1e8, 'f', -1, 64),
+		Identifier: id,
+	})
+}
+
+// execute는 주문을 보내고 체결이 끝날 때까지 ORDER_POLL_INTERVAL마다 조회합니다.
+// ORDER_FILL_TIMEOUT 안에 끝나지 않으면 남은 수량을 취소하고, 그때까지 체결된 수량만 반환합니다
+func (o *OrderService) execute(ctx context.Context, request model.UpbitOrderRequest) (fill, error) {
+	if o.orderClient == nil {
+		return fill{}, fmt.Errorf("%w: order client is not configured", ErrInvalidOrder)
+	}
+	c := o.config.Config()
+	if c.AccessKey == "" || c.SecretKey == "" {
+		return fill{}, ErrNoCredentials
+	}
+
+	log := logger.FromContext(ctx)
+	placed, err := o.orderClient.PlaceOrder(ctx, c.AccessKey, c.SecretKey, request)
+	if err != nil {
+		return fill{}, err
+	}
+	log.Infof("[%v] 업비트 주문을 접수했습니다. (uuid: %v, side: %v, ord_type: %v) 🔘", request.Market, placed.UUID, request.Side, request.OrdType)
+
+	deadline := o.clock.Now().Add(ORDER_FILL_TIMEOUT)
+	var order *model.UpbitOrder
+	for {
+		order, err = o.orderClient.GetOrder(ctx, c.AccessKey, c.SecretKey, placed.UUID)
+		if err != nil {
+			return fill{}, err
+		}
+		if order.State != model.UPBIT_ORDER_WAIT {
+			break
+		}
+		if !o.clock.Now().Before(deadline) {
+			log.Warnf("[%v] 주문이 %v 안에 체결되지 않아 취소합니다. (uuid: %v) 🟠", request.Market, ORDER_FILL_TIMEOUT, placed.UUID)
+			if _, err := o.orderClient.CancelOrder(ctx, c.AccessKey, c.SecretKey, placed.UUID); err != nil {
+				return fill{}, err
+			}
+			// 취소 직전까지의 체결 내역을 다시 조회합니다
+			if order, err = o.orderClient.GetOrder(ctx, c.AccessKey, c.SecretKey, placed.UUID); err != nil {
+				return fill{}, err
+			}
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return fill{}, ctx.Err()
+		case <-o.clock.After(ORDER_POLL_INTERVAL):
+		}
+	}
+
+	result, err := parseFill(order)
+	if err != nil {
+		return fill{}, err
+	}
+	if result.volume <= 0 {
+		return fill{}, fmt.Errorf("%w: %v order %v is %v", ErrOrderNotFilled, request.Market, order.UUID, order.State)
+	}
+	return result, nil
+}
+
+// parseFill은 주문의 체결 내역을 합산합니다
+func parseFill(order *model.UpbitOrder) (fill, error) {
+	var result fill
+	for _, trade := range order.Trades {
+		volume, err := strconv.ParseFloat(trade.Volume, 64)
+		if err != nil {
+			return fill{}, fmt.Errorf("invalid trade volume %q: %w", trade.Volume, err)
+		}
+		funds, err := strconv.ParseFloat(trade.Funds, 64)
+		if err != nil {
+			return fill{}, fmt.Errorf("invalid trade funds %q: %w", trade.Funds, err)
+		}
+		result.volume += volume
+		result.funds += funds
+	}
+	if order.PaidFee != "" {
+		fee, err := strconv.ParseFloat(order.PaidFee, 64)
+		if err != nil {
+			return fill{}, fmt.Errorf("invalid paid fee %q: %w", order.PaidFee, err)
+		}
+		result.fee = fee
+	}
+	return result, nil
+}
@@ -1,106 +1,168 @@
 package service
 
 import (
+	"context"
 	"go-trading-bot/config"
 	"go-trading-bot/internal/client"
+	"go-trading-bot/internal/clock"
 	"go-trading-bot/internal/logger"
 	"go-trading-bot/internal/model"
+	"go-trading-bot/internal/scheduler"
+	"sync"
+	"time"
 )
 
 type MarketHandler struct {
-	upbitAPIClient *client.UpbitAPIClient
-	binanceAPIClient *client.BinanceAPIClient
+	upbitAPIClient   client.ExchangeClient
+	binanceAPIClient client.PriceClient
+	config           config.Provider
+	clock            clock.Clock
+	balanceMu        sync.Mutex
+	lastBalance      balanceResult // 마지막 계좌 조회 결과, 준비 상태 확인에서 재사용합니다
 }
 
-func (m *MarketHandler) validateAndFilterMarkets() (validMarkets []string) {
-	tradingConfig := config.GetTradingConfig()
+// balanceResult는 업비트 계좌 조회 시각과 결과입니다
+type balanceResult struct {
+	at  time.Time
+	err error
+}
 
-	if len(tradingConfig.Markets) == 0 {
-		logger.Log.Error("설정된 마켓이 없습니다.")
+func (m *MarketHandler) validateAndFilterMarkets(ctx context.Context, markets []string) (validMarkets []string) {
+	log := logger.FromContext(ctx)
+	if len(markets) == 0 {
+		log.Error("설정된 마켓이 없습니다.")
 		return []string{}
 	}
 
-	logger.Log.Info("마켓 검증 시작 🔘")
-	logger.Log.Infof("설정된 마켓 수: %v", len(tradingConfig.Markets))
-	logger.Log.Infof("설정된 마켓: %+v", tradingConfig.Markets)
+	log.Info("마켓 검증 시작 🔘")
+	log.Infof("설정된 마켓 수: %v", len(markets))
+	log.Infof("설정된 마켓: %+v", markets)
 
 	var userTargets []string
-	for _, m := range tradingConfig.Markets {
+	for _, m := range markets {
 		userTargets = append(userTargets, "KRW-"+m)
 	}
 
-	marketInfo, err := m.upbitAPIClient.GetAllMarkets()
+	marketInfo, err := m.upbitAPIClient.GetAllMarkets(ctx)
 	if err != nil {
-		logger.Log.Errorf("Upbit 마켓 목록 조회 실패. 설정된 마켓을 그대로 사용합니다. %s 🔴", err.Error())
+		log.Errorf("Upbit 마켓 목록 조회 실패. 설정된 마켓을 그대로 사용합니다. %s 🔴", err.Error())
 		return userTargets
 	}
 
 	if len(marketInfo) == 0 {
-		logger.Log.Error("Upbit 마켓 목록이 비어있습니다. 설정된 마켓을 그대로 사용합니다. 🔴")
+		log.Error("Upbit 마켓 목록이 비어있습니다. 설정된 마켓을 그대로 사용합니다. 🔴")
 		return userTargets
 	}
 
-	logger.Log.Infof("업비트 지원 마켓 수: %v", len(marketInfo))
+	log.Infof("업비트 지원 마켓 수: %v", len(marketInfo))
 
 	for _, u := range userTargets {
 		find := false
 		for _, m := range marketInfo {
 			if u == m.Market {
-				logger.Log.Infof("[유효] %v - %v (%v)", m.Market, m.KoreanName, m.EnglishName)
+				log.Infof("[유효] %v - %v (%v)", m.Market, m.KoreanName, m.EnglishName)
 				validMarkets = append(validMarkets, m.Market)
 				find = true
 			}
 		}
 
 		if !find {
-			logger.Log.Warnf("[무효] 업비트에서 지원하지 않는 마켓입니다. 제외됩니다(%v) 🟠", u)
+			log.Warnf("[무효] 업비트에서 지원하지 않는 마켓입니다. 제외됩니다(%v) 🟠", u)
 		}
 	}
 
-	logger.Log.Infof("유효한 마켓 수 : %v / %v", len(validMarkets), len(userTargets))
-	logger.Log.Info("마켓 검증 완료 🟢")
+	log.Infof("유효한 마켓 수 : %v / %v", len(validMarkets), len(userTargets))
+	log.Info("마켓 검증 완료 🟢")
 
 	return validMarkets
 }
 
-func (m *MarketHandler) GetCandles(market string, requireCandleCount int) (candles []model.Candle) {
-	candleConfig := config.GetTradingConfig().Candle
+func (m *MarketHandler) GetCandles(ctx context.Context, market string, requireCandleCount int) (candles []model.Candle) {
+	log := logger.FromContext(ctx)
+	candleConfig := m.config.TradingConfig().Candle
 	path := candleConfig.BuildAPIPath()
 	if len(path) == 0 {
-		logger.Log.Errorf("Candle Path를 만드는데 실패했습니다. %+v", candleConfig)
+		log.Errorf("Candle Path를 만드는데 실패했습니다. %+v", candleConfig)
 		return candles
 	}
 
-	candles, err := m.upbitAPIClient.FetchCandles(market, path, requireCandleCount)
+	fetchCount := requireCandleCount
+	if candleConfig.ClosedOnly {
+		fetchCount++
+	}
+
+	candles, err := m.upbitAPIClient.FetchCandles(ctx, market, path, fetchCount)
 	if err != nil {
-		logger.Log.Errorf("Failed to fetch Candles -> %s", err.Error())
+		log.Errorf("Failed to fetch Candles -> %s", err.Error())
+		return candles
+	}
+
+	if candleConfig.ClosedOnly {
+		candles = dropLiveCandle(candles, candleConfig, m.clock.Now())
+		if len(candles) > requireCandleCount {
+			candles = candles[:requireCandleCount]
+		}
+	}
+
+	return candles
+}
+
+// dropLiveCandle은 아직 마감되지 않은 최신 캔들(candles[0])을 제외합니다
+func dropLiveCandle(candles []model.Candle, candleConfig config.Candle, now time.Time) []model.Candle {
+	if len(candles) == 0 {
 		return candles
 	}
 
+	liveStart := scheduler.CandleStart(candleConfig.Category, candleConfig.Unit, now)
+	start, err := candles[0].StartTime()
+	if err != nil || liveStart.IsZero() || !start.Before(liveStart) {
+		// 시작 시각을 알 수 없으면 진행 중인 캔들로 간주합니다
+		return candles[1:]
+	}
+
 	return candles
 }
 
-func (m *MarketHandler) GetPositions() (positions model.Positions) {
-	config := config.GetConfig()
-	if config.AccessKey == "" || config.SecretKey == "" {
-		logger.Log.Error("AccessKey 또는 SecretKey가 설정되지 않았습니다.")
-		return positions
+// fetchBalance는 업비트 계좌를 조회하고 그 결과를 lastBalance에 기록합니다
+func (m *MarketHandler) fetchBalance(ctx context.Context, accessKey, secretKey string) (model.Positions, error) {
+	positions, err := m.upbitAPIClient.FetchBalance(ctx, accessKey, secretKey)
+	m.balanceMu.Lock()
+	m.lastBalance = balanceResult{at: m.clock.Now(), err: err}
+	m.balanceMu.Unlock()
+	return positions, err
+}
+
+// LastBalanceCheck는 마지막 계좌 조회 시각과 오류를 반환합니다. 조회한 적이 없으면 시각이 zero입니다
+func (m *MarketHandler) LastBalanceCheck() (time.Time, error) {
+	m.balanceMu.Lock()
+	defer m.balanceMu.Unlock()
+	return m.lastBalance.at, m.lastBalance.err
+}
+
+// GetCurrentPrices는 마켓별 현재가를 조회합니다
+func (m *MarketHandler) GetCurrentPrices(ctx context.Context, markets []string) map[string]float64 {
+	prices := make(map[string]float64)
+	if len(markets) == 0 {
+		return prices
 	}
 
-	positions, err := m.upbitAPIClient.FetchBalance(config.AccessKey, config.SecretKey)
+	tickers, err := m.upbitAPIClient.FetchTickers(ctx, markets)
 	if err != nil {
-		logger.Log.Errorf("Failed to fetch positions: %v", err)
-		return positions
+		logger.FromContext(ctx).Errorf("Failed to fetch tickers: %v", err)
+		return prices
 	}
 
-	return positions
+	for _, ticker := range tickers {
+		prices[ticker.Market] = ticker.TradePrice
+	}
+	return prices
 }
 
-func (m *MarketHandler) GetBinancePrices() (prices []model.Price) {
-	config := config.GetTradingConfig()
-	binancePrices, err := m.binanceAPIClient.GetPrices()
+func (m *MarketHandler) GetBinancePrices(ctx context.Context) (prices []model.Price) {
+	config := m.config.TradingConfig()
+	binancePrices, err := m.binanceAPIClient.GetPrices(ctx)
 	if err != nil {
-		logger.Log.Errorf("Failed to fetch binance prices: %v", err)
+		logger.FromContext(ctx).Errorf("Failed to fetch binance prices: %v", err)
 		return []model.Price{}
 	}
 
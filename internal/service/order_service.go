@@ -1,57 +1,236 @@
 package service
 
 import (
+	"context"
+	"fmt"
 	"go-trading-bot/config"
+	"go-trading-bot/internal/client"
+	"go-trading-bot/internal/clock"
+	"go-trading-bot/internal/event"
 	"go-trading-bot/internal/logger"
+	"go-trading-bot/internal/metrics"
 	"go-trading-bot/internal/model"
+	"sync"
+
+	"github.com/google/uuid"
 )
 
 type OrderService struct {
-	positions map[string]model.Position
+	mu          sync.RWMutex
+	positions   map[string]model.Position
+	orders      []model.Order
+	riskManager *RiskManager
+	events      *event.Bus
+	orderClient client.OrderClient // 실거래 모드(live-trading)에서 주문을 보낼 업비트 주문 API
+	config      config.Provider
+	clock       clock.Clock
+	closed      bool
 }
 
 func (o *OrderService) GetPosition(market string) *model.Position {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
 	if position, exists := o.positions[market]; exists {
 		return &position
 	}
 	return nil
 }
 
+// GetPositions는 보유 중인 모든 포지션을 반환합니다
+func (o *OrderService) GetPositions() model.Positions {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	positions := make(model.Positions, 0, len(o.positions))
+	for _, position := range o.positions {
+		positions = append(positions, position)
+	}
+	return positions
+}
+
 func (o *OrderService) SetPosition(market string, position *model.Position) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
 	o.positions[market] = *position
 }
 
 func (o *OrderService) RemovePosition(market string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
 	delete(o.positions, market)
 }
 
-func (o *OrderService) PlaceOrder(market string, signalType model.SignalType, currentPrice float64) {
-	if signalType == model.BUY {
-		orderAmount := config.GetTradingConfig().OrderAmount
+// PlaceOrder는 리스크 규칙을 확인한 뒤 주문을 실행합니다. orderAmount가 0이면 설정의 order-amount를 사용합니다
+func (o *OrderService) PlaceOrder(ctx context.Context, market string, signalType model.SignalType, currentPrice float64, orderAmount float64) (*model.Order, error) {
+	return o.placeOrder(ctx, market, signalType, currentPrice, orderAmount, "")
+}
+
+// PlaceSignalOrder는 전략 신호로 주문을 실행합니다. 매수 포지션과 주문에 신호의 사이클 단계를 기록합니다
+func (o *OrderService) PlaceSignalOrder(ctx context.Context, signal model.Signal) (*model.Order, error) {
+	return o.placeOrder(ctx, signal.Market, signal.Type, signal.CurrentPrice, 0, signal.StageName())
+}
+
+func (o *OrderService) placeOrder(ctx context.Context, market string, signalType model.SignalType, currentPrice float64, orderAmount float64, stage string) (*model.Order, error) {
+	if currentPrice <= 0 {
+		return nil, fmt.Errorf("%w: current price is %v", ErrInvalidOrder, currentPrice)
+	}
+
+	log := logger.FromContext(ctx)
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.closed {
+		log.Warnf("[%v] 종료 중이라 %v 주문을 실행하지 않습니다. 🟠", market, signalType)
+		o.publishRejected(market, signalType, ErrShuttingDown)
+		return nil, ErrShuttingDown
+	}
+
+	switch signalType {
+	case model.BUY:
+		if orderAmount <= 0 {
+			orderAmount = o.config.TradingConfig().OrderAmount
+		}
+		if err := o.riskManager.CheckEntry(market, orderAmount, o.positions); err != nil {
+			log.Warnf("[%v] 매수 주문이 거부되었습니다: %v 🟠", market, err)
+			o.publishRejected(market, signalType, err)
+			return nil, err
+		}
+
+		id := uuid.NewString()
+		price, entryPrice := currentPrice, currentPrice
 		quantity := float64(int((orderAmount/currentPrice)*10000)) / 10000
-		position := &model.Position{
+		log.Infof("[%v] 매수 주문을 실행합니다. 주문 금액: %v, 주문 수량: %v", market, orderAmount, quantity)
+		if o.isLive() {
+			filled, err := o.executeBuy(ctx, id, market, orderAmount)
+			if err != nil {
+				log.Errorf("[%v] 매수 주문이 실패했습니다: %v 🔴", market, err)
+				o.publishRejected(market, signalType, err)
+				return nil, err
+			}
+			// 수수료를 포함한 단가를 진입가로 사용해 실현 손익에 매수 수수료가 반영되도록 합니다
+			price, quantity = filled.price(), filled.volume
+			entryPrice = (filled.funds + filled.fee) / filled.volume
+		}
+
+		position := model.Position{
 			Market:     market,
 			Status:     model.POSITION_BUY,
 			Quantity:   quantity,
-			EntryPrice: currentPrice,
+			EntryPrice: entryPrice,
 			Profit:     0,
+			Stage:      stage,
+		}
+		log.Infof("[%v] 포지션 정보: %v", market, position)
+		o.positions[market] = position
+		order := o.recordOrder(id, market, model.BUY, price, quantity, 0, stage)
+		log.WithField(logger.FIELD_ORDER_ID, order.ID).Infof("[%v] 매수 주문이 체결되었습니다. 🟢", market)
+		return &order, nil
+	case model.SELL:
+		position, exists := o.positions[market]
+		if !exists {
+			log.Infof("[%v] 포지션이 없습니다.", market)
+			o.publishRejected(market, signalType, ErrNoPosition)
+			return nil, ErrNoPosition
 		}
-		logger.Log.Infof("[%v] 매수 주문을 실행합니다. 주문 금액: %v, 주문 수량: %v", market, orderAmount, quantity)
-		logger.Log.Infof("[%v] 포지션 정보: %v", market, position)
-		o.SetPosition(market, position)
-	} else if signalType == model.SELL {
-		position := o.GetPosition(market)
-		if position == nil {
-			logger.Log.Infof("[%v] 포지션이 없습니다.", market)
-			return
+
+		id := uuid.NewString()
+		price, quantity := currentPrice, position.Quantity
+		proceeds := currentPrice * position.Quantity
+		log.Infof("[%v] 매도 주문을 실행합니다. 포지션 수량: %v", market, position.Quantity)
+		if o.isLive() {
+			filled, err := o.executeSell(ctx, id, market, position.Quantity)
+			if err != nil {
+				log.Errorf("[%v] 매도 주문이 실패했습니다: %v 🔴", market, err)
+				o.publishRejected(market, signalType, err)
+				return nil, err
+			}
+			price, quantity = filled.price(), min(filled.volume, position.Quantity)
+			proceeds = filled.funds - filled.fee
 		}
 
-		profit := (currentPrice - position.EntryPrice) * position.Quantity
+		profit := proceeds - position.EntryPrice*quantity
+		if remaining := position.Quantity - quantity; remaining > 0 {
+			// 일부만 체결되면 남은 수량은 포지션으로 유지합니다
+			log.Warnf("[%v] 매도 주문이 일부만 체결되었습니다. 남은 수량: %v 🟠", market, remaining)
+			o.positions[market] = model.Position{Market: market, Status: model.POSITION_BUY, Quantity: remaining, EntryPrice: position.EntryPrice, Stage: position.Stage}
+		} else {
+			delete(o.positions, market)
+		}
 		position.Profit = profit
 		position.Status = model.POSITION_NONE
+		log.Infof("[%v] 포지션 정보: %v, 수익: %v", market, position, profit)
+		order := o.recordOrder(id, market, model.SELL, price, quantity, profit, position.Stage)
+		log.WithField(logger.FIELD_ORDER_ID, order.ID).Infof("[%v] 매도 주문이 체결되었습니다. 🟢", market)
+		return &order, nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported side %v", ErrInvalidOrder, signalType)
+	}
+}
+
+// Close는 실행 중인 주문이 끝날 때까지 기다린 뒤 새 주문을 막습니다
+func (o *OrderService) Close() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.closed = true
+}
 
-		logger.Log.Infof("[%v] 매도 주문을 실행합니다. 포지션 수량: %v, 수익: %v", market, position.Quantity, profit)
-		logger.Log.Infof("[%v] 포지션 정보: %v", market, position)
-		o.RemovePosition(market)
+// Snapshot은 저장할 포지션과 주문 기록의 복사본을 반환합니다
+func (o *OrderService) Snapshot() (model.Positions, []model.Order) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	positions := make(model.Positions, 0, len(o.positions))
+	for _, position := range o.positions {
+		positions = append(positions, position)
 	}
+	return positions, append([]model.Order(nil), o.orders...)
+}
+
+// Restore는 저장된 포지션과 주문 기록으로 상태를 교체합니다
+func (o *OrderService) Restore(positions model.Positions, orders []model.Order) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.positions = make(map[string]model.Position, len(positions))
+	for _, position := range positions {
+		o.positions[position.Market] = position
+	}
+	o.orders = append([]model.Order(nil), orders...)
+}
+
+// GetOrders는 조건에 맞는 주문 기록을 최신순으로 반환합니다
+func (o *OrderService) GetOrders(filter model.OrderFilter) []model.Order {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	orders := make([]model.Order, 0)
+	for i := len(o.orders) - 1; i >= 0; i-- {
+		if filter.Matches(o.orders[i]) {
+			orders = append(orders, o.orders[i])
+		}
+	}
+	return orders
+}
+
+// recordOrder는 체결된 주문을 기록합니다. o.mu를 잡은 상태에서 호출해야 합니다
+func (o *OrderService) recordOrder(id, market string, signalType model.SignalType, price, quantity, profit float64, stage string) model.Order {
+	order := model.Order{
+		ID:        id,
+		Market:    market,
+		Side:      signalType.String(),
+		Price:     price,
+		Quantity:  quantity,
+		Amount:    price * quantity,
+		Profit:    profit,
+		Stage:     stage,
+		CreatedAt: o.clock.Now(),
+	}
+	o.orders = append(o.orders, order)
+
+	// 모의 주문은 접수와 동시에 체결되고, 실거래 주문은 체결이 끝난 뒤에 기록됩니다
+	metrics.Orders.Inc(order.Side, "filled")
+	o.events.Publish(event.ORDER_PLACED, market, order)
+	o.events.Publish(event.ORDER_FILLED, market, order)
+	return order
+}
+
+func (o *OrderService) publishRejected(market string, signalType model.SignalType, err error) {
+	metrics.Orders.Inc(signalType.String(), "rejected")
+	o.events.Publish(event.ORDER_REJECTED, market, map[string]string{"Market": market, "Side": signalType.String(), "Reason": err.Error()})
 }
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"go-trading-bot/config"
+	"go-trading-bot/internal/clock"
+	"go-trading-bot/internal/model"
+	"go-trading-bot/internal/scheduler"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	ErrEntriesPaused        = errors.New("new entries are paused")
+	ErrOutsideTradingWindow = errors.New("outside trading window")
+	ErrPositionExists       = errors.New("position already exists")
+	ErrNoPosition           = errors.New("no position")
+	ErrMaxPositions         = errors.New("max positions reached")
+	ErrOrderAmountExceeded  = errors.New("order amount exceeds limit")
+	ErrInvalidOrder         = errors.New("invalid order")
+)
+
+// RiskManager는 신규 진입 제한과 손절/익절 청산 조건을 관리합니다.
+// 청산(매도)은 일시정지나 거래 시간과 관계없이 항상 허용됩니다.
+type RiskManager struct {
+	paused        atomic.Bool
+	tradingWindow atomic.Pointer[scheduler.TradingWindow]
+	config        config.Provider
+	clock         clock.Clock
+}
+
+func (r *RiskManager) Pause()         { r.paused.Store(true) }
+func (r *RiskManager) Resume()        { r.paused.Store(false) }
+func (r *RiskManager) IsPaused() bool { return r.paused.Load() }
+
+// SetTradingWindow는 신규 진입 허용 시간대를 교체합니다. nil이면 제한하지 않습니다
+func (r *RiskManager) SetTradingWindow(tradingWindow *scheduler.TradingWindow) {
+	r.tradingWindow.Store(tradingWindow)
+}
+
+// EntryAllowed는 현재 신규 진입이 가능한 시간대인지 반환합니다
+func (r *RiskManager) EntryAllowed(now time.Time) bool {
+	return r.tradingWindow.Load().Allows(now)
+}
+
+// CheckEntry는 신규 매수 주문이 리스크 규칙을 만족하는지 확인합니다
+func (r *RiskManager) CheckEntry(market string, orderAmount float64, positions map[string]model.Position) error {
+	if r.IsPaused() {
+		return ErrEntriesPaused
+	}
+	if !r.EntryAllowed(r.clock.Now()) {
+		return ErrOutsideTradingWindow
+	}
+	if _, exists := positions[market]; exists {
+		return ErrPositionExists
+	}
+
+	risk := r.config.TradingConfig().Risk
+	if risk.MaxPositions > 0 && len(positions) >= risk.MaxPositions {
+		return fmt.Errorf("%w (%d)", ErrMaxPositions, risk.MaxPositions)
+	}
+	if risk.MaxOrderAmount > 0 && orderAmount > risk.MaxOrderAmount {
+		return fmt.Errorf("%w (%.0f > %.0f)", ErrOrderAmountExceeded, orderAmount, risk.MaxOrderAmount)
+	}
+	return nil
+}
+
+// CheckExit는 포지션이 손절/익절 조건에 도달했는지 확인하고, 도달했으면 사유를 반환합니다
+func (r *RiskManager) CheckExit(position model.Position, currentPrice float64) (string, bool) {
+	if position.EntryPrice <= 0 || currentPrice <= 0 {
+		return "", false
+	}
+
+	risk := r.config.TradingConfig().Risk
+	rate := (currentPrice - position.EntryPrice) / position.EntryPrice * 100
+
+	if risk.StopLossPercent > 0 && rate <= -risk.StopLossPercent {
+		return fmt.Sprintf("손절 (%.2f%% ≤ -%.2f%%)", rate, risk.StopLossPercent), true
+	}
+	if risk.TakeProfitPercent > 0 && rate >= risk.TakeProfitPercent {
+		return fmt.Sprintf("익절 (%.2f%% ≥ %.2f%%)", rate, risk.TakeProfitPercent), true
+	}
+	return "", false
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"fmt"
+	"go-trading-bot/config"
+	"go-trading-bot/internal/logger"
+	"go-trading-bot/internal/notify"
+	"go-trading-bot/internal/scheduler"
+)
+
+// createRunner는 분석, 리포트, 유지보수, 요약 알림 작업을 등록한 스케줄러를 생성합니다
+func (t *TradingBot) createRunner(tradingConfig *config.TradingConfig) *scheduler.Runner {
+	runner := scheduler.NewRunner(t.clock)
+	runner.Add("analysis", scheduler.NewAnalysisSchedule(tradingConfig, t.clock), func() { t.cycleCoordinator.Trigger() })
+
+	location := scheduler.LoadLocation(tradingConfig.Scheduler.Timezone)
+	type cronJob struct {
+		name string
+		expr string
+		run  func()
+	}
+	jobs := []cronJob{
+		{"report", tradingConfig.Scheduler.ReportCron, t.sendReport},
+		{"maintenance", tradingConfig.Scheduler.MaintenanceCron, t.runMaintenance},
+	}
+	if digester, ok := t.notifier.(notify.Digester); ok {
+		jobs = append(jobs, cronJob{"digest", tradingConfig.AlertPolicy.DigestCron, func() { _ = digester.SendDigest() }})
+	}
+
+	for _, job := range jobs {
+		if job.expr == "" {
+			continue
+		}
+		schedule, err := scheduler.ParseCron(job.expr, location)
+		if err != nil {
+			logger.Log.Errorf("[%v] cron 표현식이 올바르지 않아 작업을 등록하지 않습니다. %v 🔴", job.name, err)
+			continue
+		}
+		runner.Add(job.name, schedule, job.run)
+	}
+
+	return runner
+}
+
+// sendReport는 최신 신호, 포지션, 사이클 상태를 요약해 알림으로 전송합니다
+func (t *TradingBot) sendReport() {
+	logger.Log.Info("=========report===========")
+
+	status := t.GetCycleStatus()
+	report := fmt.Sprintf("🕐 <b>시각:</b> %s\n", t.clock.Now().In(scheduler.KST).Format("2006-01-02 15:04:05"))
+	report += fmt.Sprintf("🔁 <b>사이클:</b> %d회 (건너뜀 %d회)\n", status.CycleCount, status.SkippedTicks)
+	if status.LastError != "" {
+		report += fmt.Sprintf("⚠️ <b>마지막 오류:</b> %s\n", status.LastError)
+	}
+	if t.riskManager.IsPaused() {
+		report += "🛒 <b>신규 진입:</b> 일시정지\n\n"
+	} else if t.riskManager.EntryAllowed(t.clock.Now()) {
+		report += "🛒 <b>신규 진입:</b> 허용\n\n"
+	} else {
+		report += "🛒 <b>신규 진입:</b> 거래 시간 아님\n\n"
+	}
+
+	for _, signal := range t.GetAllLatestSignals() {
+		report += fmt.Sprintf("• <b>%s</b> %v", signal.Market, signal.Type)
+		if signal.Stage != nil {
+			report += fmt.Sprintf(" (%v)", signal.Stage.StageNumber)
+		}
+		if position := t.orderService.GetPosition(signal.Market); position != nil {
+			profit := (signal.CurrentPrice - position.EntryPrice) * position.Quantity
+			report += fmt.Sprintf(" - 보유중, 평가손익 %.0f", profit)
+		}
+		report += "\n"
+	}
+
+	t.sendNotification(notify.Notification{Type: notify.TYPE_REPORT, Title: "📋 요약 리포트", Message: report})
+}
+
+// runMaintenance는 업비트 마켓 목록을 다시 검증하고 제외된 마켓의 신호를 정리합니다.
+// 런타임에 추가하거나 제거한 마켓은 설정의 마켓 목록에 반영해 검증합니다
+func (t *TradingBot) runMaintenance() {
+	logger.Log.Info("=========maintenance===========")
+
+	validMarkets := t.marketHandler.validateAndFilterMarkets(t.ctx, t.runtimeAssets(t.config.TradingConfig().Markets))
+	if len(validMarkets) == 0 {
+		logger.Log.Warn("유효한 마켓이 없어 기존 마켓 목록을 유지합니다. 🟠")
+		return
+	}
+
+	valid := make(map[string]bool, len(validMarkets))
+	for _, m := range validMarkets {
+		valid[m] = true
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.validateMarkets = t.withoutRemovedMarkets(validMarkets)
+	for market := range t.latestSignal {
+		if !valid[market] {
+			logger.Log.Infof("[%v] 더 이상 유효하지 않은 마켓의 신호를 삭제합니다.", market)
+			delete(t.latestSignal, market)
+		}
+	}
+}
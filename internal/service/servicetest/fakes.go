@@ -0,0 +1,294 @@
+package servicetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-trading-bot/internal/clock"
+	"go-trading-bot/internal/model"
+	"go-trading-bot/internal/notify"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrNoCandles     = errors.New("no candles")      // 캔들이 등록되지 않은 마켓을 조회한 경우
+	ErrOrderNotFound = errors.New("order not found") // 없는 uuid로 주문을 조회하거나 취소한 경우
+)
+
+// Exchange는 메모리에 등록한 마켓, 캔들, 잔고로 응답하는 client.ExchangeClient이자 client.OrderClient입니다.
+// 주문은 최신 캔들의 종가로 즉시 체결되고, 접수 응답은 업비트와 같이 대기(wait) 상태로 돌려줍니다
+type Exchange struct {
+	clock clock.Clock
+
+	mu      sync.Mutex
+	markets []model.MarketInfo
+	candles map[string][]model.Candle // 마켓별 캔들, 최신순
+	balance []model.Position
+	err     error
+
+	orders   []model.UpbitOrder
+	feeRate  float64
+	orderErr error
+
+	balanceCalls int
+}
+
+// NewExchange는 서버 시각으로 c를 사용하는 Exchange를 생성합니다
+func NewExchange(c clock.Clock) *Exchange {
+	return &Exchange{clock: c, candles: make(map[string][]model.Candle)}
+}
+
+// AddMarket은 업비트 마켓 목록에 market(KRW-XXX)을 추가합니다
+func (e *Exchange) AddMarket(market string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	asset := strings.TrimPrefix(market, "KRW-")
+	e.markets = append(e.markets, model.MarketInfo{Market: market, KoreanName: asset, EnglishName: asset})
+}
+
+// SetCandles는 마켓의 캔들을 교체합니다. candles는 업비트 응답과 같이 최신순이어야 합니다
+func (e *Exchange) SetCandles(market string, candles []model.Candle) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.candles[market] = append([]model.Candle(nil), candles...)
+}
+
+// SetBalance는 계좌 잔고(API 키 확인에 사용)를 교체합니다
+func (e *Exchange) SetBalance(positions []model.Position) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.balance = append([]model.Position(nil), positions...)
+}
+
+// Fail은 이후 모든 요청이 err를 반환하도록 합니다. nil이면 정상 응답으로 되돌립니다
+func (e *Exchange) Fail(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.err = err
+}
+
+func (e *Exchange) GetAllMarkets(ctx context.Context) ([]model.MarketInfo, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.err != nil {
+		return nil, e.err
+	}
+	return append([]model.MarketInfo(nil), e.markets...), nil
+}
+
+func (e *Exchange) FetchCandles(ctx context.Context, market, path string, count int) ([]model.Candle, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.err != nil {
+		return nil, e.err
+	}
+	candles, exists := e.candles[market]
+	if !exists {
+		return nil, ErrNoCandles
+	}
+	return append([]model.Candle(nil), candles[:min(count, len(candles))]...), nil
+}
+
+func (e *Exchange) FetchTickers(ctx context.Context, markets []string) ([]model.Ticker, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.err != nil {
+		return nil, e.err
+	}
+	tickers := make([]model.Ticker, 0, len(markets))
+	for _, market := range markets {
+		if candles := e.candles[market]; len(candles) > 0 {
+			tickers = append(tickers, model.Ticker{Market: market, TradePrice: candles[0].TradePrice, Timestamp: candles[0].Timestamp})
+		}
+	}
+	return tickers, nil
+}
+
+// BalanceCalls는 FetchBalance가 호출된 횟수입니다
+func (e *Exchange) BalanceCalls() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.balanceCalls
+}
+
+func (e *Exchange) FetchBalance(ctx context.Context, accessKey, secretKey string) ([]model.Position, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.balanceCalls++
+	if e.err != nil {
+		return nil, e.err
+	}
+	return append([]model.Position(nil), e.balance...), nil
+}
+
+func (e *Exchange) ServerTime(ctx context.Context) (time.Time, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.err != nil {
+		return time.Time{}, e.err
+	}
+	return e.clock.Now(), nil
+}
+
+// SetFeeRate는 체결 금액에 대한 주문 수수료율을 설정합니다 (예: 0.0005)
+func (e *Exchange) SetFeeRate(rate float64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.feeRate = rate
+}
+
+// FailOrders는 이후 주문 요청이 err를 반환하도록 합니다. 시세 조회에는 영향을 주지 않으며, nil이면 정상 응답으로 되돌립니다
+func (e *Exchange) FailOrders(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.orderErr = err
+}
+
+// Orders는 접수한 주문을 체결 내역과 함께 접수 순으로 반환합니다
+func (e *Exchange) Orders() []model.UpbitOrder {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]model.UpbitOrder(nil), e.orders...)
+}
+
+func (e *Exchange) GetOrderChance(ctx context.Context, accessKey, secretKey, market string) (*model.UpbitOrderChance, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.orderErr != nil {
+		return nil, e.orderErr
+	}
+	fee := formatNumber(e.feeRate)
+	return &model.UpbitOrderChance{BidFee: fee, AskFee: fee, Market: model.UpbitChanceMarket{ID: market, State: "active"}}, nil
+}
+
+func (e *Exchange) PlaceOrder(ctx context.Context, accessKey, secretKey string, request model.UpbitOrderRequest) (*model.UpbitOrder, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.orderErr != nil {
+		return nil, e.orderErr
+	}
+	candles := e.candles[request.Market]
+	if len(candles) == 0 {
+		return nil, ErrNoCandles
+	}
+	price := candles[0].TradePrice
+
+	var volume, funds float64
+	var err error
+	switch {
+	case request.Side == model.UPBIT_SIDE_BID && request.OrdType == model.UPBIT_ORD_TYPE_PRICE:
+		funds, err = strconv.ParseFloat(request.Price, 64)
+		volume = funds / price
+	case request.Side == model.UPBIT_SIDE_ASK && request.OrdType == model.UPBIT_ORD_TYPE_MARKET:
+		volume, err = strconv.ParseFloat(request.Volume, 64)
+		funds = volume * price
+	default:
+		return nil, fmt.Errorf("unsupported order %s/%s", request.Side, request.OrdType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	uuid := fmt.Sprintf("order-%d", len(e.orders)+1)
+	createdAt := e.clock.Now().Format(time.RFC3339)
+	order := model.UpbitOrder{
+		UUID:           uuid,
+		Side:           request.Side,
+		OrdType:        request.OrdType,
+		Price:          request.Price,
+		Volume:         request.Volume,
+		State:          model.UPBIT_ORDER_DONE,
+		Market:         request.Market,
+		CreatedAt:      createdAt,
+		PaidFee:        formatNumber(funds * e.feeRate),
+		ExecutedVolume: formatNumber(volume),
+		TradesCount:    1,
+		Identifier:     request.Identifier,
+		Trades: []model.UpbitTrade{{
+			Market: request.Market, UUID: uuid + "-trade", Price: formatNumber(price), Volume: formatNumber(volume),
+			Funds: formatNumber(funds), Side: request.Side, CreatedAt: createdAt,
+		}},
+	}
+	e.orders = append(e.orders, order)
+
+	placed := order
+	placed.State, placed.PaidFee, placed.ExecutedVolume, placed.TradesCount, placed.Trades = model.UPBIT_ORDER_WAIT, "0", "0", 0, nil
+	return &placed, nil
+}
+
+func (e *Exchange) GetOrder(ctx context.Context, accessKey, secretKey, uuid string) (*model.UpbitOrder, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.orderErr != nil {
+		return nil, e.orderErr
+	}
+	for _, order := range e.orders {
+		if order.UUID == uuid {
+			return &order, nil
+		}
+	}
+	return nil, ErrOrderNotFound
+}
+
+func (e *Exchange) CancelOrder(ctx context.Context, accessKey, secretKey, uuid string) (*model.UpbitOrder, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.orderErr != nil {
+		return nil, e.orderErr
+	}
+	for i := range e.orders {
+		if e.orders[i].UUID == uuid {
+			if e.orders[i].State == model.UPBIT_ORDER_WAIT {
+				e.orders[i].State = model.UPBIT_ORDER_CANCEL
+			}
+			order := e.orders[i]
+			return &order, nil
+		}
+	}
+	return nil, ErrOrderNotFound
+}
+
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// Prices는 고정된 시세로 응답하는 client.PriceClient입니다
+type Prices struct {
+	Clock   clock.Clock
+	Tickers []model.PriceTicker
+}
+
+func (p *Prices) GetPrices(ctx context.Context) ([]model.PriceTicker, error) {
+	return append([]model.PriceTicker(nil), p.Tickers...), nil
+}
+
+func (p *Prices) ServerTime(ctx context.Context) (time.Time, error) {
+	return p.Clock.Now(), nil
+}
+
+// Recorder는 받은 알림을 기록하는 notify.Notifier입니다
+type Recorder struct {
+	mu            sync.Mutex
+	notifications []notify.Notification
+}
+
+func (r *Recorder) Name() string { return "recorder" }
+
+func (r *Recorder) Notify(n notify.Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.notifications = append(r.notifications, n)
+	return nil
+}
+
+// Take는 지금까지 받은 알림을 반환하고 기록을 비웁니다
+func (r *Recorder) Take() []notify.Notification {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	notifications := r.notifications
+	r.notifications = nil
+	return notifications
+}
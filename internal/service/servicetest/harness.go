@@ -0,0 +1,109 @@
+// Package servicetest는 네트워크 없이 TradingBot의 분석 사이클을 실행하는 테스트 하네스입니다.
+// 거래소, 시계, 설정, 알림, 상태 저장소를 모두 메모리 구현으로 바꾸고, 사이클마다 생긴 주문과 알림을 돌려줍니다
+package servicetest
+
+import (
+	"go-trading-bot/config"
+	"go-trading-bot/internal/clock"
+	"go-trading-bot/internal/model"
+	"go-trading-bot/internal/notify"
+	"go-trading-bot/internal/scheduler"
+	"go-trading-bot/internal/service"
+	"go-trading-bot/internal/store"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Harness는 메모리 구현으로 구성한 TradingBot입니다
+type Harness struct {
+	Bot      *service.TradingBot
+	Exchange *Exchange
+	Clock    *clock.Fake
+	Config   *config.Static
+	Notifier *Recorder
+	Store    *store.MemoryStore
+	Logger   *logrus.Logger // 기본적으로 출력하지 않습니다. 디버깅할 때 SetOutput으로 바꾸세요
+
+	orderCount int
+}
+
+// Result는 사이클 한 번의 결과입니다
+type Result struct {
+	Orders        []model.Order         // 이번 사이클에서 체결된 주문 (체결 순)
+	Notifications []notify.Notification // 이번 사이클에서 보낸 알림 (전송 순)
+	Signals       []model.Signal        // 마켓별 최신 신호 (마켓 순)
+}
+
+// New는 tc로 설정하고 시계를 now에 멈춘 Harness를 생성합니다. tc.Markets는 모두 업비트 지원 마켓으로 등록됩니다
+func New(tc *config.TradingConfig, now time.Time) *Harness {
+	fakeClock := clock.NewFake(now)
+	h := &Harness{
+		Exchange: NewExchange(fakeClock),
+		Clock:    fakeClock,
+		Config:   config.NewStatic(&config.Config{}, tc),
+		Notifier: &Recorder{},
+		Store:    store.NewMemoryStore(),
+		Logger:   logrus.New(),
+	}
+	h.Logger.SetOutput(io.Discard)
+	for _, market := range tc.Markets {
+		h.Exchange.AddMarket("KRW-" + market)
+	}
+
+	h.Bot = service.New(service.Dependencies{
+		Exchange: h.Exchange,
+		Prices:   &Prices{Clock: fakeClock},
+		Clock:    fakeClock,
+		Config:   h.Config,
+		Notifier: h.Notifier,
+		Store:    h.Store,
+		Logger:   h.Logger,
+	})
+	h.Bot.Initialize()
+	return h
+}
+
+// SetPrices는 종가 목록(과거 → 최신)으로 마켓의 캔들을 만듭니다.
+// 마지막 가격이 현재 시각에 진행 중인 캔들이므로, closed-only 설정에서는 그 직전 가격까지만 분석됩니다
+func (h *Harness) SetPrices(market string, prices ...float64) {
+	candleConfig := h.Config.TradingConfig().Candle
+	start := scheduler.CandleStart(candleConfig.Category, candleConfig.Unit, h.Clock.Now())
+
+	candles := make([]model.Candle, len(prices))
+	for i := len(prices) - 1; i >= 0; i-- {
+		price := prices[i]
+		candles[len(prices)-1-i] = model.Candle{
+			Market:            market,
+			CandleDateTimeUTC: start.UTC().Format("2006-01-02T15:04:05"),
+			OpeningPrice:      price,
+			HighPrice:         price,
+			LowPrice:          price,
+			TradePrice:        price,
+			Timestamp:         start.UnixMilli(),
+		}
+		start = scheduler.CandleStart(candleConfig.Category, candleConfig.Unit, start.Add(-time.Nanosecond))
+	}
+	h.Exchange.SetCandles(market, candles)
+}
+
+// RunCycle은 분석 사이클을 한 번 실행하고 그동안 생긴 주문과 알림을 반환합니다
+func (h *Harness) RunCycle() (Result, error) {
+	h.Notifier.Take()
+	err := h.Bot.RunCycle()
+
+	// GetOrders는 최신순이므로 뒤집어서 이번 사이클의 주문만 자릅니다
+	orders := h.Bot.GetOrders(model.OrderFilter{})
+	for i, j := 0, len(orders)-1; i < j; i, j = i+1, j-1 {
+		orders[i], orders[j] = orders[j], orders[i]
+	}
+	newOrders := orders[min(h.orderCount, len(orders)):]
+	h.orderCount = len(orders)
+
+	signals := h.Bot.GetAllLatestSignals()
+	sort.Slice(signals, func(i, j int) bool { return signals[i].Market < signals[j].Market })
+
+	return Result{Orders: newOrders, Notifications: h.Notifier.Take(), Signals: signals}, err
+}
@@ -0,0 +1,284 @@
+package servicetest_test
+
+import (
+	"context"
+	"errors"
+	"go-trading-bot/config"
+	"go-trading-bot/internal/model"
+	"go-trading-bot/internal/service"
+	"go-trading-bot/internal/service/servicetest"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+var start = time.Date(2025, 1, 6, 1, 0, 0, 0, time.UTC)
+
+func crossConfig() *config.TradingConfig {
+	return &config.TradingConfig{
+		Markets:            []string{"BTC"},
+		Strategy:           "moving-average-cross",
+		Candle:             config.Candle{Category: "minutes", Unit: 240},
+		MovingAverageCross: config.MovingAverageCross{ShortPeriod: 2, LongPeriod: 4},
+		OrderAmount:        1000000,
+	}
+}
+
+func TestCycleBuyHoldSell(t *testing.T) {
+	h := servicetest.New(crossConfig(), start)
+
+	// 골든 크로스: 이전 MA2 9 < MA4 9.5, 현재 MA2 14 > MA4 12
+	h.SetPrices("KRW-BTC", 10, 10, 10, 10, 8, 20)
+	result, err := h.RunCycle()
+	if err != nil {
+		t.Fatalf("buy cycle: %v", err)
+	}
+	if len(result.Orders) != 1 || result.Orders[0].Side != model.BUY.String() || result.Orders[0].Price != 20 {
+		t.Fatalf("buy cycle orders = %+v, want one BUY at 20", result.Orders)
+	}
+	if len(result.Signals) != 1 || result.Signals[0].Type != model.BUY {
+		t.Errorf("buy cycle signals = %+v, want BUY", result.Signals)
+	}
+	if !notified(result, "KRW-BTC") {
+		t.Errorf("buy cycle notifications = %+v, want an alert for KRW-BTC", result.Notifications)
+	}
+	if want := start; !result.Orders[0].CreatedAt.Equal(want) {
+		t.Errorf("order time = %v, want the harness clock %v", result.Orders[0].CreatedAt, want)
+	}
+
+	// 교차 없음: 포지션을 유지합니다
+	h.Clock.Advance(4 * time.Hour)
+	h.SetPrices("KRW-BTC", 10, 10, 10, 10, 8, 20, 22)
+	result, err = h.RunCycle()
+	if err != nil {
+		t.Fatalf("hold cycle: %v", err)
+	}
+	if len(result.Orders) != 0 || result.Signals[0].Type != model.HOLD {
+		t.Fatalf("hold cycle = %+v, want HOLD without orders", result)
+	}
+	if positions := h.Bot.GetPositionSummaries(); len(positions) != 1 || positions[0].CurrentPrice != 22 {
+		t.Errorf("positions = %+v, want KRW-BTC valued at 22", positions)
+	}
+
+	// 데드 크로스: 이전 MA2 21 > MA4 15, 현재 MA2 13.5 < MA4 13.75
+	h.Clock.Advance(4 * time.Hour)
+	h.SetPrices("KRW-BTC", 10, 10, 10, 10, 8, 20, 22, 5)
+	result, err = h.RunCycle()
+	if err != nil {
+		t.Fatalf("sell cycle: %v", err)
+	}
+	if len(result.Orders) != 1 || result.Orders[0].Side != model.SELL.String() || result.Orders[0].Profit >= 0 {
+		t.Fatalf("sell cycle orders = %+v, want one losing SELL", result.Orders)
+	}
+	if positions := h.Bot.GetPositionSummaries(); len(positions) != 0 {
+		t.Errorf("positions after sell = %+v, want none", positions)
+	}
+	if status := h.Bot.GetCycleStatus(); status.CycleCount != 3 || status.LastError != "" {
+		t.Errorf("cycle status = %+v, want 3 clean cycles", status)
+	}
+}
+
+func TestCycleReportsExchangeFailure(t *testing.T) {
+	h := servicetest.New(crossConfig(), start)
+	h.SetPrices("KRW-BTC", 10, 10, 10, 10, 8, 20)
+
+	h.Exchange.Fail(errors.New("upbit unavailable"))
+	result, err := h.RunCycle()
+	if len(result.Orders) != 0 {
+		t.Errorf("orders = %+v, want none while the exchange is down", result.Orders)
+	}
+	if err == nil || !strings.Contains(err.Error(), "KRW-BTC") {
+		t.Fatalf("cycle error = %v, want the failed market", err)
+	}
+	if status := h.Bot.GetCycleStatus(); status.LastError != err.Error() || status.LastErrorAt.IsZero() {
+		t.Errorf("last error = %q at %v, want %q", status.LastError, status.LastErrorAt, err)
+	}
+
+	// 거래소가 복구되면 다음 사이클은 정상 처리되고 이전 오류는 지워집니다
+	h.Exchange.Fail(nil)
+	h.Clock.Advance(4 * time.Hour)
+	h.SetPrices("KRW-BTC", 10, 10, 10, 10, 10, 8, 20)
+	result, err = h.RunCycle()
+	if err != nil {
+		t.Fatalf("recovered cycle: %v", err)
+	}
+	if len(result.Orders) != 1 || result.Orders[0].Side != model.BUY.String() {
+		t.Errorf("recovered cycle orders = %+v, want one BUY", result.Orders)
+	}
+	if status := h.Bot.GetCycleStatus(); status.LastError != "" || !status.LastErrorAt.IsZero() {
+		t.Errorf("last error = %q at %v, want cleared after success", status.LastError, status.LastErrorAt)
+	}
+}
+
+func liveConfig() *config.TradingConfig {
+	tc := crossConfig()
+	tc.LiveTrading = true
+	return tc
+}
+
+func TestLiveCycleSendsOrdersToExchange(t *testing.T) {
+	h := servicetest.New(liveConfig(), start)
+	h.Config.Config().AccessKey = "test-access-key"
+	h.Config.Config().SecretKey = "test-secret-key"
+	h.Exchange.SetFeeRate(0.0005)
+
+	h.SetPrices("KRW-BTC", 10, 10, 10, 10, 8, 20)
+	result, err := h.RunCycle()
+	if err != nil {
+		t.Fatalf("buy cycle: %v", err)
+	}
+	if len(result.Orders) != 1 || result.Orders[0].Price != 20 || result.Orders[0].Quantity != 50000 {
+		t.Fatalf("buy cycle orders = %+v, want 50000 filled at 20", result.Orders)
+	}
+	buy := result.Orders[0]
+
+	h.Clock.Advance(8 * time.Hour)
+	h.SetPrices("KRW-BTC", 10, 10, 10, 10, 8, 20, 22, 5)
+	result, err = h.RunCycle()
+	if err != nil {
+		t.Fatalf("sell cycle: %v", err)
+	}
+	// 매수 수수료 500원은 진입가에 포함됩니다: 매도 금액 250,000 - 수수료 125 - 매수 원가 (1,000,000 + 500)
+	if len(result.Orders) != 1 || result.Orders[0].Price != 5 || math.Abs(result.Orders[0].Profit-(-750625)) > 1e-6 {
+		t.Fatalf("sell cycle orders = %+v, want a SELL at 5 with profit -750625", result.Orders)
+	}
+	sell := result.Orders[0]
+
+	orders := h.Exchange.Orders()
+	if len(orders) != 2 {
+		t.Fatalf("exchange orders = %+v, want a buy and a sell", orders)
+	}
+	if o := orders[0]; o.Side != model.UPBIT_SIDE_BID || o.OrdType != model.UPBIT_ORD_TYPE_PRICE || o.Price != "1000000" || o.Identifier != buy.ID {
+		t.Errorf("buy request = %+v, want a 1,000,000 KRW market bid identified by %s", o, buy.ID)
+	}
+	if o := orders[1]; o.Side != model.UPBIT_SIDE_ASK || o.OrdType != model.UPBIT_ORD_TYPE_MARKET || o.Volume != "50000" || o.Identifier != sell.ID {
+		t.Errorf("sell request = %+v, want a market ask of 50000 identified by %s", o, sell.ID)
+	}
+}
+
+func TestLiveCycleRejectsFailedOrders(t *testing.T) {
+	h := servicetest.New(liveConfig(), start)
+	h.SetPrices("KRW-BTC", 10, 10, 10, 10, 8, 20)
+
+	// API 키가 없으면 주문을 보내지 않습니다
+	result, _ := h.RunCycle()
+	if len(result.Orders) != 0 || len(h.Exchange.Orders()) != 0 {
+		t.Errorf("orders without keys = %+v, want none", result.Orders)
+	}
+
+	h.Config.Config().AccessKey = "test-access-key"
+	h.Config.Config().SecretKey = "test-secret-key"
+	h.Exchange.FailOrders(errors.New("insufficient_funds_bid"))
+	result, _ = h.RunCycle()
+	if len(result.Orders) != 0 {
+		t.Errorf("orders = %+v, want none when the exchange rejects the order", result.Orders)
+	}
+
+	// 거래소가 주문을 받으면 같은 신호로 매수합니다
+	h.Exchange.FailOrders(nil)
+	result, _ = h.RunCycle()
+	if len(result.Orders) != 1 || len(h.Exchange.Orders()) != 1 {
+		t.Errorf("orders = %+v, want one BUY once the exchange accepts orders", result.Orders)
+	}
+}
+
+func notified(result servicetest.Result, market string) bool {
+	for _, n := range result.Notifications {
+		if n.Market == market || strings.Contains(n.Message, market) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestControlOrdersUseLiveOrderPath(t *testing.T) {
+	h := servicetest.New(liveConfig(), start)
+	h.Config.Config().AccessKey = "test-access-key"
+	h.Config.Config().SecretKey = "test-secret-key"
+	h.SetPrices("KRW-BTC", 10, 10, 10, 10, 10, 10)
+
+	// 수동 주문과 강제 청산도 신호 주문과 같이 업비트로 주문합니다
+	buy, err := h.Bot.PlaceManualOrder("test", "KRW-BTC", model.BUY, 500000)
+	if err != nil || buy.Quantity != 50000 {
+		t.Fatalf("manual buy = %+v, %v, want 50000 filled", buy, err)
+	}
+	sell, err := h.Bot.ClosePosition("test", "KRW-BTC")
+	if err != nil || sell.Side != model.SELL.String() || sell.Quantity != 50000 {
+		t.Fatalf("close = %+v, %v, want a SELL of 50000", sell, err)
+	}
+	if _, err := h.Bot.PlaceManualOrder("test", "KRW-BTC", model.BUY, 0); err != nil {
+		t.Fatalf("manual buy: %v", err)
+	}
+	if orders, err := h.Bot.CloseAllPositions("test"); err != nil || len(orders) != 1 {
+		t.Fatalf("close all = %+v, %v, want one SELL", orders, err)
+	}
+
+	sides := make([]string, 0, 4)
+	for _, order := range h.Exchange.Orders() {
+		sides = append(sides, order.Side+" "+order.Price+order.Volume)
+	}
+	if got, want := strings.Join(sides, ", "), "bid 500000, ask 50000, bid 1000000, ask 100000"; got != want {
+		t.Errorf("exchange orders = %s, want %s", got, want)
+	}
+	if positions := h.Bot.GetPositionSummaries(); len(positions) != 0 {
+		t.Errorf("positions = %+v, want none after close-all", positions)
+	}
+}
+
+func TestReadinessReusesBalanceCheck(t *testing.T) {
+	h := servicetest.New(liveConfig(), start)
+	h.Config.Config().AccessKey = "test-access-key"
+	h.Config.Config().SecretKey = "test-secret-key"
+	h.SetPrices("KRW-BTC", 10, 10, 10, 10, 10, 10)
+	ctx := context.Background()
+
+	if check := credentials(h.Bot.Readiness(ctx)); check.Status != model.HEALTH_OK {
+		t.Errorf("credentials = %+v, want ok", check)
+	}
+	if got := h.Exchange.BalanceCalls(); got != 1 {
+		t.Fatalf("balance calls = %d, want 1", got)
+	}
+
+	// HEALTH_CACHE_TTL이 지나 다시 확인해도 BALANCE_CHECK_TTL 안이면 계좌를 다시 조회하지 않습니다
+	h.Clock.Advance(service.HEALTH_CACHE_TTL + time.Second)
+	h.Bot.Readiness(ctx)
+	if got := h.Exchange.BalanceCalls(); got != 1 {
+		t.Errorf("balance calls = %d, want the previous check reused", got)
+	}
+
+	h.Clock.Advance(service.BALANCE_CHECK_TTL)
+	h.Bot.Readiness(ctx)
+	if got := h.Exchange.BalanceCalls(); got != 2 {
+		t.Errorf("balance calls = %d, want 2 after BALANCE_CHECK_TTL", got)
+	}
+}
+
+func credentials(report model.HealthReport) model.HealthCheck {
+	for _, check := range report.Checks {
+		if check.Name == "credentials" {
+			return check
+		}
+	}
+	return model.HealthCheck{}
+}
+
+func TestRunAfterShutdownDoesNotStartScheduler(t *testing.T) {
+	h := servicetest.New(crossConfig(), start)
+	h.SetPrices("KRW-BTC", 10, 10, 10, 10, 10, 10)
+	if err := h.Bot.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	// Shutdown이 jobs.Wait를 시작한 뒤에는 스케줄러 작업을 등록하지 않고 바로 반환해야 합니다
+	done := make(chan struct{})
+	go func() {
+		h.Bot.RunTradingBot(make(chan struct{}))
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("RunTradingBot started a scheduler after Shutdown")
+	}
+}
@@ -0,0 +1,152 @@
+package servicetest_test
+
+import (
+	"errors"
+	"go-trading-bot/internal/model"
+	"go-trading-bot/internal/scheduler"
+	"go-trading-bot/internal/service/servicetest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// roundTrip은 market을 buy에 1,000,000원어치 사고 sell에 청산합니다
+func roundTrip(t *testing.T, h *servicetest.Harness, market string, buy, sell float64) {
+	t.Helper()
+	h.SetPrices(market, buy)
+	if _, err := h.Bot.PlaceManualOrder("test", market, model.BUY, 0); err != nil {
+		t.Fatalf("buy %s: %v", market, err)
+	}
+	h.SetPrices(market, sell)
+	if _, err := h.Bot.ClosePosition("test", market); err != nil {
+		t.Fatalf("close %s: %v", market, err)
+	}
+}
+
+func TestPnLSummaryByMarketAndDay(t *testing.T) {
+	tc := crossConfig()
+	tc.Markets = []string{"BTC", "ETH"}
+	h := servicetest.New(tc, start)
+
+	// 2025-01-06 10:00 KST
+	roundTrip(t, h, "KRW-BTC", 100, 110) // +100,000
+	roundTrip(t, h, "KRW-ETH", 50, 40)   // -200,000
+	// 2025-01-07 01:00 KST (UTC로는 아직 1월 6일)
+	h.Clock.Advance(15 * time.Hour)
+	roundTrip(t, h, "KRW-BTC", 100, 105) // +50,000
+
+	got := h.Bot.GetPnLSummary(model.OrderFilter{})
+	want := model.PnLSummary{
+		TotalProfit: -50000,
+		TradeCount:  3,
+		WinCount:    2,
+		ByMarket: []model.PnLEntry{
+			{Key: "KRW-BTC", Profit: 150000, TradeCount: 2, WinCount: 2},
+			{Key: "KRW-ETH", Profit: -200000, TradeCount: 1, WinCount: 0},
+		},
+		ByDay: []model.PnLEntry{
+			{Key: "2025-01-06", Profit: -100000, TradeCount: 2, WinCount: 1},
+			{Key: "2025-01-07", Profit: 50000, TradeCount: 1, WinCount: 1},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("summary = %+v, want %+v", got, want)
+	}
+
+	if got := h.Bot.GetPnLSummary(model.OrderFilter{Market: "KRW-ETH"}); got.TotalProfit != -200000 || got.TradeCount != 1 {
+		t.Errorf("KRW-ETH summary = %+v, want one trade of -200000", got)
+	}
+	day := time.Date(2025, 1, 7, 0, 0, 0, 0, scheduler.KST)
+	if got := h.Bot.GetPnLSummary(model.OrderFilter{From: day}); got.TotalProfit != 50000 || len(got.ByDay) != 1 {
+		t.Errorf("summary from %v = %+v, want only 2025-01-07", day, got)
+	}
+}
+
+func TestPositionSummariesUnrealizedPnL(t *testing.T) {
+	h := servicetest.New(crossConfig(), start)
+	h.SetPrices("KRW-BTC", 20)
+	if _, err := h.Bot.PlaceManualOrder("test", "KRW-BTC", model.BUY, 500000); err != nil {
+		t.Fatalf("buy: %v", err)
+	}
+
+	// 25,000개 @20 → 현재가 22: (22 - 20) × 25,000 = 50,000원, 10%
+	h.SetPrices("KRW-BTC", 22)
+	summaries := h.Bot.GetPositionSummaries()
+	if len(summaries) != 1 {
+		t.Fatalf("summaries = %+v, want one position", summaries)
+	}
+	s := summaries[0]
+	if s.Market != "KRW-BTC" || s.Quantity != 25000 || s.EntryPrice != 20 || s.CurrentPrice != 22 {
+		t.Errorf("summary = %+v, want 25000 KRW-BTC @20 priced at 22", s)
+	}
+	if s.UnrealizedProfit != 50000 || s.UnrealizedProfitRate != 10 {
+		t.Errorf("unrealized = %v (%v%%), want 50000 (10%%)", s.UnrealizedProfit, s.UnrealizedProfitRate)
+	}
+
+	// 현재가를 알 수 없으면 평가손익을 계산하지 않습니다
+	h.Exchange.Fail(errors.New("exchange down"))
+	s = h.Bot.GetPositionSummaries()[0]
+	if s.CurrentPrice != 0 || s.UnrealizedProfit != 0 || s.UnrealizedProfitRate != 0 {
+		t.Errorf("summary without price = %+v, want no unrealized PnL", s)
+	}
+}
+
+func TestLivePositionsOrdersAndPnLShareLedger(t *testing.T) {
+	h := servicetest.New(liveConfig(), start)
+	h.Config.Config().AccessKey = "test-access-key"
+	h.Config.Config().SecretKey = "test-secret-key"
+	// 계좌에는 봇이 주문하지 않은 잔고도 있습니다
+	h.Exchange.SetBalance([]model.Position{{Market: "KRW", Quantity: 5000000}, {Market: "ETH", Quantity: 3, EntryPrice: 4000000}})
+
+	h.SetPrices("KRW-BTC", 20)
+	if _, err := h.Bot.PlaceManualOrder("test", "KRW-BTC", model.BUY, 500000); err != nil {
+		t.Fatalf("buy: %v", err)
+	}
+
+	// /positions, /orders, /pnl은 모두 봇이 체결한 주문 기록만 봅니다
+	positions := h.Bot.GetPositionSummaries()
+	if len(positions) != 1 || positions[0].Market != "KRW-BTC" || positions[0].Quantity != 25000 {
+		t.Fatalf("positions = %+v, want only the bot's KRW-BTC fill", positions)
+	}
+	if orders := h.Bot.GetOrders(model.OrderFilter{}); len(orders) != 1 || orders[0].Quantity != positions[0].Quantity {
+		t.Errorf("orders = %+v, want the BUY behind the position", orders)
+	}
+
+	h.SetPrices("KRW-BTC", 24)
+	sell, err := h.Bot.ClosePosition("test", "KRW-BTC")
+	if err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if got := h.Bot.GetPnLSummary(model.OrderFilter{}); got.TotalProfit != sell.Profit || got.TotalProfit != 100000 {
+		t.Errorf("pnl = %v, want the SELL's profit 100000", got.TotalProfit)
+	}
+	if positions := h.Bot.GetPositionSummaries(); len(positions) != 0 {
+		t.Errorf("positions = %+v, want none after close", positions)
+	}
+}
+
+func TestLivePerformanceReportUsesLedger(t *testing.T) {
+	h := servicetest.New(liveConfig(), start)
+	h.Config.Config().AccessKey = "test-access-key"
+	h.Config.Config().SecretKey = "test-secret-key"
+	// 봇이 주문하지 않은 계좌 잔고는 평가손익에 넣지 않습니다
+	h.Exchange.SetBalance([]model.Position{{Market: "ETH", Quantity: 3, EntryPrice: 4000000}})
+
+	roundTrip(t, h, "KRW-BTC", 20, 24) // +200,000
+	h.SetPrices("KRW-BTC", 20)
+	if _, err := h.Bot.PlaceManualOrder("test", "KRW-BTC", model.BUY, 500000); err != nil {
+		t.Fatalf("buy: %v", err)
+	}
+	h.SetPrices("KRW-BTC", 22) // 25,000개 평가손익 +50,000
+	h.Clock.Advance(time.Hour)
+
+	m := h.Bot.GetPerformanceReport(model.OrderFilter{}, 1000000).Metrics
+	if m.FinalEquity != 1250000 || m.Trades != 1 {
+		t.Errorf("final equity %v over %d trades, want 1250000 over 1", m.FinalEquity, m.Trades)
+	}
+	// 조회 기간이 끝났으면 평가손익을 더하지 않습니다
+	m = h.Bot.GetPerformanceReport(model.OrderFilter{To: h.Clock.Now()}, 1000000).Metrics
+	if m.FinalEquity != 1200000 {
+		t.Errorf("final equity to now = %v, want realized 1200000", m.FinalEquity)
+	}
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"go-trading-bot/internal/logger"
+	"go-trading-bot/internal/model"
+	"io/fs"
+	"time"
+)
+
+// Shutdown은 봇을 정리합니다.
+//  1. 새 사이클 실행을 막고 실행 중인 사이클이 끝날 때까지 기다립니다
+//  2. 실행 중인 스케줄러 작업(리포트, 마켓 점검 등)이 끝날 때까지 기다립니다
+//  3. 실행 중인 주문이 끝나길 기다린 뒤 새 주문을 막습니다
+//  4. 포지션, 주문 기록, 일시정지 상태를 저장합니다
+//
+// ctx가 만료되면 진행 중인 거래소 요청을 취소하고 그 시점의 상태를 저장합니다
+func (t *TradingBot) Shutdown(ctx context.Context) error {
+	defer t.cancel()
+
+	var errs []error
+	logger.Log.Info("실행 중인 사이클이 끝나길 기다립니다. 🔘")
+	if err := t.cycleCoordinator.Stop(ctx); err != nil {
+		logger.Log.Errorf("사이클이 종료 기한 안에 끝나지 않아 진행 중인 요청을 취소합니다. %v 🔴", err)
+		t.cancel()
+		errs = append(errs, err)
+	}
+
+	logger.Log.Info("실행 중인 스케줄러 작업이 끝나길 기다립니다. 🔘")
+	if err := t.waitJobs(ctx); err != nil {
+		logger.Log.Errorf("스케줄러 작업이 종료 기한 안에 끝나지 않아 진행 중인 요청을 취소합니다. %v 🔴", err)
+		t.cancel()
+		errs = append(errs, err)
+	}
+
+	t.orderService.Close()
+
+	if err := t.saveState(); err != nil {
+		logger.Log.Errorf("봇 상태를 저장하지 못했습니다. %v 🔴", err)
+		errs = append(errs, err)
+	}
+
+	logger.Log.Info("봇 종료 완료 🟢")
+	return errors.Join(errs...)
+}
+
+// waitJobs는 새 스케줄러 작업 등록을 막고, RunTradingBot이 시작한 작업이 모두 끝나거나 ctx가 만료될 때까지 기다립니다
+func (t *TradingBot) waitJobs(ctx context.Context) error {
+	t.jobsMu.Lock()
+	t.jobsClosed = true
+	t.jobsMu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		t.jobs.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// saveState는 포지션, 주문 기록, 일시정지 상태를 저장합니다. 상태 파일이 설정되지 않았으면 저장하지 않습니다
+func (t *TradingBot) saveState() error {
+	if t.store == nil {
+		return nil
+	}
+
+	positions, orders := t.orderService.Snapshot()
+	state := model.BotState{
+		Positions: positions,
+		Orders:    orders,
+		Paused:    t.riskManager.IsPaused(),
+		SavedAt:   t.clock.Now(),
+	}
+	if err := t.store.Save(state); err != nil {
+		return err
+	}
+
+	logger.Log.Infof("봇 상태를 저장했습니다. (포지션 %v건, 주문 %v건) 🟢", len(positions), len(orders))
+	return nil
+}
+
+// restoreState는 저장된 상태를 복원합니다. 파일이 없으면 새로 시작합니다
+func (t *TradingBot) restoreState() {
+	state, err := t.store.Load()
+	if errors.Is(err, fs.ErrNotExist) {
+		return
+	}
+	if err != nil {
+		logger.Log.Errorf("저장된 봇 상태를 읽을 수 없어 새로 시작합니다. %v 🔴", err)
+		return
+	}
+
+	t.orderService.Restore(state.Positions, state.Orders)
+	if state.Paused {
+		t.riskManager.Pause()
+	}
+	logger.Log.Infof("저장된 봇 상태를 복원했습니다. (%v 저장, 포지션 %v건, 주문 %v건, 일시정지: %v) 🟢",
+		state.SavedAt.Format(time.DateTime), len(state.Positions), len(state.Orders), state.Paused)
+}
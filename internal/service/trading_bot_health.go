@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-trading-bot/config"
+	"go-trading-bot/internal/client"
+	"go-trading-bot/internal/clock"
+	"go-trading-bot/internal/model"
+	"go-trading-bot/internal/notify"
+	"go-trading-bot/internal/scheduler"
+	"sync"
+	"time"
+)
+
+// 준비 상태 확인 기준
+const (
+	HEALTH_CACHE_TTL     = 10 * time.Second // 결과를 재사용하는 시간 (거래소 요청 수 제한 보호)
+	HEALTH_CHECK_TIMEOUT = 5 * time.Second  // 거래소 확인 요청의 최대 대기 시간
+	BALANCE_CHECK_TTL    = 5 * time.Minute  // 계좌 조회 결과를 재사용하는 시간
+	CLOCK_SKEW_WARN      = 2 * time.Second
+	CLOCK_SKEW_FAIL      = 30 * time.Second
+	CYCLE_STALE_PERIODS  = 3 // 마지막 성공 사이클이 분석 주기의 몇 배보다 오래되면 실패로 봅니다
+)
+
+// healthCache는 마지막 준비 상태 확인 결과입니다
+type healthCache struct {
+	mu     sync.Mutex
+	report model.HealthReport
+}
+
+// Liveness는 프로세스가 살아 있는지 확인합니다. 스케줄러 루프만 확인하며 외부 요청은 하지 않습니다
+func (t *TradingBot) Liveness() model.HealthReport {
+	return model.NewHealthReport([]model.HealthCheck{t.checkScheduler()}, t.clock.Now())
+}
+
+// Readiness는 봇이 정상적으로 거래할 수 있는지 확인합니다.
+// 스케줄러, 마지막 성공 사이클, 거래소 연결과 시계 오차, API 키, 알림 채널, 설정 상태를 확인하며 결과는 HEALTH_CACHE_TTL 동안 재사용합니다
+func (t *TradingBot) Readiness(ctx context.Context) model.HealthReport {
+	t.health.mu.Lock()
+	defer t.health.mu.Unlock()
+
+	now := t.clock.Now()
+	if !t.health.report.CheckedAt.IsZero() && now.Sub(t.health.report.CheckedAt) < HEALTH_CACHE_TTL {
+		return t.health.report
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, HEALTH_CHECK_TIMEOUT)
+	defer cancel()
+
+	checks := []model.HealthCheck{
+		t.checkScheduler(),
+		t.checkCycle(now),
+		checkExchange("upbit", t.marketHandler.upbitAPIClient.ServerTime, ctx, model.HEALTH_FAIL, t.clock),
+		// 바이낸스 시세는 참고용이므로 연결되지 않아도 준비 상태로 봅니다
+		checkExchange("binance", t.marketHandler.binanceAPIClient.ServerTime, ctx, model.HEALTH_WARN, t.clock),
+		t.checkCredentials(ctx),
+		t.checkNotifier(),
+		t.checkConfig(),
+	}
+
+	t.health.report = model.NewHealthReport(checks, now)
+	return t.health.report
+}
+
+func (t *TradingBot) checkScheduler() model.HealthCheck {
+	check := model.HealthCheck{Name: "scheduler", Status: model.HEALTH_OK}
+	if !t.loopRunning.Load() {
+		check.Status = model.HEALTH_FAIL
+		check.Message = "scheduler loop is not running"
+	}
+	return check
+}
+
+// checkCycle은 마지막 성공 사이클이 분석 주기의 CYCLE_STALE_PERIODS배 안에 있는지 확인합니다.
+// 시작 직후 아직 성공한 사이클이 없으면 warn으로 봅니다
+func (t *TradingBot) checkCycle(now time.Time) model.HealthCheck {
+	status := t.cycleCoordinator.Status()
+	limit := analysisPeriod(t.config.TradingConfig(), t.clock, now) * CYCLE_STALE_PERIODS
+
+	t.mu.RLock()
+	startedAt := t.startedAt
+	t.mu.RUnlock()
+
+	check := model.HealthCheck{
+		Name:    "cycle",
+		Status:  model.HEALTH_OK,
+		Details: map[string]any{"running": status.Running, "cycle_count": status.CycleCount, "stale_after": limit.String()},
+	}
+	if status.LastError != "" {
+		check.Details["last_error"] = status.LastError
+		check.Details["last_error_at"] = status.LastErrorAt
+	}
+
+	if status.LastSuccess.IsZero() {
+		if !startedAt.IsZero() && now.Sub(startedAt) < limit {
+			check.Status = model.HEALTH_WARN
+			check.Message = "no successful cycle yet"
+		} else {
+			check.Status = model.HEALTH_FAIL
+			check.Message = fmt.Sprintf("no successful cycle within %v", limit)
+		}
+		return check
+	}
+
+	age := now.Sub(status.LastSuccess)
+	check.Details["last_success"] = status.LastSuccess
+	check.Details["age"] = age.Round(time.Second).String()
+	if age > limit {
+		check.Status = model.HEALTH_FAIL
+		check.Message = fmt.Sprintf("last successful cycle was %v ago", age.Round(time.Second))
+	}
+	return check
+}
+
+// analysisPeriod는 현재 분석 스케줄의 실행 간격을 계산합니다
+func analysisPeriod(tradingConfig *config.TradingConfig, clk clock.Clock, now time.Time) time.Duration {
+	if tradingConfig == nil {
+		return time.Minute
+	}
+	schedule := scheduler.NewAnalysisSchedule(tradingConfig, clk)
+	next := schedule.Next(now)
+	period := schedule.Next(next).Sub(next)
+	if period <= 0 {
+		return time.Minute
+	}
+	return period
+}
+
+// checkExchange는 거래소 연결과 서버 시각 대비 clk의 시계 오차를 확인합니다. 연결에 실패하면 failStatus로 표시합니다
+func checkExchange(name string, serverTime func(context.Context) (time.Time, error), ctx context.Context, failStatus string, clk clock.Clock) model.HealthCheck {
+	check := model.HealthCheck{Name: name, Status: model.HEALTH_OK}
+
+	requestedAt := clk.Now()
+	server, err := serverTime(ctx)
+	if err != nil {
+		check.Status = failStatus
+		check.Message = err.Error()
+		return check
+	}
+	latency := clk.Now().Sub(requestedAt)
+
+	// 서버 시각은 요청과 응답의 중간 시점으로 간주합니다
+	skew := server.Sub(requestedAt.Add(latency / 2))
+	check.Details = map[string]any{"latency": latency.Round(time.Millisecond).String(), "clock_skew": skew.Round(time.Millisecond).String()}
+	if skew < 0 {
+		skew = -skew
+	}
+	switch {
+	case skew > CLOCK_SKEW_FAIL:
+		check.Status = model.HEALTH_FAIL
+		check.Message = fmt.Sprintf("clock skew exceeds %v", CLOCK_SKEW_FAIL)
+	case skew > CLOCK_SKEW_WARN:
+		check.Status = model.HEALTH_WARN
+		check.Message = fmt.Sprintf("clock skew exceeds %v", CLOCK_SKEW_WARN)
+	}
+	return check
+}
+
+// checkCredentials는 업비트 API 키로 계좌 조회가 되는지 확인합니다. 키가 없으면 실거래 모드에서만 실패로 봅니다.
+// 이전 확인에서 BALANCE_CHECK_TTL 안에 조회한 결과가 있으면 계좌를 다시 조회하지 않습니다
+func (t *TradingBot) checkCredentials(ctx context.Context) model.HealthCheck {
+	check := model.HealthCheck{Name: "credentials", Status: model.HEALTH_OK}
+	c := t.config.Config()
+	if c.AccessKey == "" || c.SecretKey == "" {
+		if tc := t.config.TradingConfig(); tc != nil && tc.LiveTrading {
+			check.Status = model.HEALTH_FAIL
+			check.Message = "live-trading requires ACCESS_KEY and SECRET_KEY"
+		} else {
+			check.Status = model.HEALTH_SKIP
+			check.Message = "ACCESS_KEY or SECRET_KEY is not set"
+		}
+		return check
+	}
+
+	checkedAt, err := t.marketHandler.LastBalanceCheck()
+	if checkedAt.IsZero() || t.clock.Now().Sub(checkedAt) >= BALANCE_CHECK_TTL {
+		_, err = t.marketHandler.fetchBalance(ctx, c.AccessKey, c.SecretKey)
+		checkedAt, _ = t.marketHandler.LastBalanceCheck()
+	}
+	check.Details = map[string]any{"checked_at": checkedAt}
+	if err != nil {
+		check.Status = model.HEALTH_WARN
+		if errors.Is(err, client.ErrUnauthorized) {
+			check.Status = model.HEALTH_FAIL
+		}
+		check.Message = err.Error()
+	}
+	return check
+}
+
+// checkNotifier는 알림 채널별 마지막 전송 결과를 확인합니다. 마지막 전송이 실패한 채널이 있으면 warn으로 봅니다
+func (t *TradingBot) checkNotifier() model.HealthCheck {
+	check := model.HealthCheck{Name: "notifier", Status: model.HEALTH_OK}
+	reporter, ok := t.notifier.(notify.StatusReporter)
+	if !ok {
+		check.Status = model.HEALTH_SKIP
+		check.Message = "no notification channels"
+		return check
+	}
+
+	statuses := reporter.Status()
+	if len(statuses) == 0 {
+		check.Status = model.HEALTH_SKIP
+		check.Message = "no notification channels"
+		return check
+	}
+
+	check.Details = make(map[string]any, len(statuses))
+	var failing []string
+	for _, status := range statuses {
+		check.Details[status.Name] = status
+		if status.LastErrorAt.After(status.LastSuccess) {
+			failing = append(failing, status.Name)
+		}
+	}
+	if len(failing) > 0 {
+		check.Status = model.HEALTH_WARN
+		check.Message = fmt.Sprintf("last delivery failed: %v", failing)
+	}
+	return check
+}
+
+// checkConfig는 설정이 로드되었는지와 마지막 설정 리로드 결과를 확인합니다
+func (t *TradingBot) checkConfig() model.HealthCheck {
+	check := model.HealthCheck{Name: "config", Status: model.HEALTH_OK}
+	tradingConfig := t.config.TradingConfig()
+	if tradingConfig == nil {
+		check.Status = model.HEALTH_FAIL
+		check.Message = "trading config is not loaded"
+		return check
+	}
+
+	t.mu.RLock()
+	lastReload := t.lastReload
+	markets := len(t.validateMarkets)
+	t.mu.RUnlock()
+
+	check.Details = map[string]any{"strategy": tradingConfig.Strategy, "markets": markets}
+	if !lastReload.at.IsZero() {
+		check.Details["last_reload_at"] = lastReload.at
+	}
+
+	switch {
+	case markets == 0:
+		check.Status = model.HEALTH_FAIL
+		check.Message = "no valid markets"
+	case lastReload.err != nil:
+		check.Status = model.HEALTH_WARN
+		check.Message = fmt.Sprintf("last reload failed, keeping previous config: %v", lastReload.err)
+	}
+	return check
+}
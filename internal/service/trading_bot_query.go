@@ -0,0 +1,241 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-trading-bot/config"
+	"go-trading-bot/internal/analytics"
+	"go-trading-bot/internal/model"
+	"go-trading-bot/internal/scheduler"
+	"go-trading-bot/internal/strategy"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// maxChartCandleCount는 차트용 캔들 조회 최대 개수입니다 (업비트 캔들 API 최대 200개, 마감 캔들만 사용 시 1개 추가 조회)
+const maxChartCandleCount = 199
+
+// GetPositionSummaries는 보유 포지션을 현재가 기준 평가손익과 함께 반환합니다.
+// 주문 기록, 손익과 같이 OrderService의 포지션을 사용합니다 (실거래 모드에서도 봇이 체결한 주문만 반영)
+func (t *TradingBot) GetPositionSummaries() []model.PositionSummary {
+	ctx := t.ctx
+	positions := t.orderService.GetPositions()
+
+	markets := make([]string, 0, len(positions))
+	for _, p := range positions {
+		if market := positionMarket(p); market != "" {
+			markets = append(markets, market)
+		}
+	}
+	prices := t.marketHandler.GetCurrentPrices(ctx, markets)
+
+	summaries := make([]model.PositionSummary, 0, len(positions))
+	for _, p := range positions {
+		market := positionMarket(p)
+		if market == "" {
+			continue
+		}
+
+		currentPrice, exists := prices[market]
+		if !exists {
+			// 현재가 조회에 실패하면 마지막 신호의 가격을 사용합니다
+			currentPrice = t.GetLatestSignal(market).CurrentPrice
+		}
+
+		summary := model.PositionSummary{Position: p, CurrentPrice: currentPrice}
+		if currentPrice > 0 {
+			summary.UnrealizedProfit = (currentPrice - p.EntryPrice) * p.Quantity
+			if p.EntryPrice > 0 {
+				summary.UnrealizedProfitRate = (currentPrice - p.EntryPrice) / p.EntryPrice * 100
+			}
+		}
+		summaries = append(summaries, summary)
+	}
+
+	return summaries
+}
+
+// positionMarket은 포지션의 마켓 코드(KRW-XXX)를 반환합니다. 원화 잔고는 빈 문자열을 반환합니다
+func positionMarket(p model.Position) string {
+	if strings.Contains(p.Market, "-") {
+		return p.Market
+	}
+	if p.Market == "KRW" {
+		return ""
+	}
+	return "KRW-" + p.Market
+}
+
+// GetOrders는 조건에 맞는 주문 기록을 최신순으로 반환합니다
+func (t *TradingBot) GetOrders(filter model.OrderFilter) []model.Order {
+	return t.orderService.GetOrders(filter)
+}
+
+// GetPnLSummary는 조건에 맞는 매도 주문의 실현 손익을 마켓별, 일자별로 집계합니다
+func (t *TradingBot) GetPnLSummary(filter model.OrderFilter) model.PnLSummary {
+	filter.Side = model.SELL.String()
+	orders := t.orderService.GetOrders(filter)
+
+	var summary model.PnLSummary
+	byMarket := make(map[string]*model.PnLEntry)
+	byDay := make(map[string]*model.PnLEntry)
+
+	for _, order := range orders {
+		win := order.Profit > 0
+		summary.TotalProfit += order.Profit
+		summary.TradeCount++
+		if win {
+			summary.WinCount++
+		}
+
+		addPnL(byMarket, order.Market, order.Profit)
+		addPnL(byDay, order.CreatedAt.In(scheduler.KST).Format("2006-01-02"), order.Profit)
+	}
+
+	summary.ByMarket = sortedPnLEntries(byMarket)
+	summary.ByDay = sortedPnLEntries(byDay)
+	return summary
+}
+
+// GetPerformanceReport는 조건에 맞는 주문 기록으로 성과 보고서를 만듭니다. capital이 0이면 order-amount × max(max-positions, 마켓 수)입니다.
+// 자산 곡선은 시작 자산에 실현 손익을 더한 일별(KST) 곡선이며, 조회 기간이 현재를 포함하면 같은 주문 기록의 보유 포지션 평가손익을 마지막에 더합니다
+func (t *TradingBot) GetPerformanceReport(filter model.OrderFilter, capital float64) *analytics.Report {
+	filter.Side = ""
+	orders := t.orderService.GetOrders(filter)
+	slices.Reverse(orders)
+
+	if capital <= 0 {
+		tradingConfig := t.config.TradingConfig()
+		capital = tradingConfig.OrderAmount * float64(max(tradingConfig.Risk.MaxPositions, len(t.GetValidateMarkets())))
+	}
+
+	equity := analytics.RealizedEquity(capital, orders)
+	if now := t.clock.Now(); len(equity) > 0 && (filter.To.IsZero() || now.Before(filter.To)) {
+		unrealized := 0.0
+		for _, summary := range t.GetPositionSummaries() {
+			if filter.Market == "" || filter.Market == summary.Market {
+				unrealized += summary.UnrealizedProfit
+			}
+		}
+		equity = append(equity, analytics.Point{Time: now, Equity: equity[len(equity)-1].Equity + unrealized})
+	}
+
+	title := "실거래 성과"
+	if filter.Market != "" {
+		title += " (" + filter.Market + ")"
+	}
+	return analytics.Analyze(title, capital, orders, analytics.Daily(equity, scheduler.KST))
+}
+
+func addPnL(entries map[string]*model.PnLEntry, key string, profit float64) {
+	entry, exists := entries[key]
+	if !exists {
+		entry = &model.PnLEntry{Key: key}
+		entries[key] = entry
+	}
+	entry.Profit += profit
+	entry.TradeCount++
+	if profit > 0 {
+		entry.WinCount++
+	}
+}
+
+func sortedPnLEntries(entries map[string]*model.PnLEntry) []model.PnLEntry {
+	result := make([]model.PnLEntry, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, *entry)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Key < result[j].Key })
+	return result
+}
+
+// GetStrategyInfo는 현재 사용 중인 전략과 파라미터를 반환합니다
+func (t *TradingBot) GetStrategyInfo() model.StrategyInfo {
+	tradingConfig := t.config.TradingConfig()
+	info := model.StrategyInfo{
+		Name:             tradingConfig.Strategy,
+		CandleCategory:   tradingConfig.Candle.Category,
+		CandleUnit:       tradingConfig.Candle.Unit,
+		ClosedCandleOnly: tradingConfig.Candle.ClosedOnly,
+	}
+
+	if s := t.currentStrategy(); s != nil {
+		info.RequiredCandleCount = s.GetRequiredCandleCount()
+	}
+
+	var params any
+	switch tradingConfig.Strategy {
+	case strategy.MOVING_AVERAGE_CROSS:
+		params = tradingConfig.MovingAverageCross
+	case strategy.MOVING_AVERAGE_CYCLE:
+		params = tradingConfig.MovingAverageCycle
+	}
+	if data, err := json.Marshal(params); err == nil {
+		_ = json.Unmarshal(data, &info.Parameters)
+	}
+
+	return info
+}
+
+// GetChart는 마켓의 최근 캔들과 현재 전략의 이동평균선을 반환합니다
+func (t *TradingBot) GetChart(market string, count int) (model.Chart, error) {
+	if err := t.requireMarket(market); err != nil {
+		return model.Chart{}, err
+	}
+
+	periods := movingAveragePeriods(t.config.TradingConfig())
+	longest := 0
+	for _, period := range periods {
+		longest = max(longest, period)
+	}
+
+	// 가장 긴 이동평균선도 첫 캔들부터 그릴 수 있도록 기간만큼 더 조회합니다
+	fetchCount := min(count+longest-1, maxChartCandleCount)
+	candles := t.marketHandler.GetCandles(t.ctx, market, fetchCount)
+	if len(candles) == 0 {
+		return model.Chart{}, fmt.Errorf("failed to fetch candles: %s", market)
+	}
+	slices.Reverse(candles)
+
+	start := max(len(candles)-count, 0)
+	chart := model.Chart{Market: market, Candles: candles[start:]}
+	for _, period := range periods {
+		chart.MovingAverages = append(chart.MovingAverages, model.MovingAverage{
+			Period: period,
+			Points: movingAverage(candles, period, start),
+		})
+	}
+	return chart, nil
+}
+
+// movingAveragePeriods는 현재 전략이 사용하는 이동평균 기간을 반환합니다
+func movingAveragePeriods(tradingConfig *config.TradingConfig) []int {
+	switch tradingConfig.Strategy {
+	case strategy.MOVING_AVERAGE_CROSS:
+		return []int{tradingConfig.MovingAverageCross.ShortPeriod, tradingConfig.MovingAverageCross.LongPeriod}
+	case strategy.MOVING_AVERAGE_CYCLE:
+		return []int{tradingConfig.MovingAverageCycle.ShortPeriod, tradingConfig.MovingAverageCycle.MediumPeriod, tradingConfig.MovingAverageCycle.LongPeriod}
+	}
+	return nil
+}
+
+// movingAverage는 오래된 순으로 정렬된 candles의 start 이후 구간에 대해 단순 이동평균을 계산합니다
+func movingAverage(candles []model.Candle, period, start int) []model.ChartPoint {
+	if period <= 0 {
+		return nil
+	}
+
+	points := make([]model.ChartPoint, 0, len(candles)-start)
+	var sum float64
+	for i, candle := range candles {
+		sum += candle.TradePrice
+		if i >= period {
+			sum -= candles[i-period].TradePrice
+		}
+		if i >= start && i >= period-1 {
+			points = append(points, model.ChartPoint{Time: candle.CandleDateTimeUTC, Value: sum / float64(period)})
+		}
+	}
+	return points
+}
@@ -1,60 +1,246 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"go-trading-bot/config"
 	"go-trading-bot/internal/client"
+	"go-trading-bot/internal/clock"
+	"go-trading-bot/internal/event"
 	"go-trading-bot/internal/logger"
+	"go-trading-bot/internal/metrics"
 	"go-trading-bot/internal/model"
+	"go-trading-bot/internal/notify"
+	"go-trading-bot/internal/scheduler"
+	"go-trading-bot/internal/store"
 	"go-trading-bot/internal/strategy"
 	"go-trading-bot/internal/utils"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
 	"golang.org/x/text/language"
 	"golang.org/x/text/message"
 )
 
+// TradingBot의 공유 상태(strategy, validateMarkets, addedMarkets, removedMarkets, latestSignal)는 mu로 보호되며,
+// 분석 사이클은 cycleCoordinator를 통해서만 실행되어 서로 겹치지 않습니다.
+// 거래소 요청은 모두 ctx에서 파생되므로 Shutdown의 기한이 지나면 함께 취소됩니다
 type TradingBot struct {
-	strategy        strategy.TradingStrategy
-	marketHandler   *MarketHandler
-	validateMarkets []string
-	latestSignal    map[string]model.Signal
-	orderService    *OrderService
+	ctx              context.Context
+	cancel           context.CancelFunc
+	mu               sync.RWMutex
+	strategy         strategy.TradingStrategy
+	marketHandler    *MarketHandler
+	validateMarkets  []string
+	addedMarkets     []string // AddMarket으로 추가한 마켓
+	removedMarkets   []string // RemoveMarket으로 제거한 마켓
+	latestSignal     map[string]model.Signal
+	orderService     *OrderService
+	cycleCoordinator *CycleCoordinator
+	riskManager      *RiskManager
+	events           *event.Bus
+	notifier         notify.Notifier
+	store            store.Store
+	exchange         client.ExchangeClient
+	orders           client.OrderClient
+	prices           client.PriceClient
+	config           config.Provider
+	clock            clock.Clock
+	reloadMu         sync.Mutex
+	scheduleChanged  chan struct{}
+	jobs             sync.WaitGroup // 실행 중인 스케줄러 작업
+	jobsMu           sync.Mutex     // jobs.Add와 Shutdown의 jobs.Wait 순서를 보장
+	jobsClosed       bool           // Shutdown이 시작되어 새 스케줄러 작업을 받지 않음
+	loopRunning      atomic.Bool
+	startedAt        time.Time
+	lastReload       reloadResult
+	health           healthCache
+}
+
+// Dependencies는 TradingBot이 사용하는 외부 의존성입니다. 비어 있는 항목은 실제 구현으로 채웁니다
+type Dependencies struct {
+	Exchange client.ExchangeClient // 기본값: UPBIT_API_URL의 UpbitAPIClient
+	Orders   client.OrderClient    // 기본값: Exchange가 주문 API를 구현하면 Exchange (live-trading에서만 사용)
+	Prices   client.PriceClient    // 기본값: BinanceAPIClient
+	Clock    clock.Clock           // 기본값: clock.Real (주문 시각, 캔들 마감, 거래 시간 판단에 사용)
+	Config   config.Provider       // 기본값: config.Global
+	Notifier notify.Notifier       // 기본값: notify.Nop
+	Store    store.Store           // 기본값: STATE_FILE의 FileStore, STATE_FILE이 비어 있으면 저장하지 않음
+	Logger   *logrus.Logger        // 기본값: logger.Log (사이클, 주문 로그에 사용)
+}
+
+// New는 deps를 사용하는 TradingBot을 생성합니다. Initialize를 호출해야 사용할 수 있습니다
+func New(deps Dependencies) *TradingBot {
+	if deps.Config == nil {
+		deps.Config = config.Global
+	}
+	if deps.Exchange == nil {
+		deps.Exchange = &client.UpbitAPIClient{BaseURL: deps.Config.Config().UpbitAPIUrl}
+	}
+	if deps.Orders == nil {
+		deps.Orders, _ = deps.Exchange.(client.OrderClient)
+	}
+	if deps.Prices == nil {
+		deps.Prices = &client.BinanceAPIClient{}
+	}
+	if deps.Clock == nil {
+		deps.Clock = clock.Real
+	}
+	if deps.Notifier == nil {
+		deps.Notifier = notify.Nop{}
+	}
+	if deps.Store == nil {
+		if stateFile := deps.Config.Config().StateFile; stateFile != "" {
+			deps.Store = store.NewFileStore(stateFile)
+		}
+	}
+
+	ctx := context.Background()
+	if deps.Logger != nil {
+		ctx = logger.WithLogger(ctx, deps.Logger)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	return &TradingBot{
+		ctx:      ctx,
+		cancel:   cancel,
+		notifier: deps.Notifier,
+		store:    deps.Store,
+		exchange: deps.Exchange,
+		orders:   deps.Orders,
+		prices:   deps.Prices,
+		config:   deps.Config,
+		clock:    deps.Clock,
+	}
+}
+
+// NewTradingBot은 notifier로 알림을 보내는 TradingBot을 생성합니다. notifier가 nil이면 알림을 보내지 않습니다
+func NewTradingBot(notifier notify.Notifier) *TradingBot {
+	return New(Dependencies{Notifier: notifier})
 }
 
 func (t *TradingBot) Initialize() {
-	t.marketHandler = &MarketHandler{upbitAPIClient: &client.UpbitAPIClient{BaseURL: config.GetConfig().UpbitAPIUrl}, binanceAPIClient: &client.BinanceAPIClient{}}
-	t.validateMarkets = t.marketHandler.validateAndFilterMarkets()
+	if t.notifier == nil {
+		t.notifier = notify.Nop{}
+	}
+	t.marketHandler = &MarketHandler{upbitAPIClient: t.exchange, binanceAPIClient: t.prices, config: t.config, clock: t.clock}
+	t.validateMarkets = t.marketHandler.validateAndFilterMarkets(t.ctx, t.config.TradingConfig().Markets)
 	t.latestSignal = make(map[string]model.Signal)
-	t.orderService = &OrderService{positions: make(map[string]model.Position)}
+	t.events = event.NewBus()
+	t.riskManager = &RiskManager{config: t.config, clock: t.clock}
+	t.orderService = &OrderService{positions: make(map[string]model.Position), riskManager: t.riskManager, orderClient: t.orders, events: t.events, config: t.config, clock: t.clock}
+
+	tradingConfig := t.config.TradingConfig()
+	t.strategy = strategy.CreateStrategy(tradingConfig, t.clock)
+	t.cycleCoordinator = NewCycleCoordinator(OverlapPolicy(tradingConfig.CycleOverlapPolicy), t.runTask, t.events, t.clock)
+	t.scheduleChanged = make(chan struct{}, 1)
+
+	tradingWindow, err := scheduler.NewTradingWindow(tradingConfig.TradingWindow)
+	if err != nil {
+		logger.Log.Errorf("거래 시간 설정이 올바르지 않습니다. 거래 시간 제한 없이 실행합니다. %v 🟠", err)
+	}
+	t.riskManager.SetTradingWindow(tradingWindow)
+
+	if t.store != nil {
+		t.restoreState()
+	}
 }
 
 func (t *TradingBot) RunTradingBot(stopChan <-chan struct{}) {
-	if len(t.validateMarkets) == 0 {
+	if len(t.GetValidateMarkets()) == 0 {
 		logger.Log.Errorf("유효한 마켓이 없습니다. 봇을 시작할 수 없습니다. 🔴")
 		return
 	}
 
-	go t.runTask()
-	tradingConfig := config.GetTradingConfig()
-	t.strategy = strategy.CreateStrategy(tradingConfig)
+	t.mu.Lock()
+	t.startedAt = t.clock.Now()
+	t.mu.Unlock()
+	t.loopRunning.Store(true)
+	defer t.loopRunning.Store(false)
 
-	ticker := time.NewTicker(time.Duration(tradingConfig.AnalysisInterval) * time.Minute)
-	defer ticker.Stop()
+	t.cycleCoordinator.Trigger()
 
+	// 설정 리로드로 스케줄이 바뀌면 스케줄러를 다시 생성합니다
 	for {
+		runnerStop := make(chan struct{})
+		runnerDone := make(chan struct{})
+		runner := t.createRunner(t.config.TradingConfig())
+		if !t.addJob() {
+			logger.Log.Warnf("종료 중이라 스케줄러를 시작하지 않습니다. 🟠")
+			return
+		}
+		go func() {
+			defer t.jobs.Done()
+			runner.Run(runnerStop)
+			close(runnerDone)
+			runner.Wait()
+		}()
+
 		select {
-		case <-ticker.C:
-			go t.runTask()
+		case <-t.scheduleChanged:
+			logger.Log.Info("스케줄 설정이 변경되어 스케줄러를 다시 시작합니다. 🔘")
+			close(runnerStop)
+			<-runnerDone
+			select {
+			case <-stopChan:
+				return
+			default:
+			}
 		case <-stopChan:
-			logger.Log.Infof("스케줄러 종료 요청")
+			close(runnerStop)
+			<-runnerDone
 			return
 		}
 	}
 }
 
+// addJob은 스케줄러 작업 하나를 jobs에 등록합니다. Shutdown이 시작된 뒤에는 등록하지 않고 false를 반환합니다
+func (t *TradingBot) addJob() bool {
+	t.jobsMu.Lock()
+	defer t.jobsMu.Unlock()
+	if t.jobsClosed {
+		return false
+	}
+	t.jobs.Add(1)
+	return true
+}
+
+// Events는 봇 이벤트 버스를 반환합니다
+func (t *TradingBot) Events() *event.Bus {
+	return t.events
+}
+
+// GetCycleStatus는 분석 사이클의 실행 메타데이터를 반환합니다
+func (t *TradingBot) GetCycleStatus() model.CycleStatus {
+	return t.cycleCoordinator.Status()
+}
+
+// RunCycle은 분석 사이클을 한 번 실행하고 그 사이클의 결과를 반환합니다. 사이클이 이미 실행 중이고 대기열에도 넣을 수 없으면 ErrCycleRunning을 반환합니다
+func (t *TradingBot) RunCycle() error {
+	if t.cycleCoordinator.Closed() {
+		return ErrShuttingDown
+	}
+	result, ok := t.cycleCoordinator.TriggerWait()
+	if !ok {
+		return ErrCycleRunning
+	}
+	return <-result
+}
+
+// GetValidateMarkets는 검증된 마켓 목록의 복사본을 반환합니다
+func (t *TradingBot) GetValidateMarkets() []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return append([]string(nil), t.validateMarkets...)
+}
+
 func (t *TradingBot) GetLatestSignal(market string) model.Signal {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
 	if signal, exists := t.latestSignal[market]; exists {
 		return signal
 	}
@@ -62,6 +248,8 @@ func (t *TradingBot) GetLatestSignal(market string) model.Signal {
 }
 
 func (t *TradingBot) GetAllLatestSignals() []model.Signal {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
 	signals := make([]model.Signal, 0, len(t.latestSignal))
 	for _, signal := range t.latestSignal {
 		signals = append(signals, signal)
@@ -69,39 +257,159 @@ func (t *TradingBot) GetAllLatestSignals() []model.Signal {
 	return signals
 }
 
-func (t *TradingBot) runTask() {
-	logger.Log.Info("=========runTask===========")
+// runTask는 분석 사이클 한 번을 실행합니다. 사이클마다 cycle_id를 만들어 전략, 클라이언트, 주문 로그에 붙입니다
+func (t *TradingBot) runTask() error {
+	ctx := logger.WithField(t.ctx, logger.FIELD_CYCLE_ID, uuid.NewString())
+	log := logger.FromContext(ctx)
+	log.Info("=========runTask===========")
+
+	t.mu.RLock()
+	tradingStrategy := t.strategy
+	t.mu.RUnlock()
+	if tradingStrategy == nil {
+		return errors.New("trading strategy is not configured")
+	}
 
-	requireCandleCount := t.strategy.GetRequiredCandleCount()
+	requireCandleCount := tradingStrategy.GetRequiredCandleCount()
+	ctx = logger.WithField(ctx, logger.FIELD_STRATEGY, tradingStrategy.GetName())
 
-	for _, m := range t.validateMarkets {
-		candles := t.marketHandler.GetCandles(m, requireCandleCount)
-		signal := t.strategy.Analyze(m, candles)
-		t.handleSignal(signal)
+	var failedMarkets []string
+	for _, m := range t.GetValidateMarkets() {
+		marketCtx := logger.WithField(ctx, logger.FIELD_MARKET, m)
+		candles := t.marketHandler.GetCandles(marketCtx, m, requireCandleCount)
+		if len(candles) < requireCandleCount {
+			logger.FromContext(marketCtx).Errorf("[%v] 캔들 수가 부족합니다. (%v / %v) 🔴", m, len(candles), requireCandleCount)
+			t.events.Publish(event.ERROR, m, map[string]string{"Source": "candles", "Message": fmt.Sprintf("not enough candles (%d / %d)", len(candles), requireCandleCount)})
+			failedMarkets = append(failedMarkets, m)
+			continue
+		}
+		signal := tradingStrategy.Analyze(marketCtx, m, candles)
+		t.handleSignal(marketCtx, signal)
 	}
 
+	t.checkRiskExits(ctx)
+
 	signals := t.GetAllLatestSignals()
-	positions := t.marketHandler.GetPositions()
-	actions := t.createActions(signals, positions)
-	utils.SendTelegramMultiAlert(actions)
+	positions := t.orderService.GetPositions()
+	actions := t.createActions(ctx, signals, positions)
+	for _, action := range actions {
+		t.sendNotification(t.actionNotification(action))
+	}
+	t.recordPositionMetrics(positions)
+
+	if len(failedMarkets) > 0 {
+		return fmt.Errorf("failed to analyze markets: %v", strings.Join(failedMarkets, ", "))
+	}
+	return nil
 }
 
-func (t *TradingBot) handleSignal(signal model.Signal) {
+// recordPositionMetrics는 보유 포지션 수와 마지막 신호 가격 기준 평가손익을 메트릭으로 기록합니다
+func (t *TradingBot) recordPositionMetrics(positions model.Positions) {
+	metrics.UnrealizedPnL.Reset()
+	open := 0
+	for _, p := range positions {
+		market := positionMarket(p)
+		if market == "" {
+			continue
+		}
+		open++
+		if currentPrice := t.GetLatestSignal(market).CurrentPrice; currentPrice > 0 {
+			metrics.UnrealizedPnL.Set((currentPrice-p.EntryPrice)*p.Quantity, market)
+		}
+	}
+	metrics.OpenPositions.Set(float64(open))
+}
+
+func (t *TradingBot) handleSignal(ctx context.Context, signal model.Signal) {
+	log := logger.FromContext(ctx)
+	t.mu.Lock()
 	t.latestSignal[signal.Market] = signal
+	t.mu.Unlock()
+
+	t.events.Publish(event.SIGNAL_GENERATED, signal.Market, signal)
+	metrics.Signals.Inc(signal.Market, signal.StrategyName, signal.Type.String())
+	if signal.Stage != nil {
+		metrics.Stage.Set(float64(signal.Stage.StageNumber), signal.Market)
+	}
+	if signal.Stage != nil && (signal.Stage.StageDir == model.STAGE_DIR_NORMAL || signal.Stage.StageDir == model.STAGE_DIR_REVERSE) {
+		t.events.Publish(event.STAGE_CHANGED, signal.Market, signal.Stage)
+	}
 	//t.printSignal(&signal)
-	logger.Log.Infof("SIGNAL INFO:\n%v", t.createSignalInfo(&signal))
+	log.Infof("SIGNAL INFO:\n%v", t.createSignalInfo(&signal))
 	//utils.SendTelegramAlert(signal)
 
 	switch signal.Type {
 	case model.BUY:
-		logger.Log.Infof("[%v] 매수 신호 -> BUY 주문을 실행합니다.", signal.Market)
-		t.orderService.PlaceOrder(signal.Market, model.BUY, signal.CurrentPrice)
+		log.Infof("[%v] 매수 신호 -> BUY 주문을 실행합니다.", signal.Market)
+		_, _ = t.orderService.PlaceSignalOrder(ctx, signal)
 	case model.SELL:
-		logger.Log.Infof("[%v] 매도 신호 -> SELL 주문을 실행합니다.", signal.Market)
-		t.orderService.PlaceOrder(signal.Market, model.SELL, signal.CurrentPrice)
+		log.Infof("[%v] 매도 신호 -> SELL 주문을 실행합니다.", signal.Market)
+		_, _ = t.orderService.PlaceSignalOrder(ctx, signal)
 	case model.HOLD:
-		logger.Log.Infof("[%v] HOLD 신호 -> 매매 없음, 포지션 상태: %v", signal.Market, "")
+		log.Infof("[%v] HOLD 신호 -> 매매 없음, 포지션 상태: %v", signal.Market, "")
+	}
+}
+
+// checkRiskExits는 보유 포지션 중 손절/익절 조건에 도달한 포지션을 청산합니다
+func (t *TradingBot) checkRiskExits(ctx context.Context) {
+	for _, position := range t.orderService.GetPositions() {
+		currentPrice := t.GetLatestSignal(position.Market).CurrentPrice
+		reason, exit := t.riskManager.CheckExit(position, currentPrice)
+		if !exit {
+			continue
+		}
+
+		marketCtx := logger.WithField(ctx, logger.FIELD_MARKET, position.Market)
+		log := logger.FromContext(marketCtx)
+		log.Infof("[%v] 리스크 청산 -> %v", position.Market, reason)
+		order, err := t.orderService.PlaceOrder(marketCtx, position.Market, model.SELL, currentPrice, 0)
+		if err != nil {
+			log.Errorf("[%v] 리스크 청산 실패: %v 🔴", position.Market, err)
+			t.events.Publish(event.ERROR, position.Market, map[string]string{"Source": "risk", "Message": err.Error()})
+			continue
+		}
+		t.events.Publish(event.RISK_EXIT, position.Market, map[string]any{"Reason": reason, "Order": order})
+		t.sendNotification(notify.Notification{
+			Type:     notify.TYPE_RISK,
+			Severity: notify.SEVERITY_WARNING,
+			Title:    fmt.Sprintf("🛡️ [%s] 리스크 청산", order.Market),
+			Message:  fmt.Sprintf("%s\n수량: %f, 가격: %.0f, 손익: %.0f", reason, order.Quantity, order.Price, order.Profit),
+			Market:   order.Market,
+		})
+	}
+}
+
+// sendNotification은 알림을 전송합니다. 채널별 전송 실패는 notifier에서 로그로 남깁니다
+func (t *TradingBot) sendNotification(n notify.Notification) {
+	_ = t.notifier.Notify(n)
+}
+
+// actionNotification은 마켓별 신호를 알림으로 만듭니다.
+// BUY/SELL은 signal, 단계가 바뀐 HOLD는 stage, 변화 없는 HOLD는 hold 타입이며 전송 여부는 알림 정책이 결정합니다
+func (t *TradingBot) actionNotification(action model.Action) notify.Notification {
+	signal := action.Signal
+	n := notify.Notification{
+		Type:     notify.TYPE_HOLD,
+		Severity: notify.SEVERITY_INFO,
+		Message:  utils.FormatActionMessage(action),
+		Market:   signal.Market,
+		State:    signal.Type.String(),
+	}
+	switch {
+	case signal.Type == model.BUY || signal.Type == model.SELL:
+		n.Type = notify.TYPE_SIGNAL
+	case t.shouldSendHoldAlert(&signal):
+		n.Type = notify.TYPE_STAGE
+	}
+
+	p := message.NewPrinter(language.Korean)
+	n.Summary = fmt.Sprintf("<b>%s</b> %v", signal.Market, signal.Type)
+	if signal.Stage != nil {
+		n.State += "/" + signal.Stage.StageNumber.String()
+		n.Summary += fmt.Sprintf(" (%v)", signal.Stage.StageNumber)
 	}
+	n.Summary += p.Sprintf(" · %.0f", signal.CurrentPrice)
+	return n
 }
 
 // shouldSendHoldAlert는 HOLD 신호에서도 알림을 보낼지 결정합니다
@@ -149,20 +457,20 @@ func (t *TradingBot) createSignalInfo(signal *model.Signal) string {
 	return info
 }
 
-func (t *TradingBot) createActions(signals []model.Signal, positions model.Positions) []model.Action {
+func (t *TradingBot) createActions(ctx context.Context, signals []model.Signal, positions model.Positions) []model.Action {
 	actions := make([]model.Action, 0, len(signals))
 	for _, signal := range signals {
 		asset := strings.Split(signal.Market, "-")[1]
 		var position model.Position
 		for _, p := range positions {
-			if asset == p.Market {
+			if asset == p.Market || signal.Market == p.Market {
 				position = p
 				break
 			}
 		}
 
 		var usdtPrice string
-		binancePrices := t.marketHandler.GetBinancePrices()
+		binancePrices := t.marketHandler.GetBinancePrices(ctx)
 		for _, price := range binancePrices {
 			if price.Asset == asset {
 				usdtPrice = price.Price
@@ -171,16 +479,16 @@ func (t *TradingBot) createActions(signals []model.Signal, positions model.Posit
 		}
 
 		action := model.Action{
-			Market:   signal.Market,
-			Signal:   signal,
-			Position: position,
+			Market:    signal.Market,
+			Signal:    signal,
+			Position:  position,
 			USDTPrice: usdtPrice,
 		}
 		actions = append(actions, action)
 	}
 
 	for _, action := range actions {
-		logger.Log.Infof("ACTION: %#v", action)
+		logger.FromContext(ctx).Infof("ACTION: %#v", action)
 	}
 
 	return actions
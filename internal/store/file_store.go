@@ -0,0 +1,58 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-trading-bot/internal/model"
+	"os"
+	"path/filepath"
+)
+
+// FileStore는 봇 상태를 JSON 파일로 저장합니다
+type FileStore struct {
+	path string
+}
+
+// NewFileStore는 path에 상태를 저장하는 FileStore를 생성합니다
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// Load는 저장된 상태를 읽습니다. 파일이 없으면 fs.ErrNotExist를 감싼 오류를 반환합니다
+func (s *FileStore) Load() (model.BotState, error) {
+	var state model.BotState
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return state, err
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("%s: %w", s.path, err)
+	}
+	return state, nil
+}
+
+// Save는 상태를 임시 파일에 쓴 뒤 교체합니다. 저장 중 종료되어도 이전 파일이 깨지지 않습니다
+func (s *FileStore) Save(state model.BotState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
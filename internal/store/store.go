@@ -0,0 +1,45 @@
+package store
+
+import (
+	"go-trading-bot/internal/model"
+	"io/fs"
+	"sync"
+)
+
+// Store는 재시작 후 복원할 봇 상태를 저장합니다. 저장된 상태가 없으면 Load는 fs.ErrNotExist를 반환합니다
+type Store interface {
+	Load() (model.BotState, error)
+	Save(state model.BotState) error
+}
+
+var (
+	_ Store = (*FileStore)(nil)
+	_ Store = (*MemoryStore)(nil)
+)
+
+// MemoryStore는 상태를 메모리에만 보관하는 Store입니다
+type MemoryStore struct {
+	mu    sync.Mutex
+	state *model.BotState
+}
+
+// NewMemoryStore는 비어 있는 MemoryStore를 생성합니다
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+func (s *MemoryStore) Load() (model.BotState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.state == nil {
+		return model.BotState{}, fs.ErrNotExist
+	}
+	return *s.state, nil
+}
+
+func (s *MemoryStore) Save(state model.BotState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = &state
+	return nil
+}
@@ -0,0 +1,27 @@
+package strategy
+
+import (
+	"go-trading-bot/internal/model"
+	"math"
+)
+
+// checkCandles는 최신순 candles 중 분석에 쓰는 required개가 모두 있고 가격이 올바른지 확인합니다.
+// 분석할 수 없으면 관망 사유를 반환합니다
+func checkCandles(candles []model.Candle, required int) (model.Reason, bool) {
+	if len(candles) < required || len(candles) == 0 {
+		return model.Reason{Code: model.REASON_NOT_ENOUGH_CANDLES, Params: map[string]any{
+			"Count":    len(candles),
+			"Required": required,
+		}}, false
+	}
+
+	for i, candle := range candles[:max(required, 1)] {
+		if price := candle.TradePrice; math.IsNaN(price) || math.IsInf(price, 0) || price <= 0 {
+			return model.Reason{Code: model.REASON_INVALID_CANDLE, Params: map[string]any{
+				"Index": i,
+				"Price": price,
+			}}, false
+		}
+	}
+	return model.Reason{}, true
+}
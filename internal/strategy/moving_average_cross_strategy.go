@@ -1,28 +1,33 @@
 package strategy
 
 import (
-	"fmt"
+	"context"
 	"go-trading-bot/config"
+	"go-trading-bot/internal/clock"
+	"go-trading-bot/internal/i18n"
 	"go-trading-bot/internal/logger"
 	"go-trading-bot/internal/model"
-	"time"
 )
 
 type MovingAverageCrossStrategy struct {
 	name               string
 	movingAverageCross config.MovingAverageCross
+	clock              clock.Clock
 }
 
 func (m *MovingAverageCrossStrategy) GetName() string {
 	return m.name
 }
 
-func (m *MovingAverageCrossStrategy) Analyze(market string, candles []model.Candle) model.Signal {
-	if len(candles) == 0 {
-		logger.Log.Error("캔들이 비어있습니다. 분석할 수 없습니다. 🔴")
+func (m *MovingAverageCrossStrategy) Analyze(ctx context.Context, market string, candles []model.Candle) model.Signal {
+	log := logger.FromContext(ctx)
+	currentTime := m.clock.Now().Format("2006-01-02 15:04:05")
+	if reason, ok := checkCandles(candles, m.GetRequiredCandleCount()); !ok {
+		log.Errorf("[%v] 캔들을 분석할 수 없습니다. %v 🔴", market, i18n.Default().Reason(reason))
+		return holdSignal(m.GetName(), market, candles, currentTime, reason)
 	}
 
-	logger.Log.Info("캔들 분석을 시작합니다. 🔘")
+	log.Info("캔들 분석을 시작합니다. 🔘")
 	shortPeriod := m.movingAverageCross.ShortPeriod
 	longPeriod := m.movingAverageCross.LongPeriod
 
@@ -32,26 +37,22 @@ func (m *MovingAverageCrossStrategy) Analyze(market string, candles []model.Cand
 	previousShortMA := m.calculateMA(candles, shortPeriod, 1)
 	previousLongMA := m.calculateMA(candles, longPeriod, 1)
 
-	logger.Log.Infof("[%v] 이전 MA%v: %.2f, MA%v: %.2f", market, shortPeriod, previousShortMA, longPeriod, previousLongMA)
-	logger.Log.Infof("[%v] 현재 MA%v: %.2f, MA%v: %.2f", market, shortPeriod, currentShortMA, longPeriod, currentLongMA)
+	log.Infof("[%v] 이전 MA%v: %.2f, MA%v: %.2f", market, shortPeriod, previousShortMA, longPeriod, previousLongMA)
+	log.Infof("[%v] 현재 MA%v: %.2f, MA%v: %.2f", market, shortPeriod, currentShortMA, longPeriod, currentLongMA)
 
 	currentCandle := candles[0]
-	currentTime := time.Now().Format("2006-01-02 15:04:05")
 
-	var signal model.Signal
+	signalType := model.HOLD
+	reason := model.Reason{Code: model.REASON_NO_CROSS}
 	if previousShortMA < previousLongMA && currentShortMA > currentLongMA {
-		description := fmt.Sprintf("▲ 골든 크로스 발생 -> MA%d(%.2f)이 MA%d(%.2f)를 상향 돌파", shortPeriod, currentShortMA, longPeriod, currentLongMA)
-		signal = model.Signal{Type: model.BUY, Market: market, CurrentPrice: currentCandle.TradePrice, Timestamp: currentTime, Description: description, StrategyName: m.GetName()}
+		signalType = model.BUY
+		reason = crossReason(model.REASON_GOLDEN_CROSS, shortPeriod, currentShortMA, longPeriod, currentLongMA)
+	} else if previousShortMA > previousLongMA && currentShortMA < currentLongMA {
+		signalType = model.SELL
+		reason = crossReason(model.REASON_DEAD_CROSS, shortPeriod, currentShortMA, longPeriod, currentLongMA)
 	}
 
-	if previousShortMA > previousLongMA && currentShortMA < currentLongMA {
-		description := fmt.Sprintf("▼ 데드 크로스 발생 -> MA%d(%.2f)이 MA%d(%.2f)를 하향 돌파", shortPeriod, currentShortMA, longPeriod, currentLongMA)
-		signal = model.Signal{Type: model.SELL, Market: market, CurrentPrice: currentCandle.TradePrice, Timestamp: currentTime, Description: description, StrategyName: m.GetName()}
-	}
-
-	description := "이동평균선 교차 없음 - 관망"
-	signal = model.Signal{Type: model.HOLD, Market: market, CurrentPrice: currentCandle.TradePrice, Timestamp: currentTime, Description: description, StrategyName: m.GetName()}
-	return signal
+	return model.Signal{Type: signalType, Market: market, CurrentPrice: currentCandle.TradePrice, Timestamp: currentTime, Description: i18n.Default().Reason(reason), Reason: reason, StrategyName: m.GetName()}
 }
 
 func (m *MovingAverageCrossStrategy) GetRequiredCandleCount() int {
@@ -66,3 +67,22 @@ func (m *MovingAverageCrossStrategy) calculateMA(candles []model.Candle, period
 
 	return sum / float64(period)
 }
+
+// holdSignal은 캔들을 분석할 수 없을 때 보내는 관망 신호입니다. 가격은 최신 캔들이 있을 때만 채웁니다
+func holdSignal(name, market string, candles []model.Candle, currentTime string, reason model.Reason) model.Signal {
+	signal := model.Signal{Type: model.HOLD, Market: market, Timestamp: currentTime, Description: i18n.Default().Reason(reason), Reason: reason, StrategyName: name}
+	if len(candles) > 0 {
+		signal.CurrentPrice = candles[0].TradePrice
+	}
+	return signal
+}
+
+// crossReason은 이동평균선 교차 사유를 만듭니다
+func crossReason(code string, shortPeriod int, shortMA float64, longPeriod int, longMA float64) model.Reason {
+	return model.Reason{Code: code, Params: map[string]any{
+		"ShortPeriod": shortPeriod,
+		"ShortMA":     shortMA,
+		"LongPeriod":  longPeriod,
+		"LongMA":      longMA,
+	}}
+}
@@ -2,15 +2,27 @@ package strategy
 
 import (
 	"go-trading-bot/config"
+	"go-trading-bot/internal/clock"
 	"go-trading-bot/internal/model"
 )
 
-func CreateStrategy(tradingConfig *config.TradingConfig) TradingStrategy {
+const (
+	MOVING_AVERAGE_CROSS = "moving-average-cross"
+	MOVING_AVERAGE_CYCLE = "moving-average-cycle"
+)
+
+// Names는 CreateStrategy가 지원하는 전략 이름 목록을 반환합니다
+func Names() []string {
+	return []string{MOVING_AVERAGE_CROSS, MOVING_AVERAGE_CYCLE}
+}
+
+// CreateStrategy는 설정의 전략을 생성합니다. 신호 시각은 clk로 기록합니다
+func CreateStrategy(tradingConfig *config.TradingConfig, clk clock.Clock) TradingStrategy {
 	switch strategy := tradingConfig.Strategy; strategy {
-	case "moving-average-cross":
-		return &MovingAverageCrossStrategy{tradingConfig.Strategy, tradingConfig.MovingAverageCross}
-	case "moving-average-cycle":
-		return &MovingAverageCycleStrategy{tradingConfig.Strategy, tradingConfig.MovingAverageCycle, make(map[string]model.Stage)}
+	case MOVING_AVERAGE_CROSS:
+		return &MovingAverageCrossStrategy{name: tradingConfig.Strategy, movingAverageCross: tradingConfig.MovingAverageCross, clock: clk}
+	case MOVING_AVERAGE_CYCLE:
+		return &MovingAverageCycleStrategy{name: tradingConfig.Strategy, movingAverageCycle: tradingConfig.MovingAverageCycle, latestStages: make(map[string]model.Stage), clock: clk}
 	default:
 		return nil
 	}
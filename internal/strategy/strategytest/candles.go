@@ -0,0 +1,60 @@
+package strategytest
+
+import (
+	"go-trading-bot/internal/model"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// Series는 종가 목록(과거 → 최신)으로 start부터 interval 간격의 캔들을 만듭니다. 시가/고가/저가는 종가와 같습니다
+func Series(start time.Time, interval time.Duration, prices ...float64) []model.Candle {
+	candles := make([]model.Candle, len(prices))
+	for i, price := range prices {
+		t := start.Add(time.Duration(i) * interval).UTC()
+		candles[i] = model.Candle{
+			Market:            CHECK_MARKET,
+			CandleDateTimeUTC: t.Format("2006-01-02T15:04:05"),
+			OpeningPrice:      price,
+			HighPrice:         price,
+			LowPrice:          price,
+			TradePrice:        price,
+			Timestamp:         t.UnixMilli(),
+		}
+	}
+	return candles
+}
+
+// NewestFirst는 과거 → 최신 순서의 캔들을 Analyze가 받는 최신 → 과거 순서로 뒤집은 복사본을 반환합니다
+func NewestFirst(candles []model.Candle) []model.Candle {
+	reversed := make([]model.Candle, len(candles))
+	for i, candle := range candles {
+		reversed[len(candles)-1-i] = candle
+	}
+	return reversed
+}
+
+// walk는 100에서 시작하는 랜덤워크 가격 n개를 만듭니다
+func walk(seed uint64, n int) []float64 {
+	rng := rand.New(rand.NewPCG(seed, seed))
+	prices := make([]float64, n)
+	price := 100.0
+	for i := range prices {
+		price *= math.Exp(0.02 * rng.NormFloat64())
+		prices[i] = price
+	}
+	return prices
+}
+
+// scenarios는 정상 입력 검사에 쓰는 가격 흐름입니다. 보합, 상승, 하락, 순환, 랜덤워크를 포함합니다
+func scenarios(required int) [][]float64 {
+	n := required*4 + MAX_EXTRA_CANDLES
+	flat, rising, falling, cycle := make([]float64, n), make([]float64, n), make([]float64, n), make([]float64, n)
+	for i := range n {
+		flat[i] = 100
+		rising[i] = 100 + float64(i)
+		falling[i] = 100 + float64(n-i)
+		cycle[i] = 100 + 20*math.Sin(2*math.Pi*float64(i)/float64(required*2))
+	}
+	return [][]float64{flat, rising, falling, cycle, walk(3, n), walk(4, n)}
+}
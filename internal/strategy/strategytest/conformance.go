@@ -0,0 +1,191 @@
+// Package strategytest는 모든 TradingStrategy가 지켜야 할 규칙을 검사하는 키트입니다.
+// Check는 빈 캔들, 부족한 캔들, NaN 가격, 필요 캔들 수, 결정성, 패닉 여부를 검사하고
+// CheckGolden은 기록해 둔 캔들을 재생해 신호 순서가 골든 파일과 같은지 비교합니다.
+// 테스트에서는 Run/RunGolden을 사용하며, 골든 파일은 go test ./internal/strategy/strategytest -update로 다시 씁니다
+package strategytest
+
+import (
+	"context"
+	"fmt"
+	"go-trading-bot/internal/clock"
+	"go-trading-bot/internal/logger"
+	"go-trading-bot/internal/model"
+	"go-trading-bot/internal/strategy"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// EPOCH는 검사에 사용하는 고정 시각입니다
+var EPOCH = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+const (
+	CHECK_MARKET      = "KRW-TEST" // 검사에 사용하는 마켓
+	MAX_EXTRA_CANDLES = 10         // 필요 개수 검사에서 덧붙이는 오래된 캔들 수
+)
+
+// quietContext는 검사 중 전략 로그를 버리는 context입니다
+func quietContext() context.Context {
+	quiet := logrus.New()
+	quiet.SetOutput(io.Discard)
+	return logger.WithLogger(context.Background(), quiet)
+}
+
+// Factory는 검사할 전략을 새로 만듭니다. 상태가 있는 전략도 검사마다 새 인스턴스로 시작합니다
+type Factory func(clk clock.Clock) strategy.TradingStrategy
+
+// Failure는 검사에 실패한 항목입니다
+type Failure struct {
+	Check   string
+	Message string
+}
+
+func (f Failure) String() string {
+	return fmt.Sprintf("[%s] %s", f.Check, f.Message)
+}
+
+// Check는 전략이 모든 규칙을 지키는지 검사하고 실패 목록을 반환합니다. 규칙마다 첫 번째 실패만 보고합니다
+func Check(newStrategy Factory) []Failure {
+	c := &checker{ctx: quietContext(), newStrategy: newStrategy, clock: clock.NewFake(EPOCH), reported: make(map[string]bool)}
+
+	required := newStrategy(c.clock).GetRequiredCandleCount()
+	if required <= 0 {
+		c.fail("required-count", "GetRequiredCandleCount() = %d, must be positive", required)
+		return c.failures
+	}
+
+	c.checkHold("empty", nil)
+	c.checkHold("empty", []model.Candle{})
+	for _, count := range []int{1, required - 1} {
+		if count > 0 && count < required {
+			c.checkHold("short", NewestFirst(Series(EPOCH, time.Hour, walk(1, count)...)))
+		}
+	}
+
+	for _, index := range []int{0, required / 2, required - 1} {
+		for _, price := range []float64{math.NaN(), math.Inf(1), 0} {
+			candles := NewestFirst(Series(EPOCH, time.Hour, walk(2, required)...))
+			candles[index].TradePrice = price
+			c.checkHold("nan", candles)
+		}
+	}
+
+	for seed, prices := range scenarios(required) {
+		series := Series(EPOCH, time.Hour, prices...)
+		first := c.replay(series, required, 0)
+		c.checkSignals(series, required, first)
+
+		if second := c.replay(series, required, 0); !reflect.DeepEqual(first, second) {
+			c.fail("deterministic", "scenario %d: two fresh instances produced different signals", seed)
+		}
+		// 필요 개수보다 오래된 캔들은 분석에 영향을 주지 않아야 합니다
+		if extra := c.replay(series, required, MAX_EXTRA_CANDLES); !reflect.DeepEqual(first, extra) {
+			c.fail("required-count", "scenario %d: signals changed when candles older than GetRequiredCandleCount() were appended", seed)
+		}
+	}
+	return c.failures
+}
+
+// Run은 Check의 실패를 t에 보고합니다
+func Run(t testing.TB, newStrategy Factory) {
+	t.Helper()
+	for _, failure := range Check(newStrategy) {
+		t.Error(failure.String())
+	}
+}
+
+type checker struct {
+	ctx         context.Context
+	newStrategy Factory
+	clock       *clock.Fake
+	failures    []Failure
+	reported    map[string]bool
+}
+
+func (c *checker) fail(check, format string, args ...any) {
+	if c.reported[check] {
+		return
+	}
+	c.reported[check] = true
+	c.failures = append(c.failures, Failure{Check: check, Message: fmt.Sprintf(format, args...)})
+}
+
+// analyze는 패닉을 실패로 바꿔 Analyze를 호출합니다
+func (c *checker) analyze(s strategy.TradingStrategy, candles []model.Candle) (signal model.Signal, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			c.fail("no-panic", "Analyze panicked with %d candles: %v", len(candles), r)
+			ok = false
+		}
+	}()
+	return s.Analyze(c.ctx, CHECK_MARKET, candles), true
+}
+
+// checkHold는 분석할 수 없는 입력에 패닉 없이 관망 신호를 반환하는지 확인합니다
+func (c *checker) checkHold(check string, candles []model.Candle) {
+	s := c.newStrategy(c.clock)
+	signal, ok := c.analyze(s, candles)
+	if !ok {
+		return
+	}
+	if signal.Type != model.HOLD {
+		c.fail(check, "expected HOLD for %d unusable candles, got %v (%v)", len(candles), signal.Type, signal.Reason.Code)
+	}
+	c.checkIdentity(check, s, signal)
+}
+
+func (c *checker) checkIdentity(check string, s strategy.TradingStrategy, signal model.Signal) {
+	if signal.Market != CHECK_MARKET {
+		c.fail(check, "signal market is %q, want %q", signal.Market, CHECK_MARKET)
+	}
+	if signal.StrategyName != s.GetName() {
+		c.fail(check, "signal strategy is %q, want %q", signal.StrategyName, s.GetName())
+	}
+	if signal.Reason.Code == "" {
+		c.fail(check, "signal has no reason code")
+	}
+}
+
+// replay는 새 인스턴스로 series(과거 → 최신)를 한 캔들씩 재생합니다.
+// extra가 0보다 크면 최신 required개 뒤에 가격을 크게 바꾼 오래된 캔들 extra개를 붙여서 넘깁니다
+func (c *checker) replay(series []model.Candle, required, extra int) []model.Signal {
+	s := c.newStrategy(c.clock)
+	var signals []model.Signal
+	for end := required + MAX_EXTRA_CANDLES; end <= len(series); end++ {
+		window := NewestFirst(series[end-required-extra : end])
+		for i := required; i < len(window); i++ {
+			window[i].TradePrice *= 1000
+		}
+		signal, ok := c.analyze(s, window)
+		if !ok {
+			return signals
+		}
+		signals = append(signals, signal)
+	}
+	return signals
+}
+
+// checkSignals는 정상 입력에 대한 신호가 올바른지 확인합니다
+func (c *checker) checkSignals(series []model.Candle, required int, signals []model.Signal) {
+	s := c.newStrategy(c.clock)
+	for i, signal := range signals {
+		window := series[i+MAX_EXTRA_CANDLES : i+MAX_EXTRA_CANDLES+required]
+		if signal.Type != model.BUY && signal.Type != model.SELL && signal.Type != model.HOLD {
+			c.fail("valid", "unknown signal type %d", signal.Type)
+		}
+		if latest := window[len(window)-1].TradePrice; signal.CurrentPrice != latest {
+			c.fail("valid", "signal price is %v, want the latest trade price %v", signal.CurrentPrice, latest)
+		}
+		if code := signal.Reason.Code; code == model.REASON_NOT_ENOUGH_CANDLES || code == model.REASON_INVALID_CANDLE {
+			c.fail("required-count", "rejected exactly GetRequiredCandleCount() valid candles: %v", code)
+		}
+		if want := c.clock.Now().Format("2006-01-02 15:04:05"); signal.Timestamp != want {
+			c.fail("deterministic", "signal timestamp %q does not come from the injected clock (%q)", signal.Timestamp, want)
+		}
+		c.checkIdentity("valid", s, signal)
+	}
+}
@@ -0,0 +1,181 @@
+package strategytest
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-trading-bot/config"
+	"go-trading-bot/internal/backtest"
+	"go-trading-bot/internal/clock"
+	"go-trading-bot/internal/model"
+	"go-trading-bot/internal/strategy"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// CASES_FILE은 골든 테스트 목록 파일 이름입니다. 캔들은 candles/, 기대 신호는 golden/ 아래에 둡니다
+const CASES_FILE = "cases.json"
+
+// Case는 골든 테스트 하나입니다. 캔들 파일은 업비트 캔들 API 응답 형식(JSON 배열)이며 순서는 상관없습니다
+type Case struct {
+	Name    string               `json:"name"`
+	Candles string               `json:"candles"`
+	Config  config.TradingConfig `json:"config"`
+}
+
+// Step은 재생 중 한 캔들에서 나온 신호입니다
+type Step struct {
+	Time   string // 최신 캔들의 candle_date_time_utc
+	Type   model.SignalType
+	Reason string
+	Price  float64
+}
+
+func (s Step) String() string {
+	return fmt.Sprintf("%s %s %s %s", s.Time, s.Type, s.Reason, strconv.FormatFloat(s.Price, 'f', -1, 64))
+}
+
+// LoadCases는 dir의 골든 테스트 목록을 읽습니다
+func LoadCases(dir string) ([]Case, error) {
+	data, err := os.ReadFile(filepath.Join(dir, CASES_FILE))
+	if err != nil {
+		return nil, err
+	}
+	var cases []Case
+	if err := json.Unmarshal(data, &cases); err != nil {
+		return nil, fmt.Errorf("%s: %w", CASES_FILE, err)
+	}
+	return cases, nil
+}
+
+// Replay는 candles(과거 → 최신)를 한 캔들씩 재생하며 최신 GetRequiredCandleCount()개로 분석한 신호를 반환합니다.
+// 시계는 매번 최신 캔들의 시작 시각으로 맞춥니다
+func Replay(newStrategy Factory, candles []model.Candle) []Step {
+	fakeClock := clock.NewFake(EPOCH)
+	s := newStrategy(fakeClock)
+	ctx := quietContext()
+	required := s.GetRequiredCandleCount()
+
+	var steps []Step
+	for end := max(required, 1); end <= len(candles); end++ {
+		latest := candles[end-1]
+		if start, err := latest.StartTime(); err == nil {
+			fakeClock.Set(start)
+		}
+		signal := s.Analyze(ctx, latest.Market, NewestFirst(candles[max(end-required, 0):end]))
+		steps = append(steps, Step{Time: latest.CandleDateTimeUTC, Type: signal.Type, Reason: signal.Reason.Code, Price: signal.CurrentPrice})
+	}
+	return steps
+}
+
+// FromConfig는 설정으로 전략을 만드는 Factory입니다
+func FromConfig(tc *config.TradingConfig) Factory {
+	return func(clk clock.Clock) strategy.TradingStrategy {
+		return strategy.CreateStrategy(tc, clk)
+	}
+}
+
+// CheckGolden은 dir의 골든 테스트를 모두 재생해 golden/<name>.golden과 비교합니다.
+// update이면 비교하지 않고 골든 파일을 현재 결과로 다시 씁니다
+func CheckGolden(dir string, update bool) []Failure {
+	cases, err := LoadCases(dir)
+	if err != nil {
+		return []Failure{{Check: "golden", Message: err.Error()}}
+	}
+
+	var failures []Failure
+	for _, c := range cases {
+		if strategy.CreateStrategy(&c.Config, clock.Real) == nil {
+			failures = append(failures, Failure{Check: "golden", Message: fmt.Sprintf("%s: unknown strategy %q", c.Name, c.Config.Strategy)})
+			continue
+		}
+		candles, err := backtest.LoadCandles(filepath.Join(dir, "candles", c.Candles))
+		if err != nil {
+			failures = append(failures, Failure{Check: "golden", Message: fmt.Sprintf("%s: %v", c.Name, err)})
+			continue
+		}
+
+		var lines []string
+		for _, step := range Replay(FromConfig(&c.Config), candles) {
+			lines = append(lines, step.String())
+		}
+		got := strings.Join(lines, "\n") + "\n"
+
+		path := filepath.Join(dir, "golden", c.Name+".golden")
+		if update {
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err == nil {
+				err = os.WriteFile(path, []byte(got), 0o644)
+			}
+			if err != nil {
+				failures = append(failures, Failure{Check: "golden", Message: fmt.Sprintf("%s: %v", c.Name, err)})
+			}
+			continue
+		}
+
+		want, err := os.ReadFile(path)
+		if err != nil {
+			failures = append(failures, Failure{Check: "golden", Message: fmt.Sprintf("%s: %v", c.Name, err)})
+			continue
+		}
+		if message := diff(strings.Split(string(want), "\n"), strings.Split(got, "\n")); message != "" {
+			failures = append(failures, Failure{Check: "golden", Message: fmt.Sprintf("%s: %s", c.Name, message)})
+		}
+	}
+	return failures
+}
+
+// RunGolden은 CheckGolden의 실패를 t에 보고합니다
+func RunGolden(t testing.TB, dir string, update bool) {
+	t.Helper()
+	for _, failure := range CheckGolden(dir, update) {
+		t.Error(failure.String())
+	}
+}
+
+// CheckAll은 전략마다 첫 번째 골든 테스트의 설정으로 Check를 실행하고 골든 파일을 비교합니다.
+// 지원하는 전략마다 골든 테스트가 하나 이상 있어야 합니다
+func CheckAll(dir string, update bool) []Failure {
+	cases, err := LoadCases(dir)
+	if err != nil {
+		return []Failure{{Check: "golden", Message: err.Error()}}
+	}
+
+	var failures []Failure
+	covered := make(map[string]bool)
+	for _, c := range cases {
+		if covered[c.Config.Strategy] || !slices.Contains(strategy.Names(), c.Config.Strategy) {
+			continue
+		}
+		covered[c.Config.Strategy] = true
+		for _, failure := range Check(FromConfig(&c.Config)) {
+			failure.Message = c.Config.Strategy + ": " + failure.Message
+			failures = append(failures, failure)
+		}
+	}
+	for _, name := range strategy.Names() {
+		if !covered[name] {
+			failures = append(failures, Failure{Check: "golden", Message: fmt.Sprintf("%s: no golden case in %s", name, CASES_FILE)})
+		}
+	}
+	return append(failures, CheckGolden(dir, update)...)
+}
+
+// diff는 처음으로 다른 줄을 설명합니다. 같으면 빈 문자열입니다
+func diff(want, got []string) string {
+	for i := 0; i < max(len(want), len(got)); i++ {
+		var w, g string
+		if i < len(want) {
+			w = want[i]
+		}
+		if i < len(got) {
+			g = got[i]
+		}
+		if w != g {
+			return fmt.Sprintf("line %d: want %q, got %q (%d/%d lines)", i+1, w, g, len(want), len(got))
+		}
+	}
+	return ""
+}
@@ -0,0 +1,84 @@
+package strategytest_test
+
+import (
+	"context"
+	"flag"
+	"go-trading-bot/internal/clock"
+	"go-trading-bot/internal/model"
+	"go-trading-bot/internal/strategy"
+	"go-trading-bot/internal/strategy/strategytest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// 의도한 신호 변화라면 go test ./internal/strategy/strategytest -update로 골든 파일을 다시 씁니다
+var update = flag.Bool("update", false, "rewrite golden files with the current signals")
+
+const testdata = "testdata"
+
+// TestStrategies는 지원하는 모든 전략의 규칙 검사와 골든 비교를 실행합니다
+func TestStrategies(t *testing.T) {
+	for _, failure := range strategytest.CheckAll(testdata, *update) {
+		t.Error(failure.String())
+	}
+}
+
+// alwaysBuy는 캔들과 관계없이 매수 신호를 내는 규칙 위반 전략입니다
+type alwaysBuy struct{}
+
+func (alwaysBuy) GetName() string             { return "always-buy" }
+func (alwaysBuy) GetRequiredCandleCount() int { return 3 }
+func (alwaysBuy) Analyze(ctx context.Context, market string, candles []model.Candle) model.Signal {
+	return model.Signal{Market: market, Type: model.BUY, StrategyName: "always-buy"}
+}
+
+func TestCheckReportsViolations(t *testing.T) {
+	failures := strategytest.Check(func(clock.Clock) strategy.TradingStrategy { return alwaysBuy{} })
+
+	checks := make(map[string]bool)
+	for _, failure := range failures {
+		checks[failure.Check] = true
+	}
+	for _, want := range []string{"empty", "short", "nan"} {
+		if !checks[want] {
+			t.Errorf("failures = %v, want a %q violation", failures, want)
+		}
+	}
+}
+
+func TestCheckGoldenDetectsDrift(t *testing.T) {
+	cases, err := strategytest.LoadCases(testdata)
+	if err != nil {
+		t.Fatalf("LoadCases: %v", err)
+	}
+	covered := make(map[string]bool)
+	for _, c := range cases {
+		covered[c.Config.Strategy] = true
+	}
+	for _, name := range strategy.Names() {
+		if !covered[name] {
+			t.Errorf("strategy %q has no golden case", name)
+		}
+	}
+
+	// 골든 파일과 신호가 한 줄이라도 다르면 실패합니다
+	dir := t.TempDir()
+	if err := os.CopyFS(dir, os.DirFS(testdata)); err != nil {
+		t.Fatalf("copy testdata: %v", err)
+	}
+	path := filepath.Join(dir, "golden", cases[0].Name+".golden")
+	golden, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden: %v", err)
+	}
+	lines := strings.SplitN(string(golden), "\n", 2)
+	if err := os.WriteFile(path, []byte("2000-01-01T00:00:00 HOLD drift 0\n"+lines[len(lines)-1]), 0o644); err != nil {
+		t.Fatalf("write golden: %v", err)
+	}
+	failures := strategytest.CheckGolden(dir, false)
+	if len(failures) != 1 || !strings.Contains(failures[0].Message, cases[0].Name) {
+		t.Errorf("failures = %v, want one drift in %s", failures, cases[0].Name)
+	}
+}
@@ -1,9 +1,12 @@
 package strategy
 
-import "go-trading-bot/internal/model"
+import (
+	"context"
+	"go-trading-bot/internal/model"
+)
 
 type TradingStrategy interface {
 	GetName() string
-	Analyze(market string, candles []model.Candle) model.Signal
+	Analyze(ctx context.Context, market string, candles []model.Candle) model.Signal
 	GetRequiredCandleCount() int
 }
@@ -0,0 +1,102 @@
+package telegram
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// PollTimeout은 getUpdates long-polling 대기 시간입니다
+const PollTimeout = 30 * time.Second
+
+// Client는 텔레그램 Bot API 클라이언트입니다. BaseURL을 바꾸면 로컬 대체 서버로 요청할 수 있습니다
+type Client struct {
+	BaseURL    string // 예: https://api.telegram.org
+	Token      string
+	HTTPClient *http.Client
+}
+
+// NewClient는 새로운 Client를 생성합니다. HTTP 타임아웃은 getUpdates long-polling 시간보다 길어야 합니다
+func NewClient(baseURL, token string) *Client {
+	return &Client{
+		BaseURL:    baseURL,
+		Token:      token,
+		HTTPClient: &http.Client{Timeout: 2*PollTimeout + 10*time.Second},
+	}
+}
+
+// GetUpdates는 offset 이후의 업데이트를 long-polling으로 조회합니다
+func (c *Client) GetUpdates(offset int64, timeout time.Duration) ([]Update, error) {
+	var updates []Update
+	err := c.call("getUpdates", map[string]any{
+		"offset":          offset,
+		"timeout":         int(timeout.Seconds()),
+		"allowed_updates": []string{"message", "callback_query"},
+	}, &updates)
+	return updates, err
+}
+
+// SendMessage는 HTML 형식의 메시지를 전송합니다. keyboard가 있으면 인라인 키보드를 함께 보냅니다
+func (c *Client) SendMessage(chatID int64, text string, keyboard *InlineKeyboardMarkup) error {
+	params := map[string]any{
+		"chat_id":    chatID,
+		"text":       text,
+		"parse_mode": "HTML",
+	}
+	if keyboard != nil {
+		params["reply_markup"] = keyboard
+	}
+	return c.call("sendMessage", params, nil)
+}
+
+// EditMessageText는 전송한 메시지의 내용을 바꾸고 인라인 키보드를 제거합니다
+func (c *Client) EditMessageText(chatID, messageID int64, text string) error {
+	return c.call("editMessageText", map[string]any{
+		"chat_id":    chatID,
+		"message_id": messageID,
+		"text":       text,
+		"parse_mode": "HTML",
+	}, nil)
+}
+
+// AnswerCallbackQuery는 인라인 키보드 버튼 입력에 응답합니다
+func (c *Client) AnswerCallbackQuery(callbackQueryID, text string) error {
+	return c.call("answerCallbackQuery", map[string]any{
+		"callback_query_id": callbackQueryID,
+		"text":              text,
+	}, nil)
+}
+
+func (c *Client) call(method string, params map[string]any, result any) error {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/bot%s/%s", c.BaseURL, c.Token, method)
+	resp, err := c.HTTPClient.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		// 오류 메시지에 토큰이 포함된 URL이 노출되지 않도록 메서드 이름만 남깁니다
+		return fmt.Errorf("telegram %s: request failed", method)
+	}
+	defer resp.Body.Close()
+
+	var response struct {
+		apiResponse
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return fmt.Errorf("telegram %s: invalid response (status %d): %w", method, resp.StatusCode, err)
+	}
+	if !response.OK {
+		return fmt.Errorf("telegram %s: %d %s", method, response.ErrorCode, response.Description)
+	}
+	if result != nil {
+		if err := json.Unmarshal(response.Result, result); err != nil {
+			return fmt.Errorf("telegram %s: invalid result: %w", method, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,400 @@
+package telegram
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"go-trading-bot/internal/clock"
+	"go-trading-bot/internal/logger"
+	"go-trading-bot/internal/model"
+	"go-trading-bot/internal/scheduler"
+	"html"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+const (
+	confirmTimeout   = time.Minute     // /sell 확인 버튼 유효 시간
+	staleCommandAge  = 5 * time.Minute // 이보다 오래된 명령은 처리하지 않음 (재시작 직후 밀린 명령 방지)
+	pollErrorBackoff = 5 * time.Second // getUpdates 실패 시 재시도 대기
+	callbackConfirm  = "confirm:"
+	callbackCancel   = "cancel:"
+)
+
+// CommandService는 텔레그램 명령이 사용하는 TradingBot 기능입니다
+type CommandService interface {
+	GetBotStatus() model.BotStatus
+	GetPositionSummaries() []model.PositionSummary
+	GetLatestSignal(market string) model.Signal
+	GetPnLSummary(filter model.OrderFilter) model.PnLSummary
+	Pause(actor string)
+	Resume(actor string)
+	TriggerCycle(actor string) error
+	ClosePosition(actor, market string) (*model.Order, error)
+}
+
+// pendingSell은 확인 버튼을 기다리는 매도 명령입니다
+type pendingSell struct {
+	chatID    int64
+	market    string
+	expiresAt time.Time
+}
+
+// CommandBot은 getUpdates long-polling으로 텔레그램 명령을 받아 처리합니다.
+// 허용된 채팅 ID의 명령만 처리하며, /sell 같은 위험한 명령은 인라인 키보드로 확인을 받습니다
+type CommandBot struct {
+	client       *Client
+	service      CommandService
+	allowedChats map[int64]bool
+	clock        clock.Clock
+	offset       int64
+
+	mu      sync.Mutex
+	pending map[string]pendingSell
+}
+
+// NewCommandBot은 새로운 CommandBot을 생성합니다. 명령 유효 시간과 /pnl 기간은 clk 기준으로 판단합니다
+func NewCommandBot(client *Client, service CommandService, allowedChatIDs []int64, clk clock.Clock) *CommandBot {
+	allowedChats := make(map[int64]bool, len(allowedChatIDs))
+	for _, id := range allowedChatIDs {
+		allowedChats[id] = true
+	}
+	return &CommandBot{
+		client:       client,
+		service:      service,
+		allowedChats: allowedChats,
+		clock:        clk,
+		pending:      make(map[string]pendingSell),
+	}
+}
+
+// ParseChatIDs는 쉼표로 구분된 채팅 ID 목록을 파싱합니다. 숫자가 아닌 항목은 무시합니다
+func ParseChatIDs(value string) []int64 {
+	var ids []int64
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(item, 10, 64)
+		if err != nil {
+			logger.Log.Errorf("텔레그램 채팅 ID %q가 올바르지 않습니다. 무시합니다. 🔴", item)
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// Run은 stopChan이 닫힐 때까지 업데이트를 받아 처리합니다
+func (b *CommandBot) Run(stopChan <-chan struct{}) {
+	logger.Log.Infof("텔레그램 명령 처리를 시작합니다. (허용 채팅 %d개) 🟢", len(b.allowedChats))
+	for {
+		select {
+		case <-stopChan:
+			return
+		default:
+		}
+
+		updates, err := b.client.GetUpdates(b.offset, PollTimeout)
+		if err != nil {
+			logger.Log.Errorf("텔레그램 업데이트 조회 실패: %v 🔴", err)
+			select {
+			case <-stopChan:
+				return
+			case <-time.After(pollErrorBackoff):
+			}
+			continue
+		}
+
+		for _, update := range updates {
+			b.offset = update.UpdateID + 1
+			b.HandleUpdate(update)
+		}
+	}
+}
+
+// HandleUpdate는 업데이트 하나를 처리합니다
+func (b *CommandBot) HandleUpdate(update Update) {
+	switch {
+	case update.CallbackQuery != nil:
+		b.handleCallback(update.CallbackQuery)
+	case update.Message != nil:
+		b.handleMessage(update.Message)
+	}
+}
+
+func (b *CommandBot) handleMessage(msg *Message) {
+	if !strings.HasPrefix(msg.Text, "/") {
+		return
+	}
+	if !b.allowedChats[msg.Chat.ID] {
+		logger.Log.Warnf("허용되지 않은 채팅의 텔레그램 명령을 무시합니다. (chat: %d, text: %q) 🟠", msg.Chat.ID, msg.Text)
+		return
+	}
+	if b.clock.Now().Sub(time.Unix(msg.Date, 0)) > staleCommandAge {
+		logger.Log.Warnf("오래된 텔레그램 명령을 무시합니다. (chat: %d, text: %q) 🟠", msg.Chat.ID, msg.Text)
+		return
+	}
+
+	fields := strings.Fields(msg.Text)
+	// 그룹 채팅에서는 /status@BotName 형식으로 전달됩니다
+	command, _, _ := strings.Cut(fields[0], "@")
+	args := fields[1:]
+	actor := messageActor(msg)
+	logger.Log.Infof("텔레그램 명령 수신: %v %v (%v)", command, args, actor)
+
+	var reply string
+	var keyboard *InlineKeyboardMarkup
+	switch command {
+	case "/start", "/help":
+		reply = helpMessage()
+	case "/status":
+		reply = formatStatus(b.service.GetBotStatus())
+	case "/positions":
+		reply = formatPositions(b.service.GetPositionSummaries())
+	case "/signal":
+		reply = b.signal(args)
+	case "/pnl":
+		reply = b.pnl(args)
+	case "/pause":
+		b.service.Pause(actor)
+		reply = "⏸️ 신규 진입을 일시정지했습니다."
+	case "/resume":
+		b.service.Resume(actor)
+		reply = "▶️ 신규 진입을 재개했습니다."
+	case "/run":
+		if err := b.service.TriggerCycle(actor); err != nil {
+			reply = "⚠️ 분석 사이클을 실행할 수 없습니다: " + html.EscapeString(err.Error())
+		} else {
+			reply = "🔄 분석 사이클을 실행합니다."
+		}
+	case "/sell":
+		reply, keyboard = b.requestSell(msg.Chat.ID, args)
+	default:
+		reply = "알 수 없는 명령입니다.\n\n" + helpMessage()
+	}
+
+	if err := b.client.SendMessage(msg.Chat.ID, reply, keyboard); err != nil {
+		logger.Log.Errorf("텔레그램 응답 전송 실패: %v 🔴", err)
+	}
+}
+
+func (b *CommandBot) signal(args []string) string {
+	if len(args) == 0 {
+		return "사용법: /signal KRW-BTC"
+	}
+	market := normalizeMarket(args[0])
+	signal := b.service.GetLatestSignal(market)
+	if signal.Market == "" {
+		return fmt.Sprintf("[%s] 신호가 없습니다.", html.EscapeString(market))
+	}
+	return formatSignal(signal)
+}
+
+func (b *CommandBot) pnl(args []string) string {
+	period := "all"
+	if len(args) > 0 {
+		period = strings.ToLower(args[0])
+	}
+
+	// 일자별 손익 집계와 같이 KST 자정을 하루의 시작으로 봅니다
+	now := b.clock.Now().In(scheduler.KST)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, scheduler.KST)
+	var filter model.OrderFilter
+	switch period {
+	case "today":
+		filter.From = today
+	case "week":
+		filter.From = today.AddDate(0, 0, -6)
+	case "month":
+		filter.From = today.AddDate(0, 0, -29)
+	case "all":
+	default:
+		return "사용법: /pnl [today|week|month|all]"
+	}
+	return formatPnL(period, b.service.GetPnLSummary(filter))
+}
+
+// requestSell은 매도 확인 메시지와 인라인 키보드를 만듭니다
+func (b *CommandBot) requestSell(chatID int64, args []string) (string, *InlineKeyboardMarkup) {
+	if len(args) == 0 {
+		return "사용법: /sell KRW-BTC", nil
+	}
+	market := normalizeMarket(args[0])
+
+	token := newToken()
+	b.mu.Lock()
+	now := b.clock.Now()
+	for key, p := range b.pending {
+		if now.After(p.expiresAt) {
+			delete(b.pending, key)
+		}
+	}
+	b.pending[token] = pendingSell{chatID: chatID, market: market, expiresAt: now.Add(confirmTimeout)}
+	b.mu.Unlock()
+
+	keyboard := &InlineKeyboardMarkup{InlineKeyboard: [][]InlineKeyboardButton{{
+		{Text: "✅ 매도", CallbackData: callbackConfirm + token},
+		{Text: "❌ 취소", CallbackData: callbackCancel + token},
+	}}}
+	reply := fmt.Sprintf("⚠️ <b>[%s] 포지션을 현재가로 매도할까요?</b>\n%d초 안에 선택하세요.", html.EscapeString(market), int(confirmTimeout.Seconds()))
+	return reply, keyboard
+}
+
+func (b *CommandBot) handleCallback(query *CallbackQuery) {
+	if query.Message == nil || !b.allowedChats[query.Message.Chat.ID] {
+		logger.Log.Warnf("허용되지 않은 채팅의 텔레그램 버튼 입력을 무시합니다. (user: %d) 🟠", query.From.ID)
+		_ = b.client.AnswerCallbackQuery(query.ID, "허용되지 않은 채팅입니다.")
+		return
+	}
+	chatID := query.Message.Chat.ID
+
+	var token string
+	confirm := false
+	switch {
+	case strings.HasPrefix(query.Data, callbackConfirm):
+		token, confirm = strings.TrimPrefix(query.Data, callbackConfirm), true
+	case strings.HasPrefix(query.Data, callbackCancel):
+		token = strings.TrimPrefix(query.Data, callbackCancel)
+	default:
+		_ = b.client.AnswerCallbackQuery(query.ID, "")
+		return
+	}
+
+	b.mu.Lock()
+	p, exists := b.pending[token]
+	delete(b.pending, token)
+	b.mu.Unlock()
+
+	var result string
+	switch {
+	case !exists || p.chatID != chatID || b.clock.Now().After(p.expiresAt):
+		result = "⌛ 만료된 요청입니다. /sell 명령을 다시 입력하세요."
+	case !confirm:
+		result = fmt.Sprintf("❌ [%s] 매도를 취소했습니다.", html.EscapeString(p.market))
+	default:
+		actor := fmt.Sprintf("telegram:%d@%d", query.From.ID, chatID)
+		order, err := b.service.ClosePosition(actor, p.market)
+		if err != nil {
+			result = fmt.Sprintf("⚠️ [%s] 매도 실패: %s", html.EscapeString(p.market), html.EscapeString(err.Error()))
+		} else {
+			result = fmt.Sprintf("✅ [%s] 매도 완료\n수량: %f, 가격: %.0f, 손익: %.0f", html.EscapeString(p.market), order.Quantity, order.Price, order.Profit)
+		}
+	}
+
+	if err := b.client.AnswerCallbackQuery(query.ID, ""); err != nil {
+		logger.Log.Errorf("텔레그램 버튼 응답 실패: %v 🔴", err)
+	}
+	if err := b.client.EditMessageText(chatID, query.Message.MessageID, result); err != nil {
+		logger.Log.Errorf("텔레그램 메시지 수정 실패: %v 🔴", err)
+	}
+}
+
+// messageActor는 감사 로그에 남길 명령 실행자(telegram:<user>@<chat>)를 반환합니다
+func messageActor(msg *Message) string {
+	user := "unknown"
+	if msg.From != nil {
+		user = strconv.FormatInt(msg.From.ID, 10)
+	}
+	return fmt.Sprintf("telegram:%s@%d", user, msg.Chat.ID)
+}
+
+// normalizeMarket은 btc, KRW-BTC 등을 KRW-BTC 형식으로 변환합니다
+func normalizeMarket(value string) string {
+	market := strings.ToUpper(value)
+	if !strings.Contains(market, "-") {
+		market = "KRW-" + market
+	}
+	return market
+}
+
+func newToken() string {
+	buf := make([]byte, 8)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+func helpMessage() string {
+	return "<b>🤖 명령어</b>\n" +
+		"/status - 봇 상태\n" +
+		"/positions - 보유 포지션\n" +
+		"/signal KRW-BTC - 최근 신호\n" +
+		"/pnl [today|week|month|all] - 실현 손익\n" +
+		"/pause - 신규 진입 일시정지\n" +
+		"/resume - 신규 진입 재개\n" +
+		"/run - 분석 사이클 즉시 실행\n" +
+		"/sell KRW-BTC - 포지션 매도 (확인 필요)"
+}
+
+func formatStatus(status model.BotStatus) string {
+	msg := "<b>📊 봇 상태</b>\n\n"
+	if status.Paused {
+		msg += "신규 진입: <b>일시정지</b>\n"
+	} else {
+		msg += "신규 진입: <b>실행 중</b>\n"
+	}
+	if status.EntryAllowed {
+		msg += "진입 가능: <b>예</b>\n"
+	} else {
+		msg += "진입 가능: <b>아니오</b>\n"
+	}
+	msg += fmt.Sprintf("마켓: %s\n", strings.Join(status.Markets, ", "))
+	msg += fmt.Sprintf("사이클: %d회", status.Cycle.CycleCount)
+	if status.Cycle.Running {
+		msg += " (실행 중)"
+	}
+	msg += "\n"
+	if !status.Cycle.EndedAt.IsZero() {
+		msg += fmt.Sprintf("최근 사이클: %s (%v)\n", status.Cycle.EndedAt.In(scheduler.KST).Format("2006-01-02 15:04:05"), status.Cycle.Duration)
+	}
+	if status.Cycle.LastError != "" {
+		msg += fmt.Sprintf("최근 오류: %s\n", html.EscapeString(status.Cycle.LastError))
+	}
+	return msg
+}
+
+func formatPositions(positions []model.PositionSummary) string {
+	if len(positions) == 0 {
+		return "📦 보유 포지션이 없습니다."
+	}
+
+	p := message.NewPrinter(language.Korean)
+	msg := fmt.Sprintf("<b>📦 보유 포지션 (%d)</b>\n", len(positions))
+	for _, position := range positions {
+		msg += p.Sprintf("\n<b>%s</b>\n수량: %f\n진입가: %.0f, 현재가: %.0f\n평가손익: %.0f (%.2f%%)\n",
+			html.EscapeString(position.Market), position.Quantity, position.EntryPrice, position.CurrentPrice,
+			position.UnrealizedProfit, position.UnrealizedProfitRate)
+	}
+	return msg
+}
+
+func formatSignal(signal model.Signal) string {
+	p := message.NewPrinter(language.Korean)
+	msg := fmt.Sprintf("<b>[%s] %s</b>\n\n", html.EscapeString(signal.Market), signal.Type)
+	msg += p.Sprintf("💰 현재가: %.0f\n", signal.CurrentPrice)
+	if signal.Stage != nil {
+		msg += fmt.Sprintf("📊 사이클 단계: <b>%s</b> (%s)\n", signal.Stage.StageNumber, signal.Stage.StageDir)
+	}
+	if signal.Description != "" {
+		msg += fmt.Sprintf("📝 %s\n", html.EscapeString(signal.Description))
+	}
+	msg += fmt.Sprintf("🎯 %s\n🕐 %s", html.EscapeString(signal.StrategyName), signal.Timestamp)
+	return msg
+}
+
+func formatPnL(period string, summary model.PnLSummary) string {
+	p := message.NewPrinter(language.Korean)
+	msg := fmt.Sprintf("<b>💰 실현 손익 (%s)</b>\n\n", period)
+	msg += p.Sprintf("총 손익: <b>%.0f</b>\n", summary.TotalProfit)
+	msg += fmt.Sprintf("거래: %d회, 수익 거래: %d회\n", summary.TradeCount, summary.WinCount)
+	for _, entry := range summary.ByMarket {
+		msg += p.Sprintf("\n%s: %.0f (%d회)", html.EscapeString(entry.Key), entry.Profit, entry.TradeCount)
+	}
+	return msg
+}
@@ -0,0 +1,296 @@
+package telegram_test
+
+import (
+	"go-trading-bot/config"
+	"go-trading-bot/internal/clock"
+	"go-trading-bot/internal/model"
+	"go-trading-bot/internal/service/servicetest"
+	"go-trading-bot/internal/telegram"
+	"go-trading-bot/internal/telegram/telegramtest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	allowedChat  = int64(1001)
+	strangerChat = int64(6666)
+)
+
+// now는 테스트 시계의 시작 시각입니다 (2025-01-08 수요일 01:30 KST)
+var now = time.Date(2025, 1, 7, 16, 30, 0, 0, time.UTC)
+
+// fakeService는 명령으로 호출된 기능을 기록하는 CommandService입니다
+type fakeService struct {
+	mu      sync.Mutex
+	calls   []string
+	signal  model.Signal
+	filters []model.OrderFilter
+}
+
+func (f *fakeService) record(call string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, call)
+}
+
+func (f *fakeService) Calls() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.calls...)
+}
+
+func (f *fakeService) GetBotStatus() model.BotStatus {
+	return model.BotStatus{Markets: []string{"KRW-BTC"}, Cycle: model.CycleStatus{LastError: "upbit <503> & retry"}}
+}
+func (f *fakeService) GetPositionSummaries() []model.PositionSummary { return nil }
+func (f *fakeService) GetLatestSignal(market string) model.Signal {
+	if market == f.signal.Market {
+		return f.signal
+	}
+	return model.Signal{}
+}
+func (f *fakeService) GetPnLSummary(filter model.OrderFilter) model.PnLSummary {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.filters = append(f.filters, filter)
+	return model.PnLSummary{}
+}
+func (f *fakeService) Pause(actor string)  { f.record("pause " + actor) }
+func (f *fakeService) Resume(actor string) { f.record("resume " + actor) }
+func (f *fakeService) TriggerCycle(actor string) error {
+	f.record("run " + actor)
+	return nil
+}
+func (f *fakeService) ClosePosition(actor, market string) (*model.Order, error) {
+	f.record("close " + market + " " + actor)
+	return &model.Order{Market: market, Quantity: 1, Price: 100}, nil
+}
+
+func newBot(t *testing.T, service telegram.CommandService) (*telegram.CommandBot, *telegramtest.Server, *clock.Fake) {
+	t.Helper()
+	server := telegramtest.NewServer("test-token")
+	t.Cleanup(server.Close)
+	client := telegram.NewClient(server.URL, "test-token")
+	clk := clock.NewFake(now)
+	return telegram.NewCommandBot(client, service, []int64{allowedChat}, clk), server, clk
+}
+
+func message(chatID int64, text string) telegram.Update {
+	return telegram.Update{Message: &telegram.Message{
+		MessageID: 1,
+		From:      &telegram.User{ID: chatID},
+		Chat:      telegram.Chat{ID: chatID, Type: "private"},
+		Date:      now.Unix(),
+		Text:      text,
+	}}
+}
+
+func callback(chatID int64, data string) telegram.Update {
+	return telegram.Update{CallbackQuery: &telegram.CallbackQuery{
+		ID:      "callback",
+		From:    telegram.User{ID: chatID},
+		Message: &telegram.Message{MessageID: 2, Chat: telegram.Chat{ID: chatID, Type: "private"}},
+		Data:    data,
+	}}
+}
+
+func TestCommandBotIgnoresUnauthorizedChat(t *testing.T) {
+	service := &fakeService{}
+	bot, server, _ := newBot(t, service)
+
+	for _, text := range []string{"/pause", "/run", "/status", "/sell KRW-BTC"} {
+		bot.HandleUpdate(message(strangerChat, text))
+	}
+	if calls := server.Calls(); len(calls) != 0 {
+		t.Errorf("replied to an unauthorized chat: %+v", calls)
+	}
+	if calls := service.Calls(); len(calls) != 0 {
+		t.Errorf("unauthorized chat reached the service: %v", calls)
+	}
+
+	bot.HandleUpdate(message(allowedChat, "/pause"))
+	calls := server.Calls()
+	if len(calls) != 1 || calls[0].Method != "sendMessage" || calls[0].Params["chat_id"] != float64(allowedChat) {
+		t.Fatalf("calls = %+v, want one reply to the allowed chat", calls)
+	}
+	if got := service.Calls(); len(got) != 1 || got[0] != "pause telegram:1001@1001" {
+		t.Errorf("service calls = %v, want pause by the allowed chat", got)
+	}
+}
+
+func TestCommandBotIgnoresStaleCommand(t *testing.T) {
+	service := &fakeService{}
+	bot, server, _ := newBot(t, service)
+
+	update := message(allowedChat, "/pause")
+	update.Message.Date = now.Add(-time.Hour).Unix()
+	bot.HandleUpdate(update)
+
+	if len(server.Calls()) != 0 || len(service.Calls()) != 0 {
+		t.Errorf("stale command was handled: %+v %v", server.Calls(), service.Calls())
+	}
+}
+
+func TestCommandBotSellConfirmationIsBoundToChat(t *testing.T) {
+	service := &fakeService{}
+	bot, server, _ := newBot(t, service)
+
+	bot.HandleUpdate(message(allowedChat, "/sell btc"))
+	calls := server.Calls()
+	if len(calls) != 1 {
+		t.Fatalf("calls = %+v, want the confirmation message", calls)
+	}
+	keyboard := calls[0].Params["reply_markup"].(map[string]any)["inline_keyboard"].([]any)[0].([]any)
+	confirm := keyboard[0].(map[string]any)["callback_data"].(string)
+	if !strings.HasPrefix(confirm, "confirm:") {
+		t.Fatalf("first button = %q, want a confirm button", confirm)
+	}
+
+	// 허용되지 않은 채팅에서 누른 버튼은 거부하고 요청을 소비하지 않습니다
+	bot.HandleUpdate(callback(strangerChat, confirm))
+	calls = server.Calls()
+	if last := calls[len(calls)-1]; last.Method != "answerCallbackQuery" || last.Params["text"] != "허용되지 않은 채팅입니다." {
+		t.Errorf("last call = %+v, want a rejected callback answer", last)
+	}
+	if got := service.Calls(); len(got) != 0 {
+		t.Fatalf("unauthorized confirmation reached the service: %v", got)
+	}
+
+	bot.HandleUpdate(callback(allowedChat, confirm))
+	if got := service.Calls(); len(got) != 1 || got[0] != "close KRW-BTC telegram:1001@1001" {
+		t.Fatalf("service calls = %v, want one close of KRW-BTC", got)
+	}
+
+	// 같은 확인 버튼은 한 번만 사용할 수 있습니다
+	bot.HandleUpdate(callback(allowedChat, confirm))
+	if got := service.Calls(); len(got) != 1 {
+		t.Errorf("confirmation was replayed: %v", got)
+	}
+	calls = server.Calls()
+	if last := calls[len(calls)-1]; last.Method != "editMessageText" || !strings.Contains(last.Params["text"].(string), "만료된 요청") {
+		t.Errorf("last call = %+v, want an expired message", last)
+	}
+}
+
+func TestCommandBotSellConfirmationExpires(t *testing.T) {
+	service := &fakeService{}
+	bot, server, clk := newBot(t, service)
+
+	bot.HandleUpdate(message(allowedChat, "/sell btc"))
+	calls := server.Calls()
+	keyboard := calls[0].Params["reply_markup"].(map[string]any)["inline_keyboard"].([]any)[0].([]any)
+	confirm := keyboard[0].(map[string]any)["callback_data"].(string)
+
+	// 확인 버튼은 1분 동안만 유효합니다
+	clk.Advance(time.Minute + time.Second)
+	bot.HandleUpdate(callback(allowedChat, confirm))
+	if got := service.Calls(); len(got) != 0 {
+		t.Fatalf("expired confirmation reached the service: %v", got)
+	}
+	calls = server.Calls()
+	if last := calls[len(calls)-1]; last.Method != "editMessageText" || !strings.Contains(last.Params["text"].(string), "만료된 요청") {
+		t.Errorf("last call = %+v, want an expired message", last)
+	}
+}
+
+func TestCommandBotPnLPeriodsStartAtKSTMidnight(t *testing.T) {
+	service := &fakeService{}
+	bot, _, _ := newBot(t, service)
+
+	for _, period := range []string{"today", "week", "month", "all"} {
+		bot.HandleUpdate(message(allowedChat, "/pnl "+period))
+	}
+
+	// 테스트 시각은 UTC로는 1월 7일이지만 KST로는 1월 8일입니다
+	kst := time.FixedZone("KST", 9*60*60)
+	wants := []time.Time{
+		time.Date(2025, 1, 8, 0, 0, 0, 0, kst),
+		time.Date(2025, 1, 2, 0, 0, 0, 0, kst),
+		time.Date(2024, 12, 10, 0, 0, 0, 0, kst),
+		{},
+	}
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	if len(service.filters) != len(wants) {
+		t.Fatalf("filters = %+v, want %d", service.filters, len(wants))
+	}
+	for i, want := range wants {
+		if got := service.filters[i].From; !got.Equal(want) {
+			t.Errorf("filter %d From = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestCommandBotSellClosesLivePosition(t *testing.T) {
+	h := servicetest.New(&config.TradingConfig{
+		Markets:            []string{"BTC"},
+		Strategy:           "moving-average-cross",
+		Candle:             config.Candle{Category: "minutes", Unit: 240},
+		MovingAverageCross: config.MovingAverageCross{ShortPeriod: 2, LongPeriod: 4},
+		OrderAmount:        1000000,
+		LiveTrading:        true,
+	}, now)
+	h.Config.Config().AccessKey = "test-access-key"
+	h.Config.Config().SecretKey = "test-secret-key"
+	h.SetPrices("KRW-BTC", 10, 10, 10, 10, 10, 20)
+	if _, err := h.Bot.PlaceManualOrder("test", "KRW-BTC", model.BUY, 0); err != nil {
+		t.Fatalf("buy: %v", err)
+	}
+
+	server := telegramtest.NewServer("test-token")
+	t.Cleanup(server.Close)
+	bot := telegram.NewCommandBot(telegram.NewClient(server.URL, "test-token"), h.Bot, []int64{allowedChat}, h.Clock)
+
+	// 실거래 모드에서도 /sell 확인은 업비트 매도 주문으로 이어집니다
+	bot.HandleUpdate(message(allowedChat, "/sell btc"))
+	keyboard := server.Calls()[0].Params["reply_markup"].(map[string]any)["inline_keyboard"].([]any)[0].([]any)
+	bot.HandleUpdate(callback(allowedChat, keyboard[0].(map[string]any)["callback_data"].(string)))
+
+	calls := server.Calls()
+	if last := calls[len(calls)-1]; last.Method != "editMessageText" || !strings.Contains(last.Params["text"].(string), "[KRW-BTC] 매도 완료") {
+		t.Fatalf("last call = %+v, want a completed sell", last)
+	}
+	orders := h.Exchange.Orders()
+	if len(orders) != 2 || orders[1].Side != model.UPBIT_SIDE_ASK || orders[1].Volume != "50000" {
+		t.Errorf("exchange orders = %+v, want the position sold on the exchange", orders)
+	}
+}
+
+func TestCommandBotEscapesHTML(t *testing.T) {
+	service := &fakeService{signal: model.Signal{
+		Market:       "KRW-BTC",
+		Type:         model.BUY,
+		StrategyName: "cross<ma>",
+		Description:  `<script>alert("x")</script> & more`,
+	}}
+	bot, server, _ := newBot(t, service)
+
+	bot.HandleUpdate(message(allowedChat, "/signal btc"))
+	bot.HandleUpdate(message(allowedChat, "/signal <i>eth"))
+	bot.HandleUpdate(message(allowedChat, "/status"))
+
+	calls, ok := server.WaitForCalls(3, time.Second)
+	if !ok {
+		t.Fatalf("calls = %+v, want 3 replies", calls)
+	}
+	wants := [][]string{
+		{"&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt; &amp; more", "cross&lt;ma&gt;"},
+		{"[KRW-&lt;I&gt;ETH]"},
+		{"upbit &lt;503&gt; &amp; retry"},
+	}
+	for i, want := range wants {
+		text := calls[i].Params["text"].(string)
+		for _, fragment := range want {
+			if !strings.Contains(text, fragment) {
+				t.Errorf("reply %d = %q, want escaped %q", i, text, fragment)
+			}
+		}
+		for _, raw := range []string{"<script>", "<ma>", "<I>", "<503>"} {
+			if strings.Contains(text, raw) {
+				t.Errorf("reply %d contains unescaped %q: %q", i, raw, text)
+			}
+		}
+	}
+}
@@ -0,0 +1,175 @@
+// Package telegramtest는 텔레그램 Bot API를 대신하는 로컬 서버입니다.
+// telegram.Client의 BaseURL을 Server.URL로 지정하면 실제 텔레그램 없이 명령 처리를 검증할 수 있습니다
+package telegramtest
+
+import (
+	"encoding/json"
+	"go-trading-bot/internal/telegram"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Call은 서버가 받은 Bot API 호출입니다
+type Call struct {
+	Method string
+	Params map[string]any
+}
+
+// Server는 getUpdates, sendMessage, editMessageText, answerCallbackQuery를 흉내 내는 서버입니다
+type Server struct {
+	URL   string
+	Token string
+
+	server  *httptest.Server
+	mu      sync.Mutex
+	updates []telegram.Update
+	nextID  int64
+	calls   []Call
+	notify  chan struct{}
+	closed  chan struct{}
+}
+
+// NewServer는 token으로만 요청을 받는 서버를 시작합니다
+func NewServer(token string) *Server {
+	s := &Server{Token: token, nextID: 1, notify: make(chan struct{}, 1), closed: make(chan struct{})}
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+	s.URL = s.server.URL
+	return s
+}
+
+// Close는 대기 중인 getUpdates를 끝내고 서버를 종료합니다
+func (s *Server) Close() {
+	close(s.closed)
+	s.server.Close()
+}
+
+// PushUpdate는 getUpdates로 전달될 업데이트를 추가합니다. update_id는 자동으로 부여됩니다
+func (s *Server) PushUpdate(update telegram.Update) {
+	s.mu.Lock()
+	update.UpdateID = s.nextID
+	s.nextID++
+	s.updates = append(s.updates, update)
+	s.mu.Unlock()
+	s.signal()
+}
+
+// PushMessage는 chatID 채팅에서 보낸 텍스트 메시지를 추가합니다
+func (s *Server) PushMessage(chatID int64, text string) {
+	s.PushUpdate(telegram.Update{Message: &telegram.Message{
+		MessageID: time.Now().UnixNano(),
+		From:      &telegram.User{ID: chatID},
+		Chat:      telegram.Chat{ID: chatID, Type: "private"},
+		Date:      time.Now().Unix(),
+		Text:      text,
+	}})
+}
+
+// PushCallback은 인라인 키보드 버튼 입력을 추가합니다
+func (s *Server) PushCallback(chatID, messageID int64, data string) {
+	s.PushUpdate(telegram.Update{CallbackQuery: &telegram.CallbackQuery{
+		ID:      time.Now().Format(time.RFC3339Nano),
+		From:    telegram.User{ID: chatID},
+		Message: &telegram.Message{MessageID: messageID, Chat: telegram.Chat{ID: chatID, Type: "private"}},
+		Data:    data,
+	}})
+}
+
+// Calls는 getUpdates를 제외하고 지금까지 받은 호출을 반환합니다
+func (s *Server) Calls() []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Call(nil), s.calls...)
+}
+
+// WaitForCalls는 getUpdates를 제외한 호출이 n개 이상 쌓일 때까지 기다립니다
+func (s *Server) WaitForCalls(n int, timeout time.Duration) ([]Call, bool) {
+	deadline := time.Now().Add(timeout)
+	for {
+		calls := s.Calls()
+		if len(calls) >= n {
+			return calls, true
+		}
+		if time.Now().After(deadline) {
+			return calls, false
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func (s *Server) signal() {
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/bot")
+	token, method, ok := strings.Cut(path, "/")
+	if !ok || token != s.Token {
+		writeJSON(w, http.StatusUnauthorized, map[string]any{"ok": false, "error_code": 401, "description": "Unauthorized"})
+		return
+	}
+
+	params := make(map[string]any)
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]any{"ok": false, "error_code": 400, "description": "Bad Request: " + err.Error()})
+		return
+	}
+
+	switch method {
+	case "getUpdates":
+		writeJSON(w, http.StatusOK, map[string]any{"ok": true, "result": s.getUpdates(params)})
+	case "sendMessage", "editMessageText", "answerCallbackQuery":
+		s.mu.Lock()
+		s.calls = append(s.calls, Call{Method: method, Params: params})
+		s.mu.Unlock()
+
+		var result any = true
+		if method == "sendMessage" {
+			text, _ := params["text"].(string)
+			result = telegram.Message{MessageID: time.Now().UnixNano(), Date: time.Now().Unix(), Text: text}
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"ok": true, "result": result})
+	default:
+		writeJSON(w, http.StatusNotFound, map[string]any{"ok": false, "error_code": 404, "description": "Not Found: method not found"})
+	}
+}
+
+// getUpdates는 offset 이상의 업데이트를 반환하며, 없으면 timeout까지 새 업데이트를 기다립니다
+func (s *Server) getUpdates(params map[string]any) []telegram.Update {
+	offset, _ := params["offset"].(float64)
+	timeout, _ := params["timeout"].(float64)
+	deadline := time.After(time.Duration(timeout) * time.Second)
+
+	for {
+		s.mu.Lock()
+		var result []telegram.Update
+		for _, update := range s.updates {
+			if update.UpdateID >= int64(offset) {
+				result = append(result, update)
+			}
+		}
+		s.mu.Unlock()
+
+		if len(result) > 0 {
+			return result
+		}
+		select {
+		case <-s.notify:
+		case <-deadline:
+			return []telegram.Update{}
+		case <-s.closed:
+			return []telegram.Update{}
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
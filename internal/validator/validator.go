@@ -0,0 +1,482 @@
+// Package validator
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-trading-bot/config"
+	"go-trading-bot/internal/i18n"
+	"go-trading-bot/internal/logger"
+	"go-trading-bot/internal/notify"
+	"go-trading-bot/internal/scheduler"
+	"go-trading-bot/internal/strategy"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Upbit 원화 마켓 최소 주문 금액
+const minOrderAmount = 5000
+
+// API 키 secret 권장 최소 길이
+const minAPISecretLength = 16
+
+// placeholderMarkers는 예시 설정에 쓰인 비밀 값의 표식입니다. 이 값으로는 봇을 시작하지 않습니다
+var placeholderMarkers = []string{"change_me", "changeme", "your_", "placeholder"}
+
+func isPlaceholder(secret string) bool {
+	secret = strings.ToLower(secret)
+	for _, marker := range placeholderMarkers {
+		if strings.Contains(secret, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// Issue는 설정 검증에서 발견된 문제 하나를 나타냅니다
+type Issue struct {
+	Path    string // JSON 경로 (예: $.candle.unit) 또는 환경 변수 (예: env.ACCESS_KEY)
+	Message string
+	Fatal   bool // true면 봇을 시작할 수 없음
+}
+
+func (i Issue) String() string {
+	level := "WARN"
+	if i.Fatal {
+		level = "ERROR"
+	}
+	return fmt.Sprintf("[%s] %s: %s", level, i.Path, i.Message)
+}
+
+type Issues []Issue
+
+// HasFatal은 봇을 시작할 수 없는 문제가 있는지 반환합니다
+func (v Issues) HasFatal() bool {
+	for _, issue := range v {
+		if issue.Fatal {
+			return true
+		}
+	}
+	return false
+}
+
+func (v Issues) Error() string {
+	lines := make([]string, 0, len(v))
+	for _, issue := range v {
+		lines = append(lines, issue.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (v *Issues) fatal(path, format string, args ...any) {
+	*v = append(*v, Issue{Path: path, Message: fmt.Sprintf(format, args...), Fatal: true})
+}
+
+func (v *Issues) warn(path, format string, args ...any) {
+	*v = append(*v, Issue{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// ValidateFile은 설정 파일을 읽어 파싱하고 검증합니다. 파싱에 실패하면 nil 설정을 반환합니다
+func ValidateFile(path string, c *config.Config) (*config.TradingConfig, Issues) {
+	var issues Issues
+
+	file, err := os.ReadFile(path)
+	if err != nil {
+		issues.fatal(path, "설정 파일을 읽을 수 없습니다: %v", err)
+		return nil, issues
+	}
+
+	var tradingConfig config.TradingConfig
+	if err := json.Unmarshal(file, &tradingConfig); err != nil {
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &syntaxErr):
+			line, col := position(file, syntaxErr.Offset)
+			issues.fatal("$", "JSON 문법 오류 (line %d, column %d): %v", line, col, syntaxErr)
+		case errors.As(err, &typeErr):
+			issues.fatal("$."+typeErr.Field, "%v 타입이어야 합니다 (입력값: %v)", typeErr.Type, typeErr.Value)
+		default:
+			issues.fatal("$", "설정 파일을 파싱할 수 없습니다: %v", err)
+		}
+		return nil, issues
+	}
+
+	// 알 수 없는 키는 오타일 가능성이 높으므로 경고합니다
+	decoder := json.NewDecoder(bytes.NewReader(file))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&config.TradingConfig{}); err != nil && strings.Contains(err.Error(), "unknown field") {
+		issues.warn("$", "%v", err)
+	}
+
+	issues = append(issues, ValidateTradingConfig(&tradingConfig, c)...)
+	return &tradingConfig, issues
+}
+
+// ValidateTradingConfig는 파싱된 설정의 값을 검증합니다
+func ValidateTradingConfig(tc *config.TradingConfig, c *config.Config) Issues {
+	var issues Issues
+
+	validateMarkets(&issues, tc.Markets)
+	validateStrategy(&issues, tc)
+	validateCandle(&issues, tc.Candle)
+	validateScheduler(&issues, tc)
+	validateTradingWindow(&issues, "$.trading-window", tc.TradingWindow)
+
+	if tc.OrderAmount <= 0 {
+		issues.fatal("$.order-amount", "주문 금액은 0보다 커야 합니다 (입력값: %v)", tc.OrderAmount)
+	} else if tc.OrderAmount < minOrderAmount {
+		issues.warn("$.order-amount", "업비트 최소 주문 금액(%v원)보다 작습니다 (입력값: %v)", minOrderAmount, tc.OrderAmount)
+	}
+
+	validateRisk(&issues, tc)
+	validateNotification(&issues, tc.Notification)
+	validateAlertPolicy(&issues, tc.AlertPolicy)
+
+	switch tc.CycleOverlapPolicy {
+	case "", "skip", "queue":
+	default:
+		issues.fatal("$.cycle-overlap-policy", "skip 또는 queue 중 하나여야 합니다 (입력값: %q)", tc.CycleOverlapPolicy)
+	}
+
+	if c != nil {
+		validateSecrets(&issues, tc, c)
+		validateAlertMessage(&issues, c)
+		validateLogging(&issues, c)
+		if c.ShutdownTimeout <= 0 {
+			issues.warn("env.SHUTDOWN_TIMEOUT", "0보다 커야 합니다. 기본값(30초)을 사용합니다 (입력값: %v)", c.ShutdownTimeout)
+		}
+	}
+
+	return issues
+}
+
+func validateMarkets(issues *Issues, markets []string) {
+	if len(markets) == 0 {
+		issues.fatal("$.markets", "최소 하나의 마켓이 필요합니다")
+		return
+	}
+
+	seen := make(map[string]bool)
+	for i, m := range markets {
+		path := fmt.Sprintf("$.markets[%d]", i)
+		switch {
+		case strings.TrimSpace(m) == "":
+			issues.fatal(path, "마켓 이름이 비어 있습니다")
+		case strings.Contains(m, "-"):
+			issues.fatal(path, "KRW- 접두사 없이 자산 이름만 입력해야 합니다 (입력값: %q)", m)
+		case m != strings.ToUpper(m):
+			issues.warn(path, "마켓 이름은 대문자여야 합니다 (입력값: %q)", m)
+		}
+		if seen[m] {
+			issues.warn(path, "중복된 마켓입니다 (%q)", m)
+		}
+		seen[m] = true
+	}
+}
+
+func validateStrategy(issues *Issues, tc *config.TradingConfig) {
+	if !slices.Contains(strategy.Names(), tc.Strategy) {
+		issues.fatal("$.strategy", "알 수 없는 전략입니다 (입력값: %q, 지원: %v)", tc.Strategy, strings.Join(strategy.Names(), ", "))
+	}
+
+	// 선택된 전략의 파라미터 오류는 치명적, 그 외 전략은 경고로 처리합니다
+	cross := tc.MovingAverageCross
+	validatePeriods(issues, tc.Strategy == strategy.MOVING_AVERAGE_CROSS, "$.moving-average-cross",
+		[]string{"short-period", "long-period"}, []int{cross.ShortPeriod, cross.LongPeriod})
+
+	cycle := tc.MovingAverageCycle
+	validatePeriods(issues, tc.Strategy == strategy.MOVING_AVERAGE_CYCLE, "$.moving-average-cycle",
+		[]string{"short-period", "medium-period", "long-period"}, []int{cycle.ShortPeriod, cycle.MediumPeriod, cycle.LongPeriod})
+}
+
+func validatePeriods(issues *Issues, active bool, path string, names []string, periods []int) {
+	report := issues.warn
+	if active {
+		report = issues.fatal
+	}
+
+	for i, period := range periods {
+		if period <= 0 {
+			report(path+"."+names[i], "기간은 0보다 커야 합니다 (입력값: %v)", period)
+			return
+		}
+	}
+
+	for i := 1; i < len(periods); i++ {
+		if periods[i-1] >= periods[i] {
+			report(path+"."+names[i], "%s(%v)는 %s(%v)보다 커야 합니다", names[i], periods[i], names[i-1], periods[i-1])
+		}
+	}
+
+	// 업비트 캔들 조회는 최대 200개
+	if last := periods[len(periods)-1]; last+1 > 200 {
+		report(path+"."+names[len(names)-1], "필요한 캔들 수(%v)가 업비트 최대 조회 개수(200)를 초과합니다", last+1)
+	}
+}
+
+func validateCandle(issues *Issues, candle config.Candle) {
+	if !slices.Contains(config.CandleCategories, candle.Category) {
+		issues.fatal("$.candle.category", "%v 중 하나여야 합니다 (입력값: %q)", strings.Join(config.CandleCategories, ", "), candle.Category)
+		return
+	}
+
+	if candle.Category == "minutes" {
+		if !slices.Contains(config.MinuteUnits, candle.Unit) {
+			issues.fatal("$.candle.unit", "분봉 단위는 %v 중 하나여야 합니다 (입력값: %v)", config.MinuteUnits, candle.Unit)
+		}
+	} else if candle.Unit > 1 {
+		issues.warn("$.candle.unit", "%v 캔들에서는 unit이 사용되지 않습니다 (입력값: %v)", candle.Category, candle.Unit)
+	}
+}
+
+func validateScheduler(issues *Issues, tc *config.TradingConfig) {
+	sc := tc.Scheduler
+
+	switch sc.Type {
+	case "", scheduler.TYPE_INTERVAL:
+		if tc.AnalysisInterval <= 0 {
+			issues.fatal("$.analysis-interval", "분석 주기(분)는 0보다 커야 합니다 (입력값: %v)", tc.AnalysisInterval)
+		}
+	case scheduler.TYPE_CANDLE_CLOSE:
+		if sc.CandleCloseDelay < 0 {
+			issues.fatal("$.scheduler.candle-close-delay", "0 이상이어야 합니다 (입력값: %v)", sc.CandleCloseDelay)
+		}
+	case scheduler.TYPE_CRON:
+		if _, err := scheduler.ParseCron(sc.Cron, nil); err != nil {
+			issues.fatal("$.scheduler.cron", "%v", err)
+		}
+	default:
+		issues.fatal("$.scheduler.type", "interval, candle-close, cron 중 하나여야 합니다 (입력값: %q)", sc.Type)
+	}
+
+	if sc.Type != "" && sc.Type != scheduler.TYPE_INTERVAL && tc.AnalysisInterval <= 0 {
+		issues.warn("$.analysis-interval", "스케줄 생성 실패 시 사용할 분석 주기(분)가 0 이하입니다 (입력값: %v)", tc.AnalysisInterval)
+	}
+
+	for _, job := range []struct{ path, expr string }{
+		{"$.scheduler.report-cron", sc.ReportCron},
+		{"$.scheduler.maintenance-cron", sc.MaintenanceCron},
+	} {
+		if job.expr == "" {
+			continue
+		}
+		if _, err := scheduler.ParseCron(job.expr, nil); err != nil {
+			issues.fatal(job.path, "%v", err)
+		}
+	}
+
+	validateTimezone(issues, "$.scheduler.timezone", sc.Timezone)
+}
+
+func validateTradingWindow(issues *Issues, path string, tw config.TradingWindow) {
+	report := issues.warn
+	if tw.Enabled {
+		report = issues.fatal
+	}
+
+	for i, h := range tw.Hours {
+		if _, err := scheduler.ParseHourRange(h); err != nil {
+			report(fmt.Sprintf("%s.hours[%d]", path, i), "%v", err)
+		}
+	}
+	for i, d := range tw.Weekdays {
+		if _, err := scheduler.ParseWeekday(d); err != nil {
+			report(fmt.Sprintf("%s.weekdays[%d]", path, i), "%v", err)
+		}
+	}
+	for i, d := range tw.BlackoutDates {
+		if _, err := time.Parse("2006-01-02", d); err != nil {
+			report(fmt.Sprintf("%s.blackout-dates[%d]", path, i), "YYYY-MM-DD 형식이어야 합니다 (입력값: %q)", d)
+		}
+	}
+
+	validateTimezone(issues, path+".timezone", tw.Timezone)
+}
+
+func validateTimezone(issues *Issues, path, name string) {
+	if name == "" {
+		return
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		issues.warn(path, "타임존을 찾을 수 없어 KST를 사용합니다 (입력값: %q)", name)
+	}
+}
+
+func validateRisk(issues *Issues, tc *config.TradingConfig) {
+	risk := tc.Risk
+	if risk.MaxPositions < 0 {
+		issues.fatal("$.risk.max-positions", "0 이상이어야 합니다 (입력값: %v)", risk.MaxPositions)
+	}
+	if risk.MaxOrderAmount < 0 {
+		issues.fatal("$.risk.max-order-amount", "0 이상이어야 합니다 (입력값: %v)", risk.MaxOrderAmount)
+	} else if risk.MaxOrderAmount > 0 && tc.OrderAmount > risk.MaxOrderAmount {
+		issues.fatal("$.order-amount", "최대 주문 금액(risk.max-order-amount: %v)보다 큽니다 (입력값: %v)", risk.MaxOrderAmount, tc.OrderAmount)
+	}
+	if risk.StopLossPercent < 0 || risk.StopLossPercent >= 100 {
+		issues.fatal("$.risk.stop-loss-percent", "0 이상 100 미만이어야 합니다 (입력값: %v)", risk.StopLossPercent)
+	}
+	if risk.TakeProfitPercent < 0 {
+		issues.fatal("$.risk.take-profit-percent", "0 이상이어야 합니다 (입력값: %v)", risk.TakeProfitPercent)
+	}
+}
+
+func validateNotification(issues *Issues, notification config.Notification) {
+	for i, route := range notification.Routes {
+		path := fmt.Sprintf("$.notification.routes[%d]", i)
+		if !slices.Contains(notify.Sinks, route.Sink) {
+			issues.fatal(path+".sink", "%v 중 하나여야 합니다 (입력값: %q)", strings.Join(notify.Sinks, ", "), route.Sink)
+		}
+		for j, t := range route.Types {
+			if !slices.Contains(notify.Types, notify.Type(t)) {
+				issues.fatal(fmt.Sprintf("%s.types[%d]", path, j), "알 수 없는 알림 타입입니다 (입력값: %q)", t)
+			}
+		}
+		if route.MinSeverity != "" && !slices.Contains(notify.Severities, notify.Severity(route.MinSeverity)) {
+			issues.fatal(path+".min-severity", "info, warning, critical 중 하나여야 합니다 (입력값: %q)", route.MinSeverity)
+		}
+	}
+}
+
+func validateAlertPolicy(issues *Issues, policy config.AlertPolicy) {
+	quietHours := policy.QuietHours
+	validateTradingWindow(issues, "$.alert-policy.quiet-hours", config.TradingWindow{
+		Enabled:  quietHours.Enabled,
+		Timezone: quietHours.Timezone,
+		Hours:    quietHours.Hours,
+		Weekdays: quietHours.Weekdays,
+	})
+
+	if policy.DigestCron != "" {
+		if _, err := scheduler.ParseCron(policy.DigestCron, scheduler.KST); err != nil {
+			issues.fatal("$.alert-policy.digest-cron", "%v", err)
+		}
+	} else if notify.Rule(policy, notify.TYPE_HOLD).Mode == notify.MODE_DIGEST {
+		issues.warn("$.alert-policy.digest-cron", "요약 알림 주기가 없어 요약(digest)으로 보낸 알림이 전송되지 않습니다")
+	}
+
+	names := make([]string, 0, len(policy.Rules))
+	for name := range policy.Rules {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		rule := policy.Rules[name]
+		path := "$.alert-policy.rules." + name
+		if !slices.Contains(notify.Types, notify.Type(name)) {
+			issues.fatal(path, "알 수 없는 알림 타입입니다")
+		}
+		if rule.Mode != "" && !slices.Contains(notify.Modes, rule.Mode) {
+			issues.fatal(path+".mode", "%v 중 하나여야 합니다 (입력값: %q)", strings.Join(notify.Modes, ", "), rule.Mode)
+		}
+		if rule.QuietHours != "" && !slices.Contains(notify.QuietActions, rule.QuietHours) {
+			issues.fatal(path+".quiet-hours", "%v 중 하나여야 합니다 (입력값: %q)", strings.Join(notify.QuietActions, ", "), rule.QuietHours)
+		}
+	}
+}
+
+func validateSecrets(issues *Issues, tc *config.TradingConfig, c *config.Config) {
+	if tc.LiveTrading {
+		if c.AccessKey == "" {
+			issues.fatal("env.ACCESS_KEY", "실거래 모드(live-trading)에는 업비트 Access Key가 필요합니다")
+		}
+		if c.SecretKey == "" {
+			issues.fatal("env.SECRET_KEY", "실거래 모드(live-trading)에는 업비트 Secret Key가 필요합니다")
+		}
+		if isPlaceholder(c.AccessKey) || isPlaceholder(c.SecretKey) {
+			issues.fatal("env.ACCESS_KEY", "업비트 키가 .env.example의 예시 값입니다. 발급받은 키로 바꾸세요")
+		}
+	}
+
+	for _, entry := range strings.Split(c.APIKeys, ",") {
+		parts := strings.Split(strings.TrimSpace(entry), ":")
+		if len(parts) != 3 {
+			continue
+		}
+		if isPlaceholder(parts[1]) {
+			issues.fatal("env.API_KEYS", "API 키 %q의 secret이 예시 값입니다. 추측할 수 없는 값으로 바꾸세요", parts[0])
+		} else if len(parts[1]) < minAPISecretLength {
+			issues.warn("env.API_KEYS", "API 키 %q의 secret이 %d자보다 짧습니다", parts[0], minAPISecretLength)
+		}
+	}
+
+	if c.TelegramSend == "OK" {
+		if c.TelegramBotToken == "" {
+			issues.warn("env.TELEGRAM_BOT_TOKEN", "TELEGRAM_SEND=OK 이지만 봇 토큰이 없어 알림이 전송되지 않습니다")
+		}
+		if c.TelegramChatID == "" {
+			issues.warn("env.TELEGRAM_CHAT_ID", "TELEGRAM_SEND=OK 이지만 채팅 ID가 없어 알림이 전송되지 않습니다")
+		}
+	}
+
+	configured := make(map[string]bool)
+	for _, sink := range notify.SinksFromConfig(c) {
+		configured[sink.Name()] = true
+	}
+	for i, route := range tc.Notification.Routes {
+		// 텔레그램은 TELEGRAM_SEND로 켜고 끄므로 경고하지 않습니다
+		if route.Sink != notify.SINK_TELEGRAM && slices.Contains(notify.Sinks, route.Sink) && !configured[route.Sink] {
+			issues.warn(fmt.Sprintf("$.notification.routes[%d].sink", i), "%s 채널의 환경 변수가 설정되지 않아 알림이 전송되지 않습니다", route.Sink)
+		}
+	}
+
+	if c.TelegramCommand == "OK" {
+		if c.TelegramBotToken == "" {
+			issues.warn("env.TELEGRAM_BOT_TOKEN", "TELEGRAM_COMMAND=OK 이지만 봇 토큰이 없어 명령을 처리하지 않습니다")
+		}
+		if c.TelegramAllowedChatIDs == "" && c.TelegramChatID == "" {
+			issues.warn("env.TELEGRAM_ALLOWED_CHAT_IDS", "TELEGRAM_COMMAND=OK 이지만 허용된 채팅 ID가 없어 모든 명령이 거부됩니다")
+		}
+		for _, id := range strings.Split(c.TelegramAllowedChatIDs, ",") {
+			if id = strings.TrimSpace(id); id == "" {
+				continue
+			}
+			if _, err := strconv.ParseInt(id, 10, 64); err != nil {
+				issues.warn("env.TELEGRAM_ALLOWED_CHAT_IDS", "채팅 ID %q는 숫자가 아닙니다", id)
+			}
+		}
+	}
+}
+
+func validateAlertMessage(issues *Issues, c *config.Config) {
+	lang, err := i18n.ParseLanguage(c.AlertLanguage)
+	if err != nil {
+		issues.fatal("env.ALERT_LANGUAGE", "%s 중 하나여야 합니다 (입력값: %q)", strings.Join(i18n.Languages, ", "), c.AlertLanguage)
+		return
+	}
+	if _, err := i18n.NewRenderer(lang, c.AlertTemplateDir); err != nil {
+		issues.fatal("env.ALERT_TEMPLATE_DIR", "알림 템플릿을 읽을 수 없습니다: %v", err)
+	}
+}
+
+// validateLogging은 로그 설정을 확인합니다. 잘못된 값은 기본값으로 대체되므로 경고만 합니다
+func validateLogging(issues *Issues, c *config.Config) {
+	if !slices.Contains(logger.Formats, strings.ToLower(c.LogFormat)) {
+		issues.warn("env.LOG_FORMAT", "%s 중 하나여야 합니다 (입력값: %q)", strings.Join(logger.Formats, ", "), c.LogFormat)
+	}
+	if _, err := logger.ParseLevel(c.LogLevel); err != nil {
+		issues.warn("env.LOG_LEVEL", "trace, debug, info, warn, error 중 하나여야 합니다 (입력값: %q)", c.LogLevel)
+	}
+	if c.LogFile != "" && (c.LogMaxSize <= 0 || c.LogMaxBackups < 0 || c.LogMaxAge < 0) {
+		issues.warn("env.LOG_MAX_SIZE", "로그 파일 교체 설정이 올바르지 않습니다 (LOG_MAX_SIZE: %v, LOG_MAX_BACKUPS: %v, LOG_MAX_AGE: %v)", c.LogMaxSize, c.LogMaxBackups, c.LogMaxAge)
+	}
+}
+
+// position은 바이트 오프셋을 줄/열 번호로 변환합니다
+func position(data []byte, offset int64) (int, int) {
+	line, col := 1, 1
+	for i := int64(0); i < offset && i < int64(len(data)); i++ {
+		if data[i] == '\n' {
+			line++
+			col = 1
+		} else {
+			col++
+		}
+	}
+	return line, col
+}
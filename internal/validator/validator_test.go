@@ -0,0 +1,231 @@
+package validator_test
+
+import (
+	"go-trading-bot/config"
+	"go-trading-bot/internal/validator"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	fatal = true
+	warn  = false
+)
+
+// validConfig는 문제가 하나도 없는 설정입니다. 각 테스트 케이스는 여기서 한 항목만 바꿉니다
+func validConfig() (*config.TradingConfig, *config.Config) {
+	tc := &config.TradingConfig{
+		Markets:            []string{"BTC", "ETH"},
+		Strategy:           "moving-average-cross",
+		Candle:             config.Candle{Category: "minutes", Unit: 240},
+		MovingAverageCross: config.MovingAverageCross{ShortPeriod: 5, LongPeriod: 20},
+		MovingAverageCycle: config.MovingAverageCycle{ShortPeriod: 5, MediumPeriod: 20, LongPeriod: 40},
+		AnalysisInterval:   60,
+		OrderAmount:        1000000,
+		AlertPolicy:        config.AlertPolicy{DigestCron: "0 9 * * *"},
+	}
+	c := &config.Config{LogFormat: "text", LogLevel: "info", ShutdownTimeout: 30}
+	return tc, c
+}
+
+func TestValidConfigHasNoIssues(t *testing.T) {
+	tc, c := validConfig()
+	if issues := validator.ValidateTradingConfig(tc, c); len(issues) != 0 {
+		t.Errorf("issues = %v, want none", issues)
+	}
+}
+
+func TestValidateTradingConfigRules(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(tc *config.TradingConfig, c *config.Config)
+		path   string
+		fatal  bool
+	}{
+		// 마켓
+		{"no markets", func(tc *config.TradingConfig, c *config.Config) { tc.Markets = nil }, "$.markets", fatal},
+		{"empty market", func(tc *config.TradingConfig, c *config.Config) { tc.Markets = []string{"BTC", " "} }, "$.markets[1]", fatal},
+		{"prefixed market", func(tc *config.TradingConfig, c *config.Config) { tc.Markets = []string{"KRW-BTC"} }, "$.markets[0]", fatal},
+		{"lowercase market", func(tc *config.TradingConfig, c *config.Config) { tc.Markets = []string{"btc"} }, "$.markets[0]", warn},
+		{"duplicate market", func(tc *config.TradingConfig, c *config.Config) { tc.Markets = []string{"BTC", "BTC"} }, "$.markets[1]", warn},
+
+		// 전략과 파라미터: 선택한 전략은 치명적, 나머지 전략은 경고
+		{"unknown strategy", func(tc *config.TradingConfig, c *config.Config) { tc.Strategy = "rsi" }, "$.strategy", fatal},
+		{"non-positive period", func(tc *config.TradingConfig, c *config.Config) { tc.MovingAverageCross.ShortPeriod = 0 }, "$.moving-average-cross.short-period", fatal},
+		{"unordered periods", func(tc *config.TradingConfig, c *config.Config) { tc.MovingAverageCross.ShortPeriod = 30 }, "$.moving-average-cross.long-period", fatal},
+		{"too many candles", func(tc *config.TradingConfig, c *config.Config) { tc.MovingAverageCross.LongPeriod = 200 }, "$.moving-average-cross.long-period", fatal},
+		{"inactive strategy periods", func(tc *config.TradingConfig, c *config.Config) { tc.MovingAverageCycle.MediumPeriod = 50 }, "$.moving-average-cycle.long-period", warn},
+
+		// 캔들
+		{"unknown candle category", func(tc *config.TradingConfig, c *config.Config) { tc.Candle.Category = "hours" }, "$.candle.category", fatal},
+		{"unsupported minute unit", func(tc *config.TradingConfig, c *config.Config) { tc.Candle.Unit = 7 }, "$.candle.unit", fatal},
+		{"unused unit", func(tc *config.TradingConfig, c *config.Config) { tc.Candle = config.Candle{Category: "days", Unit: 3} }, "$.candle.unit", warn},
+
+		// 스케줄
+		{"no analysis interval", func(tc *config.TradingConfig, c *config.Config) { tc.AnalysisInterval = 0 }, "$.analysis-interval", fatal},
+		{"negative candle-close delay", func(tc *config.TradingConfig, c *config.Config) {
+			tc.Scheduler = config.Scheduler{Type: "candle-close", CandleCloseDelay: -1}
+		}, "$.scheduler.candle-close-delay", fatal},
+		{"invalid cron", func(tc *config.TradingConfig, c *config.Config) {
+			tc.Scheduler = config.Scheduler{Type: "cron", Cron: "* * *"}
+		}, "$.scheduler.cron", fatal},
+		{"unknown scheduler type", func(tc *config.TradingConfig, c *config.Config) { tc.Scheduler.Type = "weekly" }, "$.scheduler.type", fatal},
+		{"no fallback interval", func(tc *config.TradingConfig, c *config.Config) {
+			tc.Scheduler = config.Scheduler{Type: "cron", Cron: "0 * * * *"}
+			tc.AnalysisInterval = 0
+		}, "$.analysis-interval", warn},
+		{"invalid report cron", func(tc *config.TradingConfig, c *config.Config) { tc.Scheduler.ReportCron = "61 * * * *" }, "$.scheduler.report-cron", fatal},
+		{"invalid maintenance cron", func(tc *config.TradingConfig, c *config.Config) { tc.Scheduler.MaintenanceCron = "bad" }, "$.scheduler.maintenance-cron", fatal},
+		{"unknown scheduler timezone", func(tc *config.TradingConfig, c *config.Config) { tc.Scheduler.Timezone = "Mars/Olympus" }, "$.scheduler.timezone", warn},
+		{"overlap policy", func(tc *config.TradingConfig, c *config.Config) { tc.CycleOverlapPolicy = "drop" }, "$.cycle-overlap-policy", fatal},
+
+		// 거래 시간대: 켜져 있으면 치명적, 꺼져 있으면 경고
+		{"disabled window hours", func(tc *config.TradingConfig, c *config.Config) { tc.TradingWindow.Hours = []string{"9-18"} }, "$.trading-window.hours[0]", warn},
+		{"enabled window hours", func(tc *config.TradingConfig, c *config.Config) {
+			tc.TradingWindow = config.TradingWindow{Enabled: true, Hours: []string{"09:00-18:00", "25:00-26:00"}}
+		}, "$.trading-window.hours[1]", fatal},
+		{"enabled window weekdays", func(tc *config.TradingConfig, c *config.Config) {
+			tc.TradingWindow = config.TradingWindow{Enabled: true, Weekdays: []string{"FUNDAY"}}
+		}, "$.trading-window.weekdays[0]", fatal},
+		{"enabled window blackout", func(tc *config.TradingConfig, c *config.Config) {
+			tc.TradingWindow = config.TradingWindow{Enabled: true, BlackoutDates: []string{"2025/01/01"}}
+		}, "$.trading-window.blackout-dates[0]", fatal},
+		{"unknown window timezone", func(tc *config.TradingConfig, c *config.Config) { tc.TradingWindow.Timezone = "Mars/Olympus" }, "$.trading-window.timezone", warn},
+
+		// 주문 금액과 리스크
+		{"no order amount", func(tc *config.TradingConfig, c *config.Config) { tc.OrderAmount = 0 }, "$.order-amount", fatal},
+		{"below minimum order", func(tc *config.TradingConfig, c *config.Config) { tc.OrderAmount = 1000 }, "$.order-amount", warn},
+		{"order above max", func(tc *config.TradingConfig, c *config.Config) { tc.Risk.MaxOrderAmount = 500000 }, "$.order-amount", fatal},
+		{"negative max positions", func(tc *config.TradingConfig, c *config.Config) { tc.Risk.MaxPositions = -1 }, "$.risk.max-positions", fatal},
+		{"negative max order amount", func(tc *config.TradingConfig, c *config.Config) { tc.Risk.MaxOrderAmount = -1 }, "$.risk.max-order-amount", fatal},
+		{"stop loss 100%", func(tc *config.TradingConfig, c *config.Config) { tc.Risk.StopLossPercent = 100 }, "$.risk.stop-loss-percent", fatal},
+		{"negative take profit", func(tc *config.TradingConfig, c *config.Config) { tc.Risk.TakeProfitPercent = -1 }, "$.risk.take-profit-percent", fatal},
+
+		// 알림 라우팅
+		{"unknown sink", func(tc *config.TradingConfig, c *config.Config) {
+			tc.Notification.Routes = []config.NotificationRoute{{Sink: "pager"}}
+		}, "$.notification.routes[0].sink", fatal},
+		{"unknown route type", func(tc *config.TradingConfig, c *config.Config) {
+			tc.Notification.Routes = []config.NotificationRoute{{Sink: "telegram", Types: []string{"order", "trade"}}}
+		}, "$.notification.routes[0].types[1]", fatal},
+		{"unknown min severity", func(tc *config.TradingConfig, c *config.Config) {
+			tc.Notification.Routes = []config.NotificationRoute{{Sink: "telegram", MinSeverity: "error"}}
+		}, "$.notification.routes[0].min-severity", fatal},
+		{"unconfigured sink", func(tc *config.TradingConfig, c *config.Config) {
+			tc.Notification.Routes = []config.NotificationRoute{{Sink: "slack"}}
+		}, "$.notification.routes[0].sink", warn},
+
+		// 알림 정책
+		{"enabled quiet hours", func(tc *config.TradingConfig, c *config.Config) {
+			tc.AlertPolicy.QuietHours = config.QuietHours{Enabled: true, Hours: []string{"23:00"}}
+		}, "$.alert-policy.quiet-hours.hours[0]", fatal},
+		{"invalid digest cron", func(tc *config.TradingConfig, c *config.Config) { tc.AlertPolicy.DigestCron = "0 9 * *" }, "$.alert-policy.digest-cron", fatal},
+		{"digest without cron", func(tc *config.TradingConfig, c *config.Config) { tc.AlertPolicy.DigestCron = "" }, "$.alert-policy.digest-cron", warn},
+		{"unknown rule type", func(tc *config.TradingConfig, c *config.Config) {
+			tc.AlertPolicy.Rules = map[string]config.AlertRule{"trade": {}}
+		}, "$.alert-policy.rules.trade", fatal},
+		{"unknown rule mode", func(tc *config.TradingConfig, c *config.Config) {
+			tc.AlertPolicy.Rules = map[string]config.AlertRule{"signal": {Mode: "later"}}
+		}, "$.alert-policy.rules.signal.mode", fatal},
+		{"unknown rule quiet action", func(tc *config.TradingConfig, c *config.Config) {
+			tc.AlertPolicy.Rules = map[string]config.AlertRule{"risk": {QuietHours: "queue"}}
+		}, "$.alert-policy.rules.risk.quiet-hours", fatal},
+
+		// 환경 변수
+		{"live without access key", func(tc *config.TradingConfig, c *config.Config) {
+			tc.LiveTrading = true
+			c.SecretKey = "upbit-secret"
+		}, "env.ACCESS_KEY", fatal},
+		{"live without secret key", func(tc *config.TradingConfig, c *config.Config) {
+			tc.LiveTrading = true
+			c.AccessKey = "upbit-access"
+		}, "env.SECRET_KEY", fatal},
+		{"live with placeholder keys", func(tc *config.TradingConfig, c *config.Config) {
+			tc.LiveTrading = true
+			c.AccessKey, c.SecretKey = "your_access_key", "your_secret_key"
+		}, "env.ACCESS_KEY", fatal},
+		{"placeholder API secret", func(tc *config.TradingConfig, c *config.Config) { c.APIKeys = "ops:change_me:operator" }, "env.API_KEYS", fatal},
+		{"short API secret", func(tc *config.TradingConfig, c *config.Config) { c.APIKeys = "ops:short:operator" }, "env.API_KEYS", warn},
+		{"telegram without token", func(tc *config.TradingConfig, c *config.Config) {
+			c.TelegramSend, c.TelegramChatID = "OK", "1001"
+		}, "env.TELEGRAM_BOT_TOKEN", warn},
+		{"telegram without chat", func(tc *config.TradingConfig, c *config.Config) {
+			c.TelegramSend, c.TelegramBotToken = "OK", "token"
+		}, "env.TELEGRAM_CHAT_ID", warn},
+		{"commands without chats", func(tc *config.TradingConfig, c *config.Config) {
+			c.TelegramCommand, c.TelegramBotToken = "OK", "token"
+		}, "env.TELEGRAM_ALLOWED_CHAT_IDS", warn},
+		{"non-numeric chat id", func(tc *config.TradingConfig, c *config.Config) {
+			c.TelegramCommand, c.TelegramBotToken, c.TelegramAllowedChatIDs = "OK", "token", "1001,ops"
+		}, "env.TELEGRAM_ALLOWED_CHAT_IDS", warn},
+		{"unknown alert language", func(tc *config.TradingConfig, c *config.Config) { c.AlertLanguage = "fr" }, "env.ALERT_LANGUAGE", fatal},
+		{"missing template dir", func(tc *config.TradingConfig, c *config.Config) { c.AlertTemplateDir = "testdata/missing" }, "env.ALERT_TEMPLATE_DIR", fatal},
+		{"unknown log format", func(tc *config.TradingConfig, c *config.Config) { c.LogFormat = "xml" }, "env.LOG_FORMAT", warn},
+		{"unknown log level", func(tc *config.TradingConfig, c *config.Config) { c.LogLevel = "loud" }, "env.LOG_LEVEL", warn},
+		{"log rotation", func(tc *config.TradingConfig, c *config.Config) { c.LogFile = "bot.log" }, "env.LOG_MAX_SIZE", warn},
+		{"shutdown timeout", func(tc *config.TradingConfig, c *config.Config) { c.ShutdownTimeout = 0 }, "env.SHUTDOWN_TIMEOUT", warn},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc, c := validConfig()
+			tt.modify(tc, c)
+			issues := validator.ValidateTradingConfig(tc, c)
+			if len(issues) != 1 || issues[0].Path != tt.path || issues[0].Fatal != tt.fatal {
+				t.Errorf("issues = %v, want one %s issue (fatal: %v)", issues, tt.path, tt.fatal)
+			}
+			if issues.HasFatal() != tt.fatal {
+				t.Errorf("HasFatal = %v, want %v", issues.HasFatal(), tt.fatal)
+			}
+		})
+	}
+}
+
+func TestValidateFile(t *testing.T) {
+	tests := []struct {
+		name  string
+		json  string
+		path  string
+		fatal bool
+	}{
+		{"syntax error", "{\n  \"markets\": [\"BTC\",]\n}", "$", fatal},
+		{"type error", `{"order-amount": "1000000"}`, "$.order-amount", fatal},
+		{"unknown key", `{"markets": ["BTC"], "strategy": "moving-average-cross", "candle": {"category": "days"},
+			"moving-average-cross": {"short-period": 5, "long-period": 20},
+			"moving-average-cycle": {"short-period": 5, "medium-period": 20, "long-period": 40},
+			"analysis-interval": 60, "order-amount": 1000000, "alert-policy": {"digest-cron": "0 9 * * *"}, "ordr-amount": 1}`, "$", warn},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "application.json")
+			if err := os.WriteFile(path, []byte(tt.json), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			_, c := validConfig()
+			_, issues := validator.ValidateFile(path, c)
+			if len(issues) != 1 || issues[0].Path != tt.path || issues[0].Fatal != tt.fatal {
+				t.Errorf("issues = %v, want one %s issue (fatal: %v)", issues, tt.path, tt.fatal)
+			}
+		})
+	}
+
+	_, issues := validator.ValidateFile(filepath.Join(t.TempDir(), "missing.json"), nil)
+	if !issues.HasFatal() || !strings.HasSuffix(issues[0].Path, "missing.json") {
+		t.Errorf("missing file issues = %v, want a fatal issue for the file", issues)
+	}
+}
+
+// TestSyntaxErrorPosition은 JSON 문법 오류의 줄/열 번호를 확인합니다
+func TestSyntaxErrorPosition(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "application.json")
+	if err := os.WriteFile(path, []byte("{\n  \"markets\": [\"BTC\",]\n}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, issues := validator.ValidateFile(path, nil)
+	if len(issues) != 1 || !strings.Contains(issues[0].Message, "line 2, column 22") {
+		t.Errorf("issues = %v, want the error at line 2, column 22", issues)
+	}
+}